@@ -0,0 +1,223 @@
+package gitrim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// ApplyTextPatches applies the file patches to the tree and saves the new tree into s.
+// The tree and the blobs it contains must already be in s.
+func ApplyTextPatches(
+	ctx context.Context,
+	t *object.Tree,
+	filepatches []*TextFilePatch,
+	s storer.Storer,
+) (*object.Tree, error) {
+	editTree, err := newInflightTree(t)
+	if err != nil {
+		return nil, err
+	}
+
+	// new content of the files are calculated first, since the from files can be deleted or renamed.
+	type update struct {
+		path []string
+		mode filemode.FileMode
+		hash plumbing.Hash
+	}
+	updates := make([]update, 0, len(filepatches))
+
+	for _, fp := range filepatches {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		if fp.IsBinary() {
+			return nil, fmt.Errorf("%w: %s", ErrBinaryPatch, pathOfTextFilePatch(fp))
+		}
+
+		content := ""
+		if fp.from != nil {
+			f, err := t.File(fp.from.path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find %s in tree %s: %w", fp.from.path, t.Hash, err)
+			}
+			if fp.from.AbbreviatedHash != "" && !strings.HasPrefix(f.Hash.String(), fp.from.AbbreviatedHash) {
+				return nil, fmt.Errorf("%s has hash %s, but patch expects %s", fp.from.path, f.Hash, fp.from.AbbreviatedHash)
+			}
+			content, err = f.Contents()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", fp.from.path, err)
+			}
+		}
+
+		if fp.to == nil {
+			continue
+		}
+
+		newcontent, err := fp.ApplyToContent(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply patch to %s: %w", pathOfTextFilePatch(fp), err)
+		}
+
+		blob := s.NewEncodedObject()
+		blob.SetType(plumbing.BlobObject)
+		w, err := blob.Writer()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blob for %s: %w", fp.to.path, err)
+		}
+		if _, err := w.Write([]byte(newcontent)); err != nil {
+			w.Close()
+			return nil, fmt.Errorf("failed to write blob for %s: %w", fp.to.path, err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("failed to write blob for %s: %w", fp.to.path, err)
+		}
+		h, err := s.SetEncodedObject(blob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save blob for %s: %w", fp.to.path, err)
+		}
+		if fp.to.AbbreviatedHash != "" && !strings.HasPrefix(h.String(), fp.to.AbbreviatedHash) {
+			return nil, fmt.Errorf("patched %s has hash %s, but patch expects %s", fp.to.path, h, fp.to.AbbreviatedHash)
+		}
+
+		mode := fp.to.mode
+		if mode == filemode.Empty {
+			mode = filemode.Regular
+		}
+
+		updates = append(updates, update{path: strings.Split(fp.to.path, "/"), mode: mode, hash: h})
+	}
+
+	// delete files that are deleted or renamed, or have changed contents.
+	for _, fp := range filepatches {
+		if fp.from == nil {
+			continue
+		}
+		entry, err := t.FindEntry(fp.from.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s in tree %s: %w", fp.from.path, t.Hash, err)
+		}
+		if err := editTree.Delete(ctx, entry.Hash, entry.Mode, strings.Split(fp.from.path, "/")); err != nil {
+			return nil, errorf(err, "failed to delete file %s: %w", fp.from.path, err)
+		}
+	}
+
+	for _, u := range updates {
+		if err := editTree.Update(ctx, s, s, u.hash, u.mode, u.path); err != nil {
+			return nil, errorf(err, "failed to update file %s: %w", pathsToFullPath(u.path), err)
+		}
+	}
+
+	return editTree.BuildTree(ctx, s)
+}
+
+func pathOfTextFilePatch(fp *TextFilePatch) string {
+	if fp.to != nil {
+		return fp.to.path
+	}
+	if fp.from != nil {
+		return fp.from.path
+	}
+	return ""
+}
+
+var ErrMissingMappedParent = errors.New("commit has no mapped commit in the filtered repo")
+
+// ApplyMailPatches applies patches made against the filtered repo, and expands the generated commits back
+// into the unfiltered repo.
+//
+// The patches are applied on top of the filtered commit that fromhead is mapped to - fromhead must be already in dfs.
+// The file patches are checked against the filter with [CheckFilePatchAgainstFilter] before applied.
+// The generated commits keep the author and date from the patches, and committer will be set to the author
+// if committer is nil.
+//
+// It returns the new commits in the filtered repo, and the new commits in the unfiltered repo.
+func (dfs *FilteredDFS) ApplyMailPatches(
+	ctx context.Context,
+	fromhead plumbing.Hash,
+	patches []*MailPatch,
+	committer *object.Signature,
+) ([]*object.Commit, []*object.Commit, error) {
+	if dfs.toStorage == nil {
+		return nil, nil, ErrNilToStorage
+	}
+	if dfs.filter == nil {
+		return nil, nil, ErrEmptyFilter
+	}
+
+	tohead, found := dfs.FromToTo[fromhead]
+	if !found || tohead.IsZero() {
+		return nil, nil, fmt.Errorf("%w: %s", ErrMissingMappedParent, fromhead)
+	}
+	// commits in the dfs may not be decoded from the storage, obtain it from the storage so its tree can be read.
+	parent, err := object.GetCommit(dfs.toStorage, tohead)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to obtain mapped parent %s: %w", tohead, err)
+	}
+
+	for i, p := range patches {
+		if err := CheckFilePatchAgainstFilter(p.DiffFilePatches(), dfs.filter).ToError(); err != nil {
+			return nil, nil, fmt.Errorf("patch %d is rejected by filter: %w", i+1, err)
+		}
+	}
+
+	filtered := make([]*object.Commit, 0, len(patches))
+	for i, p := range patches {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		default:
+		}
+
+		parenttree, err := parent.Tree()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to obtain tree of %s: %w", parent.Hash, err)
+		}
+
+		newtree, err := ApplyTextPatches(ctx, parenttree, p.FilePatches, dfs.toStorage)
+		if err != nil {
+			return nil, nil, errorf(err, "failed to apply patch %d: %w", i+1, err)
+		}
+
+		c := &object.Commit{
+			Author:       p.Author,
+			Committer:    p.Author,
+			Message:      p.Message,
+			TreeHash:     newtree.Hash,
+			ParentHashes: []plumbing.Hash{parent.Hash},
+		}
+		if committer != nil {
+			c.Committer = *committer
+		}
+		if err := updateHashAndSave(ctx, c, dfs.toStorage); err != nil {
+			return nil, nil, errorf(err, "failed to save commit for patch %d: %w", i+1, err)
+		}
+
+		// obtain the commit back from storage, so its parents can be found.
+		newcommit, err := object.GetCommit(dfs.toStorage, c.Hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to obtain commit for patch %d: %w", i+1, err)
+		}
+
+		logger.Debug("apply patch", "id", i, "total", len(patches), "hash", p.Hash, "newcommit", newcommit.Hash)
+
+		filtered = append(filtered, newcommit)
+		parent = newcommit
+	}
+
+	expanded, err := dfs.ExpandFilteredCommits(ctx, filtered)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return filtered, expanded, nil
+}
@@ -0,0 +1,203 @@
+package gitrim_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/fardream/gitrim"
+)
+
+var testSignature = &object.Signature{
+	Name:  "Test User",
+	Email: "test@example.com",
+	When:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+}
+
+// newTestRepo creates an in-memory repo and commits each of the file sets in order.
+func newTestRepo(t *testing.T, commits ...map[string]string) (*git.Repository, []*object.Commit) {
+	t.Helper()
+
+	fs := memfs.New()
+	repo, err := git.Init(memory.NewStorage(), fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result []*object.Commit
+	for i, files := range commits {
+		for name, content := range files {
+			if content == "" {
+				if _, err := wt.Remove(name); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			f, err := fs.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := f.Write([]byte(content)); err != nil {
+				t.Fatal(err)
+			}
+			f.Close()
+			if _, err := wt.Add(name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		h, err := wt.Commit("commit "+string(rune('a'+i))+"\n", &git.CommitOptions{Author: testSignature})
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := repo.CommitObject(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result = append(result, c)
+	}
+
+	return repo, result
+}
+
+const testMbox = `From 1234567890123456789012345678901234567890 Mon Sep 17 00:00:00 2001
+From: Patch Author <patch@example.com>
+Date: Tue, 5 Mar 2024 10:11:12 +0800
+Subject: [PATCH 1/2] update
+ x
+
+Longer description.
+---
+ a/x.txt | 2 +-
+ 1 file changed, 1 insertion(+), 1 deletion(-)
+
+diff --git a/a/x.txt b/a/x.txt
+index a92d664..aa59d56 100644
+--- a/a/x.txt
++++ b/a/x.txt
+@@ -1,3 +1,3 @@
+ line 1
+-line 2
++line two
+ line 3
+-- 
+2.39.5
+
+From 2234567890123456789012345678901234567890 Mon Sep 17 00:00:00 2001
+From: Patch Author <patch@example.com>
+Date: Tue, 5 Mar 2024 10:12:12 +0800
+Subject: [PATCH 2/2] add z
+
+---
+ a/z.txt | 1 +
+ 1 file changed, 1 insertion(+)
+ create mode 100644 a/z.txt
+
+diff --git a/a/z.txt b/a/z.txt
+new file mode 100644
+index 0000000..95d09f2
+--- /dev/null
++++ b/a/z.txt
+@@ -0,0 +1 @@
++hello world
+\ No newline at end of file
+-- 
+2.39.5
+
+`
+
+func TestFilteredDFS_ApplyMailPatches(t *testing.T) {
+	ctx := context.Background()
+
+	repo, commits := newTestRepo(t,
+		map[string]string{"a/x.txt": "line 1\nline 2\nline 3\n", "b/y.txt": "y\n"},
+		map[string]string{"b/y.txt": "y2\n"},
+	)
+
+	filter, err := gitrim.NewOrFilterForPatterns("a/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dfs, err := gitrim.NewFilteredDFS(ctx, commits, repo.Storer, memory.NewStorage(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patches, err := gitrim.ParseMbox(strings.NewReader(testMbox))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("want 2 patches, got %d", len(patches))
+	}
+	if want := "update x\n\nLonger description.\n"; patches[0].Message != want {
+		t.Errorf("want message %q, got %q", want, patches[0].Message)
+	}
+
+	filtered, expanded, err := dfs.ApplyMailPatches(ctx, commits[1].Hash, patches, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 2 || len(expanded) != 2 {
+		t.Fatalf("want 2 commits, got %d filtered and %d expanded", len(filtered), len(expanded))
+	}
+
+	last := expanded[1]
+	if last.Author.Email != "patch@example.com" || last.Author.When.Unix() != 1709604732 {
+		t.Errorf("author is not kept: %s", last.Author.String())
+	}
+	for name, want := range map[string]string{
+		"a/x.txt": "line 1\nline two\nline 3\n",
+		"a/z.txt": "hello world",
+		"b/y.txt": "y2\n",
+	} {
+		f, err := last.File(name)
+		if err != nil {
+			t.Fatalf("failed to find %s: %s", name, err)
+		}
+		got, err := f.Contents()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%s: want %q, got %q", name, want, got)
+		}
+	}
+}
+
+func TestFilteredDFS_ApplyMailPatches_rejected(t *testing.T) {
+	ctx := context.Background()
+
+	repo, commits := newTestRepo(t,
+		map[string]string{"a/x.txt": "line 1\nline 2\nline 3\n", "b/y.txt": "y\n"},
+	)
+
+	filter, err := gitrim.NewOrFilterForPatterns("b/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dfs, err := gitrim.NewFilteredDFS(ctx, commits, repo.Storer, memory.NewStorage(), filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patches, err := gitrim.ParseMbox(strings.NewReader(testMbox))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := dfs.ApplyMailPatches(ctx, commits[0].Hash, patches, nil); err == nil {
+		t.Fatal("want the patches rejected by the filter")
+	}
+}
@@ -0,0 +1,91 @@
+package gitrim
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/revlist"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// Supported versions of git bundle format.
+const (
+	BundleV2 = 2
+	BundleV3 = 3
+)
+
+// bundlePackWindow is the window size for delta compression in the packfile.
+const bundlePackWindow = 10
+
+var (
+	ErrUnsupportedBundleVersion = errors.New("unsupported bundle version")
+	ErrEmptyBundle              = errors.New("bundle contains no references")
+)
+
+// WriteBundle writes the references and the objects reachable from them into w as a git bundle, which can be
+// cloned or fetched from by git.
+//
+// Objects reachable from prerequisites are excluded from the bundle - the repo that fetches from the bundle
+// must already contain the prerequisites. The prerequisites must be in s.
+// version is either [BundleV2] or [BundleV3].
+func WriteBundle(
+	w io.Writer,
+	s storer.EncodedObjectStorer,
+	refs []*plumbing.Reference,
+	prerequisites []plumbing.Hash,
+	version int,
+) error {
+	if len(refs) == 0 {
+		return ErrEmptyBundle
+	}
+
+	bw := bufio.NewWriter(w)
+
+	switch version {
+	case BundleV2:
+		fmt.Fprint(bw, "# v2 git bundle\n")
+	case BundleV3:
+		fmt.Fprint(bw, "# v3 git bundle\n@object-format=sha1\n")
+	default:
+		return fmt.Errorf("%w: %d", ErrUnsupportedBundleVersion, version)
+	}
+
+	for _, p := range prerequisites {
+		// the comment is optional, and git uses the subject of the commit.
+		comment := ""
+		if c, err := object.GetCommit(s, p); err == nil {
+			subject, _, _ := strings.Cut(c.Message, "\n")
+			comment = " " + subject
+		}
+		fmt.Fprintf(bw, "-%s%s\n", p, comment)
+	}
+
+	wants := make([]plumbing.Hash, 0, len(refs))
+	for _, ref := range refs {
+		if ref.Type() != plumbing.HashReference {
+			return fmt.Errorf("reference %s is not a hash reference", ref.Name())
+		}
+		fmt.Fprintf(bw, "%s %s\n", ref.Hash(), ref.Name())
+		wants = append(wants, ref.Hash())
+	}
+	fmt.Fprint(bw, "\n")
+
+	objects, err := revlist.Objects(s, wants, prerequisites)
+	if err != nil {
+		return fmt.Errorf("failed to list objects for bundle: %w", err)
+	}
+
+	logger.Debug("write bundle", "refs", len(refs), "prerequisites", len(prerequisites), "objects", len(objects))
+
+	if _, err := packfile.NewEncoder(bw, s, false).Encode(objects, bundlePackWindow); err != nil {
+		return fmt.Errorf("failed to write packfile for bundle: %w", err)
+	}
+
+	return bw.Flush()
+}
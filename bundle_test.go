@@ -0,0 +1,96 @@
+package gitrim_test
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/fardream/gitrim"
+)
+
+// readBundle reads the header lines of the bundle, and loads the packfile into a new storage.
+func readBundle(t *testing.T, data []byte) ([]string, *memory.Storage) {
+	t.Helper()
+
+	r := bufio.NewReader(bytes.NewReader(data))
+	var header []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read bundle header: %s", err)
+		}
+		if line == "\n" {
+			break
+		}
+		header = append(header, line)
+	}
+
+	s := memory.NewStorage()
+	if err := packfile.UpdateObjectStorage(s, r); err != nil {
+		t.Fatalf("failed to read packfile: %s", err)
+	}
+
+	return header, s
+}
+
+func TestWriteBundle(t *testing.T) {
+	repo, commits := newTestRepo(t,
+		map[string]string{"a/x.txt": "x\n"},
+		map[string]string{"a/y.txt": "y\n"},
+		map[string]string{"a/x.txt": "x2\n"},
+	)
+
+	last := commits[len(commits)-1]
+	ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), last.Hash)
+
+	var full bytes.Buffer
+	if err := gitrim.WriteBundle(&full, repo.Storer, []*plumbing.Reference{ref}, nil, gitrim.BundleV3); err != nil {
+		t.Fatal(err)
+	}
+	header, s := readBundle(t, full.Bytes())
+	wantheader := []string{
+		"# v3 git bundle\n",
+		"@object-format=sha1\n",
+		fmt.Sprintf("%s refs/heads/main\n", last.Hash),
+	}
+	if fmt.Sprint(header) != fmt.Sprint(wantheader) {
+		t.Errorf("want header %q, got %q", wantheader, header)
+	}
+	for _, c := range commits {
+		if _, err := object.GetCommit(s, c.Hash); err != nil {
+			t.Errorf("commit %s is not in the bundle: %s", c.Hash, err)
+		}
+	}
+
+	var incremental bytes.Buffer
+	if err := gitrim.WriteBundle(&incremental, repo.Storer, []*plumbing.Reference{ref}, []plumbing.Hash{commits[1].Hash}, gitrim.BundleV2); err != nil {
+		t.Fatal(err)
+	}
+	header, s = readBundle(t, incremental.Bytes())
+	wantheader = []string{
+		"# v2 git bundle\n",
+		fmt.Sprintf("-%s commit b\n", commits[1].Hash),
+		fmt.Sprintf("%s refs/heads/main\n", last.Hash),
+	}
+	if fmt.Sprint(header) != fmt.Sprint(wantheader) {
+		t.Errorf("want header %q, got %q", wantheader, header)
+	}
+	if _, err := object.GetCommit(s, last.Hash); err != nil {
+		t.Errorf("last commit is not in the bundle: %s", err)
+	}
+	for _, c := range commits[:2] {
+		if _, err := object.GetCommit(s, c.Hash); err == nil {
+			t.Errorf("commit %s before the prerequisite is in the bundle", c.Hash)
+		}
+	}
+
+	if err := gitrim.WriteBundle(&bytes.Buffer{}, repo.Storer, []*plumbing.Reference{ref}, nil, 4); err == nil {
+		t.Error("want error for unsupported version")
+	}
+}
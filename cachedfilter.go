@@ -8,6 +8,9 @@ type CachedFilter struct {
 
 	dircache    map[string]FilterResult
 	nondircache map[string]FilterResult
+
+	hits   uint64
+	misses uint64
 }
 
 var _ Filter = (*CachedFilter)(nil)
@@ -17,18 +20,22 @@ func (f *CachedFilter) Filter(paths []string, isdir bool) FilterResult {
 	if isdir {
 		r, in := f.dircache[name]
 		if in {
+			f.hits++
 			return r
 		}
 
+		f.misses++
 		r = f.filter.Filter(paths, isdir)
 		f.dircache[name] = r
 		return r
 	} else {
 		r, in := f.nondircache[name]
 		if in {
+			f.hits++
 			return r
 		}
 
+		f.misses++
 		r = f.filter.Filter(paths, isdir)
 		f.nondircache[name] = r
 		return r
@@ -48,3 +55,9 @@ func (f *CachedFilter) Reset() {
 	clear(f.dircache)
 	clear(f.nondircache)
 }
+
+// Stats returns the number of paths found in the cache and the number of paths passed to the underlying filter.
+// The numbers are not cleared by [CachedFilter.Reset].
+func (f *CachedFilter) Stats() (hits uint64, misses uint64) {
+	return f.hits, f.misses
+}
@@ -8,18 +8,30 @@
 //
 // The generated commit history can be set to a branch as defined by branch name parameter, and can also be optionally
 // set as the head of the repo.
+//
+// Instead of writing to an output directory, the generated history can also be written as a "git fast-import" stream,
+// optionally with a marks file mapping the marks in the stream to the commit and blob hashes.
+//
+// The generated history can also be written as a git bundle, which can be cloned or fetched from by git.
+//
+// Instead of reading from a .git folder, the original history can also be read from a "git fast-export" stream.
 package main
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/spf13/cobra"
 
 	"github.com/fardream/gitrim"
@@ -33,11 +45,18 @@ func main() {
 type Cmd struct {
 	*cobra.Command
 
-	inputdir  string
-	outputdir string
-	overwrite bool
+	inputdir        string
+	inputFastExport string
+	outputdir       string
+	overwrite       bool
 	cmd.HistCmd
 
+	fastImport string
+	marksFile  string
+
+	bundle        string
+	bundleVersion int
+
 	cmd.SetBranchCmd
 	cmd.LogCmd
 	cmd.FilterCmd
@@ -53,6 +72,13 @@ Input/output are directly read/written from the .git folder of git repositories.
 
 The generated commit history can be set to a branch as defined by branch name parameter, and can also be optionally
 set as the head of the repo.
+
+Instead of writing to an output directory, the generated history can also be written as a "git fast-import" stream,
+optionally with a marks file mapping the marks in the stream to the commit and blob hashes.
+
+The generated history can also be written as a git bundle, which can be cloned or fetched from by git.
+
+Instead of reading from a .git folder, the original history can also be read from a "git fast-export" stream.
 ` + "\n" + cmd.PatternDescription
 
 func newCmd() *Cmd {
@@ -63,21 +89,33 @@ func newCmd() *Cmd {
 			Long:  longDescription,
 			Args:  cobra.NoArgs,
 		},
+		bundleVersion: gitrim.BundleV2,
 	}
 
 	c.SetupFilterCobra(c.Command, true)
 	c.Flags().StringVarP(&c.inputdir, "input-dir", "i", c.inputdir, "input directory containing original git repo")
-	c.MarkFlagRequired("input-dir")
 	c.MarkFlagDirname("input-dir")
+	c.Flags().StringVar(&c.inputFastExport, "input-fast-export", c.inputFastExport, "read the original history from a git fast-export stream in this file instead, - for stdin")
+	c.MarkFlagFilename("input-fast-export")
+	c.MarkFlagsOneRequired("input-dir", "input-fast-export")
+	c.MarkFlagsMutuallyExclusive("input-dir", "input-fast-export")
 	c.Flags().StringVarP(&c.outputdir, "output-dir", "o", c.outputdir, "output directory")
-	c.MarkFlagRequired("output-dir")
 	c.MarkFlagDirname("output-dir")
 	c.Flags().BoolVarP(&c.overwrite, "overwrite", "w", c.overwrite, "overwrite the destination if it's already exists")
+	c.Flags().StringVar(&c.fastImport, "fast-import", c.fastImport, "output the history as a git fast-import stream to this file, - for stdout")
+	c.MarkFlagFilename("fast-import")
+	c.Flags().StringVar(&c.marksFile, "marks-file", c.marksFile, "write the marks of the fast-import stream to this file")
+	c.MarkFlagFilename("marks-file")
+	c.Flags().StringVar(&c.bundle, "bundle", c.bundle, "output the history as a git bundle to this file, - for stdout")
+	c.MarkFlagFilename("bundle")
+	c.Flags().IntVar(&c.bundleVersion, "bundle-version", c.bundleVersion, "version of the git bundle, 2 or 3")
+	c.MarkFlagsOneRequired("output-dir", "fast-import", "bundle")
+	c.MarkFlagsMutuallyExclusive("output-dir", "fast-import", "bundle")
 	c.Flags().IntVarP(&c.NumCommit, "num-commit", "n", c.NumCommit, "number of commits to seek back")
 	c.Flags().StringVarP(&c.EndCommit, "end-commit", "e", c.EndCommit, "commit hash (default to head)")
 	c.Flags().StringArrayVarP(&c.StartCommits, "start-commit", "s", c.StartCommits, "commit hash to start from, default to empty, and history will seek to root unless restricted by number of commit")
 
-	c.Flags().StringVar(&c.Branch, "branch", c.Branch, "branch to set the head to")
+	c.Flags().StringVar(&c.Branch, "branch", c.Branch, "branch to set the head to, default to master for fast-import stream and bundle")
 	c.Flags().BoolVar(&c.SetHead, "set-head", c.SetHead, "set the generated commit history as the head")
 
 	c.Flags().IntVar(&c.LogLevel, "log-level", c.LogLevel, "log level passing to slog.")
@@ -108,16 +146,108 @@ func (c *Cmd) run(*cobra.Command, []string) {
 	defer cancel()
 
 	c.InitLog()
+	if c.marksFile != "" && c.fastImport == "" {
+		cmd.OrPanic(fmt.Errorf("marks file is only supported for fast-import stream"))
+	}
+
 	chc := cache.NewObjectLRUDefault()
 
-	inputfs := cmd.NewFileSystem(c.inputdir, chc)
+	var inputfs storer.Storer
+	if c.inputFastExport != "" {
+		inputfs = loadFastExport(ctx, c.inputFastExport)
+	} else {
+		inputfs = cmd.NewFileSystem(c.inputdir, chc)
+	}
 
 	hist := c.GetHistory(ctx, inputfs)
 
 	orfilter := c.GetFilter()
+
+	if c.fastImport != "" {
+		c.writeFastImport(ctx, hist, inputfs, orfilter)
+		return
+	}
+	if c.bundle != "" {
+		c.writeBundle(ctx, hist, inputfs, orfilter)
+		return
+	}
+
 	outputfs := newOutputDir(c.outputdir, c.overwrite, chc)
 
 	newhist := cmd.GetOrPanic(gitrim.FilterLinearHistory(ctx, hist, outputfs, orfilter))
 
 	c.SetBrancHeadFromHistory(outputfs, newhist)
 }
+
+// loadFastExport loads the fast-export stream into memory.
+func loadFastExport(ctx context.Context, input string) storer.Storer {
+	var r io.Reader = os.Stdin
+	if input != "-" {
+		f := cmd.GetOrPanic(os.Open(input))
+		defer f.Close()
+		r = f
+	}
+
+	s := memory.NewStorage()
+	refs := cmd.GetOrPanic(gitrim.LoadFastExport(ctx, r, s))
+	cmd.Logger().Debug("loaded fast-export stream", "refs", len(refs))
+
+	return s
+}
+
+// streamBranch returns the branch for stream outputs like fast-import stream and bundle.
+func (c *Cmd) streamBranch() plumbing.ReferenceName {
+	if c.SetHead {
+		cmd.Logger().Warn("set-head is ignored for stream output")
+	}
+	if c.Branch == "" {
+		return plumbing.NewBranchReferenceName("master")
+	}
+	return plumbing.NewBranchReferenceName(c.Branch)
+}
+
+func createOutputFile(name string) (io.Writer, func()) {
+	if name == "-" {
+		return os.Stdout, func() {}
+	}
+	f := cmd.GetOrPanic(os.Create(name))
+	return f, func() { cmd.OrPanic(f.Close()) }
+}
+
+func (c *Cmd) writeFastImport(ctx context.Context, hist []*object.Commit, inputfs storer.Storer, filter gitrim.Filter) {
+	branch := c.streamBranch()
+
+	dfs := cmd.GetOrPanic(gitrim.NewFilteredDFS(ctx, hist, inputfs, memory.NewStorage(), filter))
+
+	output, closeoutput := createOutputFile(c.fastImport)
+	defer closeoutput()
+
+	fw := gitrim.NewFastImportWriter(output)
+	cmd.OrPanic(dfs.WriteFastImport(ctx, fw, branch))
+	cmd.OrPanic(fw.Flush())
+
+	if c.marksFile != "" {
+		f := cmd.GetOrPanic(os.Create(c.marksFile))
+		defer f.Close()
+		cmd.OrPanic(fw.WriteMarks(f))
+	}
+}
+
+func (c *Cmd) writeBundle(ctx context.Context, hist []*object.Commit, inputfs storer.Storer, filter gitrim.Filter) {
+	branch := c.streamBranch()
+
+	tostorage := memory.NewStorage()
+	dfs := cmd.GetOrPanic(gitrim.NewFilteredDFS(ctx, hist, inputfs, tostorage, filter))
+	if len(hist) == 0 {
+		cmd.OrPanic(gitrim.ErrEmptyFilteredDFS)
+	}
+	head := dfs.FromToTo[hist[len(hist)-1].Hash]
+	if head.IsZero() {
+		cmd.OrPanic(gitrim.ErrEmptyFilteredDFS)
+	}
+
+	output, closeoutput := createOutputFile(c.bundle)
+	defer closeoutput()
+
+	cmd.OrPanic(gitrim.WriteBundle(output, tostorage, []*plumbing.Reference{plumbing.NewHashReference(branch, head)}, nil, c.bundleVersion))
+}
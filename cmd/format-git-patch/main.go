@@ -0,0 +1,126 @@
+// format-git-patch generates patch emails, in the format of "git format-patch", for the filtered view of a range of commits.
+//
+// The range is specified by the end commit (default to head) and the start commits, the same as filter-git-hist - the start commits
+// and their ancestors are excluded from the output. Full history of the end commit is filtered,
+// so the generated patches apply to the repo generated by filter-git-hist with the same filter.
+//
+// Commits that are empty after filtering are skipped, and so are merge commits.
+//
+// The patches can be applied by "git am" on the filtered repo.
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/gitrim"
+	"github.com/fardream/gitrim/cmd"
+)
+
+func main() {
+	newCmd().Execute()
+}
+
+type Cmd struct {
+	*cobra.Command
+
+	inputdir   string
+	outputFile string
+	cmd.HistCmd
+
+	cmd.LogCmd
+	cmd.FilterCmd
+}
+
+const longDescription = `format-git-patch generates patch emails, in the format of "git format-patch", for the filtered view of a range of commits.
+
+The range is specified by the end commit (default to head) and the start commits, the same as filter-git-hist - the start commits
+and their ancestors are excluded from the output. Full history of the end commit is filtered,
+so the generated patches apply to the repo generated by filter-git-hist with the same filter.
+
+Commits that are empty after filtering are skipped, and so are merge commits.
+
+The patches can be applied by "git am" on the filtered repo.
+` + "\n" + cmd.PatternDescription
+
+func newCmd() *Cmd {
+	c := &Cmd{
+		Command: &cobra.Command{
+			Use:   "format-git-patch",
+			Short: "generate patches for filtered commits",
+			Long:  longDescription,
+			Args:  cobra.NoArgs,
+		},
+		outputFile: "-",
+	}
+
+	c.SetupFilterCobra(c.Command, true)
+	c.Flags().StringVarP(&c.inputdir, "input-dir", "i", c.inputdir, "input directory containing original git repo")
+	c.MarkFlagRequired("input-dir")
+	c.MarkFlagDirname("input-dir")
+	c.Flags().StringVarP(&c.outputFile, "output", "o", c.outputFile, "output mbox file, - for stdout")
+	c.MarkFlagFilename("output")
+	c.Flags().IntVarP(&c.NumCommit, "num-commit", "n", c.NumCommit, "number of commits to seek back")
+	c.Flags().StringVarP(&c.EndCommit, "end-commit", "e", c.EndCommit, "commit hash (default to head)")
+	c.Flags().StringArrayVarP(&c.StartCommits, "start-commit", "s", c.StartCommits, "commit hash to start from, this commit and its ancestors are excluded")
+
+	c.Flags().IntVar(&c.LogLevel, "log-level", c.LogLevel, "log level passing to slog.")
+
+	c.Run = c.run
+
+	return c
+}
+
+func (c *Cmd) run(*cobra.Command, []string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	c.InitLog()
+	chc := cache.NewObjectLRUDefault()
+
+	inputfs := cmd.NewFileSystem(c.inputdir, chc)
+
+	// start commits are the base of the range, and not part of the output.
+	starts := cmd.GetOrPanic(gitrim.NewHashSetFromStrings(c.StartCommits...))
+	commits := make([]*object.Commit, 0)
+	for _, commit := range c.GetHistory(ctx, inputfs) {
+		if _, found := starts[commit.Hash]; !found {
+			commits = append(commits, commit)
+		}
+	}
+	if len(commits) == 0 {
+		cmd.Logger().Warn("no commits in the range")
+		return
+	}
+
+	// the full history is needed so the first commit in the range has the correct parent.
+	fullhist := cmd.GetOrPanic(gitrim.GetDFSPath(ctx, commits[len(commits)-1], gitrim.NewHashSet(), 0))
+	inrange := gitrim.NewHashSetFromCommits(commits)
+	before := make([]*object.Commit, 0, len(fullhist))
+	for _, commit := range fullhist {
+		if _, found := inrange[commit.Hash]; !found {
+			before = append(before, commit)
+		}
+	}
+
+	dfs := cmd.GetOrPanic(gitrim.NewFilteredDFS(ctx, before, inputfs, memory.NewStorage(), c.GetFilter()))
+
+	var output io.Writer = os.Stdout
+	if c.outputFile != "-" {
+		f := cmd.GetOrPanic(os.Create(c.outputFile))
+		defer f.Close()
+		output = f
+	}
+
+	written := cmd.GetOrPanic(dfs.FormatPatches(ctx, output, commits))
+
+	cmd.Logger().Info("patches generated", "commits", len(commits), "patches", len(written))
+}
@@ -0,0 +1,37 @@
+package main
+
+import "github.com/spf13/cobra"
+
+type applyPatchCmd struct {
+	*cobra.Command
+
+	id       string
+	mboxFile string
+	noDryrun bool
+
+	overrideFromBranch string
+	overrideToBranch   string
+}
+
+func newApplyPatchCmd(torun func(*cobra.Command, []string)) *applyPatchCmd {
+	r := &applyPatchCmd{
+		Command: &cobra.Command{
+			Use:   "apply-patch",
+			Short: "apply patches generated by git format-patch against sub repo to original repo",
+			Args:  cobra.NoArgs,
+		},
+		mboxFile: "-",
+	}
+
+	r.Flags().StringVarP(&r.id, "id", "i", r.id, "id of the sync")
+	r.MarkFlagRequired("id")
+	r.Flags().StringVarP(&r.mboxFile, "mbox", "m", r.mboxFile, "mbox file containing the patches, - for stdin")
+	r.MarkFlagFilename("mbox")
+	r.Flags().BoolVarP(&r.noDryrun, "no-dryrun", "p", r.noDryrun, "push the changes, instead of dryrun/check the patches")
+	r.Flags().StringVar(&r.overrideFromBranch, "from-branch", r.overrideFromBranch, "override from branch")
+	r.Flags().StringVar(&r.overrideToBranch, "to-branch", r.overrideToBranch, "override to branch")
+
+	r.Run = torun
+
+	return r
+}
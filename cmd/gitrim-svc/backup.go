@@ -0,0 +1,28 @@
+package main
+
+import "github.com/spf13/cobra"
+
+type backupCmd struct {
+	*cobra.Command
+
+	output string
+}
+
+func newBackupCmd(torun func(*cobra.Command, []string)) *backupCmd {
+	r := &backupCmd{
+		Command: &cobra.Command{
+			Use:   "backup",
+			Short: "back up the db of a running service",
+			Long:  "stream a consistent copy of the db while the service keeps running. The copy can replace db_path of a stopped service to restore it.",
+			Args:  cobra.NoArgs,
+		},
+	}
+
+	r.Flags().StringVarP(&r.output, "output", "o", r.output, "output file")
+	r.MarkFlagRequired("output")
+	r.MarkFlagFilename("output")
+
+	r.Run = torun
+
+	return r
+}
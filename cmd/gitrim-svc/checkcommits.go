@@ -7,6 +7,7 @@ type syncToFromCmd struct {
 
 	id       string
 	noDryrun bool
+	queue    bool
 
 	overrideFromBranch string
 	overrideToBranch   string
@@ -28,6 +29,9 @@ func newSyncToFromCmd(torun func(*cobra.Command, []string)) *syncToFromCmd {
 	r.Flags().StringVar(&r.overrideFromBranch, "from-branch", r.overrideFromBranch, "override from branch")
 	r.Flags().StringVar(&r.overrideToBranch, "to-branch", r.overrideToBranch, "override to branch")
 	r.Flags().BoolVar(&r.allowGpg, "allow-gpg", r.allowGpg, "allow gpg signatures in the commits")
+	r.Flags().BoolVarP(&r.queue, "queue", "q", r.queue, "queue a job to push the changes on the server instead of waiting for it, implies --no-dryrun, see ls-jobs")
+	r.MarkFlagsMutuallyExclusive("queue", "from-branch")
+	r.MarkFlagsMutuallyExclusive("queue", "to-branch")
 
 	r.Run = torun
 
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/fardream/gitrim/cmd"
+	"github.com/fardream/gitrim/svc"
+)
+
+// maxRecvMsgSize is the max size of responses from the server, the stats of the repo syncs can be large.
+const maxRecvMsgSize = 256 << 20
+
+// serverFlags contains the options to connect to a running GiTrim server.
+type serverFlags struct {
+	server             string
+	useTls             bool
+	caFile             string
+	serverName         string
+	insecureSkipVerify bool
+	token              string
+	tokenFile          string
+}
+
+func (f *serverFlags) addFlags(c *cobra.Command) {
+	c.PersistentFlags().StringVar(&f.server, "server", f.server, "address of the GiTrim server. If set, the commands are sent to the server instead of opening the database in config")
+	c.PersistentFlags().BoolVar(&f.useTls, "tls", f.useTls, "connect to the server with TLS")
+	c.PersistentFlags().StringVar(&f.caFile, "ca-file", f.caFile, "CA certificates to verify the server, system CA certificates are used if not set. Implies --tls")
+	c.MarkPersistentFlagFilename("ca-file")
+	c.PersistentFlags().StringVar(&f.serverName, "server-name", f.serverName, "override the server name to verify the certificate of the server. Implies --tls")
+	c.PersistentFlags().BoolVar(&f.insecureSkipVerify, "insecure-skip-verify", f.insecureSkipVerify, "don't verify the certificate of the server. Implies --tls")
+	c.PersistentFlags().StringVar(&f.token, "token", f.token, "bearer token sent to the server")
+	c.PersistentFlags().StringVar(&f.tokenFile, "token-file", f.tokenFile, "file containing the bearer token sent to the server")
+	c.MarkPersistentFlagFilename("token-file")
+	c.MarkFlagsMutuallyExclusive("token", "token-file")
+}
+
+func (f *serverFlags) isTls() bool {
+	return f.useTls || f.caFile != "" || f.serverName != "" || f.insecureSkipVerify
+}
+
+func (f *serverFlags) transportCredentials() (credentials.TransportCredentials, error) {
+	if !f.isTls() {
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg := &tls.Config{
+		ServerName:         f.serverName,
+		InsecureSkipVerify: f.insecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if f.caFile != "" {
+		pem, err := os.ReadFile(f.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", f.caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+func (f *serverFlags) getToken() (string, error) {
+	if f.tokenFile == "" {
+		return f.token, nil
+	}
+
+	token, err := os.ReadFile(f.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
+
+// tokenCredentials sends the token as bearer token in authorization header.
+type tokenCredentials struct {
+	token      string
+	requireTls bool
+}
+
+var _ credentials.PerRPCCredentials = (*tokenCredentials)(nil)
+
+func (t *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTls
+}
+
+func (f *serverFlags) dial() (*grpc.ClientConn, error) {
+	creds, err := f.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRecvMsgSize)),
+	}
+
+	token, err := f.getToken()
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		if !f.isTls() {
+			cmd.Logger().Warn("sending token without tls")
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(&tokenCredentials{token: token, requireTls: f.isTls()}))
+	}
+
+	return grpc.NewClient(f.server, opts...)
+}
+
+// newClient returns the client to a running server if --server is set, or a client that opens the database
+// in the config directly. The returned function releases the resources of the client.
+func (c *rootCmd) newClient() (svc.GiTrimClient, func()) {
+	if c.serverFlags.server != "" {
+		conn := cmd.GetOrPanic(c.serverFlags.dial())
+		return svc.NewGiTrimClient(conn), func() { conn.Close() }
+	}
+
+	config := cmd.GetOrPanic(svc.ParseConfigYAML(cmd.GetOrPanic(os.ReadFile(c.configPath))))
+
+	s := cmd.GetOrPanic(svc.New(config))
+
+	return svc.NewLocalClient(s), func() { s.Close() }
+}
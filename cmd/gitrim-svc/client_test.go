@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/fardream/gitrim/svc"
+)
+
+// serveTest serves a new service with config on a loopback listener, and returns its address.
+func serveTest(t *testing.T, config *svc.GiTrimConfig) string {
+	t.Helper()
+
+	config.DbPath = filepath.Join(t.TempDir(), "gitrim.db")
+	s, err := svc.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- s.ServeListener(ctx, lis) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("failed to serve: %v", err)
+		}
+		s.Close()
+	})
+
+	return lis.Addr().String()
+}
+
+func TestServerFlags_dial(t *testing.T) {
+	authaddr := serveTest(t, &svc.GiTrimConfig{
+		Auth: &svc.AuthConfig{
+			Tokens:       []*svc.TokenIdentity{{Identity: "admin", Token: "admin-token"}},
+			RoleBindings: []*svc.RoleBinding{{Identities: []string{"admin"}, Role: svc.RoleBinding_ADMIN}},
+		},
+	})
+	noauthaddr := serveTest(t, &svc.GiTrimConfig{})
+
+	tokenfile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenfile, []byte("admin-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, test := range map[string]struct {
+		flags serverFlags
+		code  codes.Code
+	}{
+		"no auth":     {flags: serverFlags{server: noauthaddr}, code: codes.OK},
+		"no token":    {flags: serverFlags{server: authaddr}, code: codes.Unauthenticated},
+		"wrong token": {flags: serverFlags{server: authaddr, token: "wrong-token"}, code: codes.Unauthenticated},
+		"token":       {flags: serverFlags{server: authaddr, token: "admin-token"}, code: codes.OK},
+		"token file":  {flags: serverFlags{server: authaddr, tokenFile: tokenfile}, code: codes.OK},
+	} {
+		t.Run(name, func(t *testing.T) {
+			conn, err := test.flags.dial()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			_, err = svc.NewGiTrimClient(conn).ListRepoSyncs(context.Background(), &svc.ListRepoSyncsRequest{})
+			if got := status.Code(err); got != test.code {
+				t.Errorf("want code %s, got %v", test.code, err)
+			}
+		})
+	}
+
+	t.Run("missing token file", func(t *testing.T) {
+		f := serverFlags{server: authaddr, tokenFile: filepath.Join(t.TempDir(), "missing")}
+		if _, err := f.dial(); err == nil {
+			t.Error("want error for missing token file")
+		}
+	})
+}
+
+func TestRootCmd_newClient(t *testing.T) {
+	dbpath := filepath.Join(t.TempDir(), "gitrim.db")
+	configpath := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(configpath, []byte("db_path: "+dbpath+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	addr := serveTest(t, &svc.GiTrimConfig{})
+
+	for name, c := range map[string]*rootCmd{
+		"local":  {configPath: configpath},
+		"server": {serverFlags: serverFlags{server: addr}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, closefn := c.newClient()
+			defer closefn()
+
+			if _, err := client.ListRepoSyncs(context.Background(), &svc.ListRepoSyncsRequest{}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "github.com/spf13/cobra"
+
+type deleteRepoSyncCmd struct {
+	*cobra.Command
+
+	id []string
+}
+
+func newDeleteRepoSyncCmd(torun func(*cobra.Command, []string)) *deleteRepoSyncCmd {
+	r := &deleteRepoSyncCmd{
+		Command: &cobra.Command{
+			Use:   "delete-repo-sync",
+			Short: "delete repo syncs, the repos are not touched",
+			Args:  cobra.NoArgs,
+		},
+	}
+
+	r.Flags().StringArrayVarP(&r.id, "id", "i", r.id, "ids to delete")
+	r.MarkFlagRequired("id")
+
+	r.Run = torun
+
+	return r
+}
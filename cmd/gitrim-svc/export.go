@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/gitrim/svc"
+)
+
+type exportCmd struct {
+	*cobra.Command
+
+	output  string
+	keyFile string
+	format  string
+
+	request *svc.ExportRequest
+}
+
+func newExportCmd(torun func(*cobra.Command, []string)) *exportCmd {
+	r := &exportCmd{
+		Command: &cobra.Command{
+			Use:   "export",
+			Short: "export the repo syncs",
+			Long:  "export the repo syncs, their stats, and their secrets sealed with the key in key-file, so they can be imported into another service",
+			Args:  cobra.NoArgs,
+		},
+		output:  "-",
+		format:  "json",
+		request: &svc.ExportRequest{},
+	}
+
+	r.Flags().StringVarP(&r.output, "output", "o", r.output, "output file, - for stdout")
+	r.MarkFlagFilename("output")
+	r.Flags().StringVar(&r.keyFile, "key-file", r.keyFile, "file with the hex of the 16 bytes AES key sealing the secrets in the export")
+	r.MarkFlagRequired("key-file")
+	r.MarkFlagFilename("key-file")
+	r.Flags().StringVar(&r.format, "format", r.format, "json for json lines, or proto for size delimited protobuf")
+	r.Flags().StringSliceVar(&r.request.Ids, "id", r.request.Ids, "ids of the repo syncs to export, all if empty")
+
+	r.Run = torun
+
+	return r
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/gitrim/svc"
+)
+
+type exportAuditCmd struct {
+	*cobra.Command
+
+	output string
+
+	request *svc.ListAuditEventsRequest
+}
+
+func newExportAuditCmd(torun func(*cobra.Command, []string)) *exportAuditCmd {
+	r := &exportAuditCmd{
+		Command: &cobra.Command{
+			Use:   "export-audit",
+			Short: "export the audit events",
+			Long:  "export the audit events of the pushes to the repos as json lines, in the order they are recorded",
+			Args:  cobra.NoArgs,
+		},
+		output:  "-",
+		request: &svc.ListAuditEventsRequest{},
+	}
+
+	r.Flags().StringVarP(&r.output, "output", "o", r.output, "output file, - for stdout")
+	r.MarkFlagFilename("output")
+	r.Flags().StringVar(&r.request.RepoSyncId, "repo-sync-id", r.request.RepoSyncId, "only export the events of the repo sync")
+	r.Flags().Uint64Var(&r.request.AfterSequence, "after-sequence", r.request.AfterSequence, "only export the events after the sequence, like the last one exported before")
+
+	r.Run = torun
+
+	return r
+}
@@ -0,0 +1,38 @@
+package main
+
+import "github.com/spf13/cobra"
+
+type importCmd struct {
+	*cobra.Command
+
+	input      string
+	keyFile    string
+	format     string
+	onConflict string
+}
+
+func newImportCmd(torun func(*cobra.Command, []string)) *importCmd {
+	r := &importCmd{
+		Command: &cobra.Command{
+			Use:   "import",
+			Short: "import the repo syncs exported by export",
+			Long:  "import the repo syncs exported by export in one transaction, nothing is imported if any of them fails",
+			Args:  cobra.NoArgs,
+		},
+		input:      "-",
+		format:     "json",
+		onConflict: "fail",
+	}
+
+	r.Flags().StringVarP(&r.input, "input", "i", r.input, "input file, - for stdin")
+	r.MarkFlagFilename("input")
+	r.Flags().StringVar(&r.keyFile, "key-file", r.keyFile, "file with the hex of the AES key the export is sealed with")
+	r.MarkFlagRequired("key-file")
+	r.MarkFlagFilename("key-file")
+	r.Flags().StringVar(&r.format, "format", r.format, "json for json lines, or proto for size delimited protobuf")
+	r.Flags().StringVar(&r.onConflict, "on-conflict", r.onConflict, "what to do with a repo sync whose id exists: fail, skip, or overwrite")
+
+	r.Run = torun
+
+	return r
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/gitrim/svc"
+)
+
+type lsJobsCmd struct {
+	*cobra.Command
+
+	id     []uint
+	states []string
+
+	listRequest *svc.ListJobsRequest
+}
+
+func newLsJobsCmd(torun func(*cobra.Command, []string)) *lsJobsCmd {
+	r := &lsJobsCmd{
+		Command: &cobra.Command{
+			Use:   "ls-jobs",
+			Short: "list queued jobs",
+			Long:  "list jobs by ids, or all the jobs matching repo sync id and states if no id is provided, the most recent first",
+			Args:  cobra.NoArgs,
+		},
+		listRequest: &svc.ListJobsRequest{},
+	}
+
+	r.Flags().UintSliceVarP(&r.id, "id", "i", r.id, "ids of the jobs to list")
+	r.Flags().StringVar(&r.listRequest.RepoSyncId, "repo-sync-id", r.listRequest.RepoSyncId, "only list jobs of the repo sync")
+	r.Flags().StringArrayVar(&r.states, "state", r.states, "only list jobs in the states, like PENDING or FAILED")
+	r.Flags().Int32Var(&r.listRequest.PageSize, "limit", 20, "max number of jobs to list")
+	r.MarkFlagsMutuallyExclusive("id", "repo-sync-id")
+	r.MarkFlagsMutuallyExclusive("id", "state")
+
+	r.Run = torun
+
+	return r
+}
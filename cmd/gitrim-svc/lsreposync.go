@@ -1,6 +1,10 @@
 package main
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/gitrim/svc"
+)
 
 type lsRepoSyncCmd struct {
 	*cobra.Command
@@ -9,6 +13,8 @@ type lsRepoSyncCmd struct {
 	showmap   bool
 	checkstat bool
 
+	listRequest *svc.ListRepoSyncsRequest
+
 	overrideFromBranch string
 	overrideToBranch   string
 }
@@ -18,11 +24,19 @@ func newLsRepoSyncCmd(torun func(cmd *cobra.Command, args []string)) *lsRepoSync
 		Command: &cobra.Command{
 			Use:   "ls",
 			Short: "list repo syncs",
+			Long:  "list repo syncs by ids, or all the repo syncs matching remote, owner, and repo if no id is provided",
 			Args:  cobra.NoArgs,
 		},
+		listRequest: &svc.ListRepoSyncsRequest{},
 	}
 
 	r.Flags().StringArrayVarP(&r.id, "id", "i", r.id, "ids to list")
+	r.Flags().StringVar(&r.listRequest.RemoteName, "remote", r.listRequest.RemoteName, "only list repo syncs with from or to repo on the remote")
+	r.Flags().StringVar(&r.listRequest.Owner, "owner", r.listRequest.Owner, "only list repo syncs with from or to repo of the owner")
+	r.Flags().StringVar(&r.listRequest.Repo, "repo", r.listRequest.Repo, "only list repo syncs with from or to repo of the name")
+	r.MarkFlagsMutuallyExclusive("id", "remote")
+	r.MarkFlagsMutuallyExclusive("id", "owner")
+	r.MarkFlagsMutuallyExclusive("id", "repo")
 	r.Flags().StringVar(&r.overrideFromBranch, "from-branch", r.overrideFromBranch, "override from branch")
 	r.Flags().StringVar(&r.overrideToBranch, "to-branch", r.overrideToBranch, "override to branch")
 	r.Flags().BoolVarP(&r.checkstat, "check-repo-stat", "s", r.checkstat, "check repo stat")
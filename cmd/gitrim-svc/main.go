@@ -1,13 +1,20 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/fardream/gitrim/cmd"
 	"github.com/fardream/gitrim/svc"
@@ -22,10 +29,27 @@ type rootCmd struct {
 
 	configPath string
 
+	serverFlags serverFlags
+
 	initRepoSyncCmd *initRepoSyncCmd
 	lsRepoSyncCmd   *lsRepoSyncCmd
 	syncToSubCmd    *syncToSubCmd
 	syncToFromCmd   *syncToFromCmd
+	applyPatchCmd   *applyPatchCmd
+	syncToBundleCmd *syncToBundleCmd
+	serveCmd        *serveCmd
+
+	updateRepoSyncCmd *updateRepoSyncCmd
+	deleteRepoSyncCmd *deleteRepoSyncCmd
+	updateFilterCmd   *updateFilterCmd
+	lsJobsCmd         *lsJobsCmd
+	watchCmd          *watchCmd
+	exportAuditCmd    *exportAuditCmd
+	rotateSecretCmd   *rotateSecretCmd
+	migrateCmd        *migrateCmd
+	backupCmd         *backupCmd
+	exportCmd         *exportCmd
+	importCmd         *importCmd
 }
 
 func newRootCmd() *rootCmd {
@@ -39,6 +63,7 @@ func newRootCmd() *rootCmd {
 
 	c.PersistentFlags().StringVarP(&c.configPath, "config", "c", c.configPath, "path to the configuration")
 	c.MarkPersistentFlagFilename("config")
+	c.serverFlags.addFlags(c.Command)
 
 	c.initRepoSyncCmd = newInitRepoSyncCmd(func(*cobra.Command, []string) {
 		c.runInitRepoSync()
@@ -49,11 +74,53 @@ func newRootCmd() *rootCmd {
 	c.syncToFromCmd = newSyncToFromCmd(func(*cobra.Command, []string) {
 		c.runSyncToFrom()
 	})
+	c.applyPatchCmd = newApplyPatchCmd(func(*cobra.Command, []string) {
+		c.runApplyPatch()
+	})
+	c.syncToBundleCmd = newSyncToBundleCmd(func(*cobra.Command, []string) {
+		c.runSyncToBundle()
+	})
+	c.serveCmd = newServeCmd(func(*cobra.Command, []string) {
+		c.runServe()
+	})
+	c.updateRepoSyncCmd = newUpdateRepoSyncCmd(func(*cobra.Command, []string) {
+		c.runUpdateRepoSync()
+	})
+	c.deleteRepoSyncCmd = newDeleteRepoSyncCmd(func(*cobra.Command, []string) {
+		c.runDeleteRepoSync()
+	})
+	c.updateFilterCmd = newUpdateFilterCmd(func(*cobra.Command, []string) {
+		c.runUpdateFilter()
+	})
 	c.lsRepoSyncCmd = newLsRepoSyncCmd(func(*cobra.Command, []string) {
 		c.runLs()
 	})
+	c.lsJobsCmd = newLsJobsCmd(func(*cobra.Command, []string) {
+		c.runLsJobs()
+	})
+	c.watchCmd = newWatchCmd(func(*cobra.Command, []string) {
+		c.runWatch()
+	})
+	c.exportAuditCmd = newExportAuditCmd(func(*cobra.Command, []string) {
+		c.runExportAudit()
+	})
+	c.rotateSecretCmd = newRotateSecretCmd(func(*cobra.Command, []string) {
+		c.runRotateSecret()
+	})
+	c.migrateCmd = newMigrateCmd(func(*cobra.Command, []string) {
+		c.runMigrate()
+	})
+	c.backupCmd = newBackupCmd(func(*cobra.Command, []string) {
+		c.runBackup()
+	})
+	c.exportCmd = newExportCmd(func(*cobra.Command, []string) {
+		c.runExport()
+	})
+	c.importCmd = newImportCmd(func(*cobra.Command, []string) {
+		c.runImport()
+	})
 
-	c.AddCommand(c.initRepoSyncCmd.Command, c.syncToSubCmd.Command, c.lsRepoSyncCmd.Command, c.syncToFromCmd.Command)
+	c.AddCommand(c.initRepoSyncCmd.Command, c.syncToSubCmd.Command, c.lsRepoSyncCmd.Command, c.syncToFromCmd.Command, c.applyPatchCmd.Command, c.syncToBundleCmd.Command, c.serveCmd.Command, c.updateRepoSyncCmd.Command, c.deleteRepoSyncCmd.Command, c.updateFilterCmd.Command, c.lsJobsCmd.Command, c.watchCmd.Command, c.exportAuditCmd.Command, c.rotateSecretCmd.Command, c.migrateCmd.Command, c.backupCmd.Command, c.exportCmd.Command, c.importCmd.Command)
 
 	return c
 }
@@ -62,10 +129,8 @@ func (c *rootCmd) runInitRepoSync() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	config := cmd.GetOrPanic(svc.ParseConfigYAML(cmd.GetOrPanic(os.ReadFile(c.configPath))))
-
-	s := cmd.GetOrPanic(svc.New(config))
-	defer s.Close()
+	s, closeclient := c.newClient()
+	defer closeclient()
 
 	filter := cmd.GetOrPanic(os.ReadFile(c.initRepoSyncCmd.filterFile))
 
@@ -79,10 +144,22 @@ func (c *rootCmd) runSyncToSub() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	config := cmd.GetOrPanic(svc.ParseConfigYAML(cmd.GetOrPanic(os.ReadFile(c.configPath))))
-
-	s := cmd.GetOrPanic(svc.New(config))
-	defer s.Close()
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	// the overrides of the branches are not supported by the queue, and the flags are mutually exclusive with --queue.
+	if c.syncToSubCmd.queue {
+		resp := cmd.GetOrPanic(s.EnqueueJob(ctx, &svc.EnqueueJobRequest{
+			Request: &svc.EnqueueJobRequest_SyncToSubRepo{
+				SyncToSubRepo: &svc.SyncToSubRepoRequest{
+					Id:    c.syncToSubCmd.id,
+					Force: c.syncToSubCmd.force,
+				},
+			},
+		}))
+		fmt.Println(PrintProtoText(resp.Job))
+		return
+	}
 
 	resp := cmd.GetOrPanic(s.SyncToSubRepo(ctx,
 		&svc.SyncToSubRepoRequest{
@@ -99,28 +176,33 @@ func (c *rootCmd) runLs() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	config := cmd.GetOrPanic(svc.ParseConfigYAML(cmd.GetOrPanic(os.ReadFile(c.configPath))))
-
-	s := cmd.GetOrPanic(svc.New(config))
-	defer s.Close()
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	ids := c.lsRepoSyncCmd.id
+	if len(ids) == 0 {
+		req := c.lsRepoSyncCmd.listRequest
+		for {
+			resp := cmd.GetOrPanic(s.ListRepoSyncs(ctx, req))
+			for _, reposync := range resp.RepoSyncs {
+				ids = append(ids, reposync.Id)
+			}
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+	}
 
 printloop:
-	for _, id := range c.lsRepoSyncCmd.id {
-		resp, err := s.GetRepoSync(ctx, &svc.GetRepoSyncRequest{Id: id})
+	for _, id := range ids {
+		resp, err := s.GetRepoSync(ctx, &svc.GetRepoSyncRequest{Id: id, WithStatMaps: c.lsRepoSyncCmd.showmap})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "failed to list id: %s\nerror:\n%s\n", id, err.Error())
 			continue printloop
 		}
 		fmt.Println((PrintProtoText(resp.RepoSync)))
-		if c.lsRepoSyncCmd.showmap {
-			fmt.Println((PrintProtoText(resp.SyncStat)))
-		} else {
-			resp.SyncStat.FromDfs = nil
-			clear(resp.SyncStat.FromToTo)
-			resp.SyncStat.ToDfs = nil
-			clear(resp.SyncStat.ToToFrom)
-			fmt.Println((PrintProtoText(resp.SyncStat)))
-		}
+		fmt.Println((PrintProtoText(resp.SyncStat)))
 		if !c.lsRepoSyncCmd.checkstat {
 			continue printloop
 		}
@@ -142,12 +224,22 @@ func (c *rootCmd) runSyncToFrom() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	config := cmd.GetOrPanic(svc.ParseConfigYAML(cmd.GetOrPanic(os.ReadFile(c.configPath))))
-
-	s := cmd.GetOrPanic(svc.New(config))
-	defer s.Close()
-
-	if c.syncToFromCmd.noDryrun {
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	// the overrides of the branches are not supported by the queue, and the flags are mutually exclusive with --queue.
+	if c.syncToFromCmd.queue {
+		resp := cmd.GetOrPanic(s.EnqueueJob(ctx, &svc.EnqueueJobRequest{
+			Request: &svc.EnqueueJobRequest_CommitsFromSubRepo{
+				CommitsFromSubRepo: &svc.CommitsFromSubRepoRequest{
+					Id:                c.syncToFromCmd.id,
+					AllowPgpSignature: c.syncToFromCmd.allowGpg,
+					DoPush:            true,
+				},
+			},
+		}))
+		fmt.Println(PrintProtoText(resp.Job))
+	} else if c.syncToFromCmd.noDryrun {
 		resp := cmd.GetOrPanic(
 			s.CommitsFromSubRepo(
 				ctx,
@@ -173,3 +265,448 @@ func (c *rootCmd) runSyncToFrom() {
 		fmt.Println(PrintProtoText(resp))
 	}
 }
+
+func (c *rootCmd) runLsJobs() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	if len(c.lsJobsCmd.id) > 0 {
+		for _, id := range c.lsJobsCmd.id {
+			resp, err := s.GetJob(ctx, &svc.GetJobRequest{Id: uint64(id)})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to get job: %d\nerror:\n%s\n", id, err.Error())
+				continue
+			}
+			fmt.Println(PrintProtoText(resp.Job))
+		}
+		return
+	}
+
+	req := c.lsJobsCmd.listRequest
+	for _, state := range c.lsJobsCmd.states {
+		v, ok := svc.Job_State_value[state]
+		if !ok {
+			cmd.OrPanic(fmt.Errorf("unknown job state: %s", state))
+		}
+		req.States = append(req.States, svc.Job_State(v))
+	}
+
+	resp := cmd.GetOrPanic(s.ListJobs(ctx, req))
+	for _, job := range resp.Jobs {
+		fmt.Println(PrintProtoText(job))
+	}
+}
+
+func (c *rootCmd) runWatch() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	stream := cmd.GetOrPanic(s.WatchSyncEvents(ctx, c.watchCmd.request))
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			cmd.OrPanic(err)
+		}
+		fmt.Println(PrintProtoText(event))
+	}
+}
+
+func (c *rootCmd) runExportAudit() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	out := os.Stdout
+	if c.exportAuditCmd.output != "-" {
+		out = cmd.GetOrPanic(os.Create(c.exportAuditCmd.output))
+		defer out.Close()
+	}
+	w := bufio.NewWriter(out)
+
+	req := c.exportAuditCmd.request
+	req.PageSize = 1000
+	for {
+		resp := cmd.GetOrPanic(s.ListAuditEvents(ctx, req))
+		for _, event := range resp.Events {
+			cmd.GetOrPanic(w.Write(cmd.GetOrPanic(protojson.Marshal(event))))
+			cmd.OrPanic(w.WriteByte('\n'))
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	cmd.OrPanic(w.Flush())
+}
+
+func (c *rootCmd) runApplyPatch() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var mbox []byte
+	if c.applyPatchCmd.mboxFile == "-" {
+		mbox = cmd.GetOrPanic(io.ReadAll(os.Stdin))
+	} else {
+		mbox = cmd.GetOrPanic(os.ReadFile(c.applyPatchCmd.mboxFile))
+	}
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	resp := cmd.GetOrPanic(
+		s.CommitsFromPatches(
+			ctx,
+			&svc.CommitsFromPatchesRequest{
+				Id:                 c.applyPatchCmd.id,
+				OverrideFromBranch: c.applyPatchCmd.overrideFromBranch,
+				OverrideToBranch:   c.applyPatchCmd.overrideToBranch,
+				Mbox:               mbox,
+				DoPush:             c.applyPatchCmd.noDryrun,
+			}))
+
+	fmt.Println(PrintProtoText(resp))
+}
+
+func (c *rootCmd) runSyncToBundle() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	stream := cmd.GetOrPanic(s.SyncToSubRepoBundle(ctx,
+		&svc.SyncToSubRepoBundleRequest{
+			Id:                 c.syncToBundleCmd.id,
+			OverrideFromBranch: c.syncToBundleCmd.overrideFromBranch,
+			OverrideToBranch:   c.syncToBundleCmd.overrideToBranch,
+			Incremental:        c.syncToBundleCmd.incremental,
+			BundleVersion:      c.syncToBundleCmd.bundleVersion,
+		}))
+
+	resp := cmd.GetOrPanic(stream.Recv())
+
+	// the bundle is written to a temporary file, so a failed sync doesn't leave a broken bundle.
+	var out *os.File
+	tmp := c.syncToBundleCmd.output + ".tmp"
+	defer func() {
+		if out != nil {
+			out.Close()
+			os.Remove(tmp)
+		}
+	}()
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		cmd.OrPanic(err)
+		if out == nil {
+			out = cmd.GetOrPanic(os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644))
+		}
+		cmd.GetOrPanic(out.Write(chunk.Bundle))
+	}
+	if out != nil {
+		cmd.OrPanic(out.Close())
+		cmd.OrPanic(os.Rename(tmp, c.syncToBundleCmd.output))
+		out = nil
+	}
+
+	fmt.Println(PrintProtoText(resp))
+}
+
+func (c *rootCmd) runUpdateRepoSync() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if c.updateRepoSyncCmd.filterFile != "" {
+		c.updateRepoSyncCmd.request.Filter = string(cmd.GetOrPanic(os.ReadFile(c.updateRepoSyncCmd.filterFile)))
+	}
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	resp := cmd.GetOrPanic(s.UpdateRepoSync(ctx, c.updateRepoSyncCmd.request))
+
+	fmt.Println(PrintProtoText(resp))
+}
+
+func (c *rootCmd) runDeleteRepoSync() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	for _, id := range c.deleteRepoSyncCmd.id {
+		resp, err := s.DeleteRepoSync(ctx, &svc.DeleteRepoSyncRequest{Id: id})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to delete id: %s\nerror:\n%s\n", id, err.Error())
+			continue
+		}
+		fmt.Println(PrintProtoText(resp))
+	}
+}
+
+func (c *rootCmd) runRotateSecret() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	resp := cmd.GetOrPanic(s.RotateSecret(ctx, c.rotateSecretCmd.request))
+	fmt.Println(PrintProtoText(resp))
+}
+
+func (c *rootCmd) runMigrate() {
+	config := cmd.GetOrPanic(svc.ParseConfigYAML(cmd.GetOrPanic(os.ReadFile(c.configPath))))
+
+	result := cmd.GetOrPanic(svc.MigrateDb(config, c.migrateCmd.dryRun))
+
+	fmt.Printf("schema version: %d -> %d\n", result.FromVersion, result.ToVersion)
+	for _, m := range result.Applied {
+		fmt.Printf("  %d: %s\n", m.Version, m.Name)
+	}
+	if result.BackupPath != "" {
+		fmt.Printf("backup: %s\n", result.BackupPath)
+	}
+}
+
+func (c *rootCmd) runBackup() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	stream := cmd.GetOrPanic(s.Backup(ctx, &svc.BackupRequest{}))
+
+	// write to a temporary file, so a failed backup doesn't leave a broken db.
+	tmp := c.backupCmd.output + ".tmp"
+	out := cmd.GetOrPanic(os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600))
+	defer os.Remove(tmp)
+	defer out.Close()
+
+	var size, written int64
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		cmd.OrPanic(err)
+		if resp.Size > 0 {
+			size = resp.Size
+		}
+		written += int64(cmd.GetOrPanic(out.Write(resp.Data)))
+	}
+	if written != size {
+		cmd.OrPanic(fmt.Errorf("backup is incomplete: received %d bytes of %d", written, size))
+	}
+
+	cmd.OrPanic(out.Sync())
+	cmd.OrPanic(out.Close())
+	cmd.OrPanic(os.Rename(tmp, c.backupCmd.output))
+
+	fmt.Printf("backed up %d bytes to %s\n", written, c.backupCmd.output)
+}
+
+// readKeyFile reads the hex of an AES key from the file.
+func readKeyFile(path string) string {
+	return strings.TrimSpace(string(cmd.GetOrPanic(os.ReadFile(path))))
+}
+
+func checkExportFormat(format string) {
+	if format != "json" && format != "proto" {
+		cmd.OrPanic(fmt.Errorf("unknown format %q, must be json or proto", format))
+	}
+}
+
+func (c *rootCmd) runExport() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	checkExportFormat(c.exportCmd.format)
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	req := c.exportCmd.request
+	req.Key = readKeyFile(c.exportCmd.keyFile)
+	stream := cmd.GetOrPanic(s.Export(ctx, req))
+
+	out := os.Stdout
+	if c.exportCmd.output != "-" {
+		out = cmd.GetOrPanic(os.OpenFile(c.exportCmd.output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600))
+		defer out.Close()
+	}
+	w := bufio.NewWriter(out)
+
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		cmd.OrPanic(err)
+		if c.exportCmd.format == "proto" {
+			cmd.GetOrPanic(protodelim.MarshalTo(w, record))
+			continue
+		}
+		cmd.GetOrPanic(w.Write(cmd.GetOrPanic(protojson.Marshal(record))))
+		cmd.OrPanic(w.WriteByte('\n'))
+	}
+
+	cmd.OrPanic(w.Flush())
+}
+
+func (c *rootCmd) runImport() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	checkExportFormat(c.importCmd.format)
+	onconflict, ok := svc.ImportRequest_OnConflict_value[strings.ToUpper(c.importCmd.onConflict)]
+	if !ok {
+		cmd.OrPanic(fmt.Errorf("unknown on-conflict %q, must be fail, skip, or overwrite", c.importCmd.onConflict))
+	}
+
+	in := os.Stdin
+	if c.importCmd.input != "-" {
+		in = cmd.GetOrPanic(os.Open(c.importCmd.input))
+		defer in.Close()
+	}
+	r := bufio.NewReader(in)
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	stream := cmd.GetOrPanic(s.Import(ctx))
+
+	req := &svc.ImportRequest{
+		Key:        readKeyFile(c.importCmd.keyFile),
+		OnConflict: svc.ImportRequest_OnConflict(onconflict),
+	}
+	for {
+		record := &svc.ExportedRecord{}
+		if c.importCmd.format == "proto" {
+			err := protodelim.UnmarshalFrom(r, record)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			cmd.OrPanic(err)
+		} else {
+			line, err := r.ReadBytes('\n')
+			if errors.Is(err, io.EOF) && len(bytes.TrimSpace(line)) == 0 {
+				break
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				cmd.OrPanic(err)
+			}
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			cmd.OrPanic(protojson.Unmarshal(line, record))
+		}
+
+		req.Record = record
+		if err := stream.Send(req); err != nil {
+			// the error of the service is returned by CloseAndRecv.
+			break
+		}
+		req = &svc.ImportRequest{}
+	}
+
+	resp := cmd.GetOrPanic(stream.CloseAndRecv())
+	fmt.Println(PrintProtoText(resp))
+}
+
+func (c *rootCmd) runUpdateFilter() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	filter := cmd.GetOrPanic(os.ReadFile(c.updateFilterCmd.filterFile))
+
+	s, closeclient := c.newClient()
+	defer closeclient()
+
+	resp := cmd.GetOrPanic(s.UpdateRepoSyncFilter(ctx,
+		&svc.UpdateRepoSyncFilterRequest{
+			Id:          c.updateFilterCmd.id,
+			Filter:      string(filter),
+			NewToBranch: c.updateFilterCmd.newToBranch,
+			Force:       c.updateFilterCmd.force,
+			DoPush:      c.updateFilterCmd.noDryrun,
+		}))
+
+	fmt.Println(PrintProtoText(resp))
+}
+
+func (c *rootCmd) runServe() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	config := cmd.GetOrPanic(svc.ParseConfigYAML(cmd.GetOrPanic(os.ReadFile(c.configPath))))
+	if c.serveCmd.requireAesKey {
+		config.RequireAesKey = true
+	}
+
+	s := cmd.GetOrPanic(svc.New(config))
+	defer s.Close()
+
+	// webhook is optional, and stops the grpc server if it fails.
+	webhookerr := make(chan error, 1)
+	if config.WebhookAddress != "" {
+		go func() {
+			webhookerr <- s.ServeWebhook(ctx)
+			cancel()
+		}()
+	} else {
+		webhookerr <- nil
+	}
+
+	// jobs queued by the webhooks and the clients run in the background.
+	joberr := make(chan error, 1)
+	go func() {
+		joberr <- s.RunJobWorkers(ctx)
+		cancel()
+	}()
+
+	// scheduler runs only when poll_interval_secs is set.
+	schedulererr := make(chan error, 1)
+	if config.PollIntervalSecs > 0 {
+		go func() {
+			schedulererr <- s.RunScheduler(ctx)
+			cancel()
+		}()
+	} else {
+		schedulererr <- nil
+	}
+
+	// metrics are served only when metrics_address is set.
+	metricserr := make(chan error, 1)
+	if config.MetricsAddress != "" {
+		go func() {
+			metricserr <- s.ServeMetrics(ctx)
+			cancel()
+		}()
+	} else {
+		metricserr <- nil
+	}
+
+	cmd.OrPanic(s.Serve(ctx))
+	cancel()
+	cmd.OrPanic(<-webhookerr)
+	cmd.OrPanic(<-metricserr)
+	cmd.OrPanic(<-schedulererr)
+	cmd.OrPanic(<-joberr)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRootCmd_queueOverrides(t *testing.T) {
+	for _, args := range [][]string{
+		{"sync-to-sub", "--id", "00", "--queue", "--from-branch", "dev"},
+		{"sync-to-sub", "--id", "00", "--queue", "--to-branch", "dev"},
+		{"sync-to-from", "--id", "00", "--queue", "--from-branch", "dev"},
+		{"sync-to-from", "--id", "00", "--queue", "--to-branch", "dev"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			c := newRootCmd()
+			c.SetArgs(args)
+			c.SetOut(io.Discard)
+			c.SetErr(io.Discard)
+			// the command fails before it runs, so no client is created.
+			if err := c.Execute(); err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+				t.Errorf("want the overrides rejected with --queue, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "github.com/spf13/cobra"
+
+type migrateCmd struct {
+	*cobra.Command
+
+	dryRun bool
+}
+
+func newMigrateCmd(torun func(*cobra.Command, []string)) *migrateCmd {
+	r := &migrateCmd{
+		Command: &cobra.Command{
+			Use:   "migrate",
+			Short: "migrate the db to the latest schema version",
+			Long:  "migrate the db in the config to the latest schema version after copying it to db_backup_dir, which is also done when the service starts. The service must be stopped.",
+			Args:  cobra.NoArgs,
+		},
+	}
+
+	r.Flags().BoolVar(&r.dryRun, "dry-run", r.dryRun, "only run the pending migrations in a transaction that is rolled back")
+
+	r.Run = torun
+
+	return r
+}
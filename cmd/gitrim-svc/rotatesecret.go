@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/gitrim/svc"
+)
+
+type rotateSecretCmd struct {
+	*cobra.Command
+
+	request *svc.RotateSecretRequest
+}
+
+func newRotateSecretCmd(torun func(*cobra.Command, []string)) *rotateSecretCmd {
+	r := &rotateSecretCmd{
+		Command: &cobra.Command{
+			Use:   "rotate-secret",
+			Short: "issue a new secret for a repo sync",
+			Long:  "issue a new secret sealed with the active aes key for a repo sync, the webhooks on the forges are updated, and the old secret is accepted for the grace period",
+			Args:  cobra.NoArgs,
+		},
+		request: &svc.RotateSecretRequest{},
+	}
+
+	r.Flags().StringVarP(&r.request.Id, "id", "i", r.request.Id, "id of the repo sync")
+	r.MarkFlagRequired("id")
+	r.Flags().Int32Var(&r.request.GracePeriodSecs, "grace-period-secs", r.request.GracePeriodSecs, "seconds the old secret is still accepted, 0 for 1 day, negative to expire it immediately")
+
+	r.Run = torun
+
+	return r
+}
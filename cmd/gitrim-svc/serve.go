@@ -0,0 +1,25 @@
+package main
+
+import "github.com/spf13/cobra"
+
+type serveCmd struct {
+	*cobra.Command
+
+	requireAesKey bool
+}
+
+func newServeCmd(torun func(*cobra.Command, []string)) *serveCmd {
+	r := &serveCmd{
+		Command: &cobra.Command{
+			Use:   "serve",
+			Short: "serve the grpc service on admin address, and the webhooks on webhook address",
+			Args:  cobra.NoArgs,
+		},
+	}
+
+	r.Flags().BoolVar(&r.requireAesKey, "require-aes-key", r.requireAesKey, "refuse to start if the secrets are sealed with the all-zero key, same as require_aes_key in the config")
+
+	r.Run = torun
+
+	return r
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/gitrim"
+)
+
+type syncToBundleCmd struct {
+	*cobra.Command
+
+	id            string
+	output        string
+	incremental   bool
+	bundleVersion int32
+
+	overrideFromBranch string
+	overrideToBranch   string
+}
+
+func newSyncToBundleCmd(torun func(*cobra.Command, []string)) *syncToBundleCmd {
+	r := &syncToBundleCmd{
+		Command: &cobra.Command{
+			Use:   "sync-to-bundle",
+			Short: "sync from original repo to a git bundle file instead of the sub repo",
+			Args:  cobra.NoArgs,
+		},
+		bundleVersion: gitrim.BundleV2,
+	}
+
+	r.Flags().StringVarP(&r.id, "id", "i", r.id, "id of the sync")
+	r.MarkFlagRequired("id")
+	r.Flags().StringVarP(&r.output, "output", "o", r.output, "output bundle file")
+	r.MarkFlagRequired("output")
+	r.MarkFlagFilename("output")
+	r.Flags().BoolVar(&r.incremental, "incremental", r.incremental, "only include commits after the head of the last bundle")
+	r.Flags().Int32Var(&r.bundleVersion, "bundle-version", r.bundleVersion, "version of the git bundle, 2 or 3")
+	r.Flags().StringVar(&r.overrideFromBranch, "from-branch", r.overrideFromBranch, "override from branch")
+	r.Flags().StringVar(&r.overrideToBranch, "to-branch", r.overrideToBranch, "override to branch")
+
+	r.Run = torun
+
+	return r
+}
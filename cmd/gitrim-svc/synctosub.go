@@ -7,6 +7,7 @@ type syncToSubCmd struct {
 
 	id    string
 	force bool
+	queue bool
 
 	overrideFromBranch string
 	overrideToBranch   string
@@ -26,6 +27,9 @@ func newSyncToSubCmd(torun func(*cobra.Command, []string)) *syncToSubCmd {
 	r.Flags().BoolVarP(&r.force, "force", "f", r.force, "force push")
 	r.Flags().StringVar(&r.overrideFromBranch, "from-branch", r.overrideFromBranch, "override from branch")
 	r.Flags().StringVar(&r.overrideToBranch, "to-branch", r.overrideToBranch, "override to branch")
+	r.Flags().BoolVarP(&r.queue, "queue", "q", r.queue, "queue a job for the sync on the server instead of waiting for it, see ls-jobs")
+	r.MarkFlagsMutuallyExclusive("queue", "from-branch")
+	r.MarkFlagsMutuallyExclusive("queue", "to-branch")
 
 	r.Run = torun
 
@@ -0,0 +1,40 @@
+package main
+
+import "github.com/spf13/cobra"
+
+type updateFilterCmd struct {
+	*cobra.Command
+
+	id          string
+	filterFile  string
+	newToBranch string
+	force       bool
+	noDryrun    bool
+}
+
+func newUpdateFilterCmd(torun func(*cobra.Command, []string)) *updateFilterCmd {
+	r := &updateFilterCmd{
+		Command: &cobra.Command{
+			Use:   "update-filter",
+			Short: "change the filter of a repo sync and rebuild the sub repo",
+			Long: `change the filter of a repo sync and rebuild the sub repo.
+
+By default, only the paths in the history of the original repo that appear or disappear with the new filter are printed.
+With --no-dryrun, the history of the sub repo is rebuilt with the new filter, and pushed to --new-to-branch, or force pushed to the to branch with --force.`,
+			Args: cobra.NoArgs,
+		},
+	}
+
+	r.Flags().StringVarP(&r.id, "id", "i", r.id, "id of the sync")
+	r.MarkFlagRequired("id")
+	r.Flags().StringVarP(&r.filterFile, "filter", "f", r.filterFile, "file contains the new filters for this repo sync")
+	r.MarkFlagFilename("filter")
+	r.MarkFlagRequired("filter")
+	r.Flags().StringVar(&r.newToBranch, "new-to-branch", r.newToBranch, "push the rebuilt history to a new branch, which changes the id of the repo sync")
+	r.Flags().BoolVar(&r.force, "force", r.force, "force push the rebuilt history")
+	r.Flags().BoolVarP(&r.noDryrun, "no-dryrun", "p", r.noDryrun, "rebuild and push the sub repo")
+
+	r.Run = torun
+
+	return r
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/gitrim/svc"
+)
+
+type updateRepoSyncCmd struct {
+	*cobra.Command
+
+	filterFile string
+
+	request *svc.UpdateRepoSyncRequest
+}
+
+func newUpdateRepoSyncCmd(torun func(*cobra.Command, []string)) *updateRepoSyncCmd {
+	r := &updateRepoSyncCmd{
+		Command: &cobra.Command{
+			Use:   "update-repo-sync",
+			Short: "update the repos, branches, or filter of a repo sync",
+			Long: `update the repos, branches, or filter of a repo sync.
+
+Only the provided values are updated. Since the id of the repo sync is generated from the repos and branches, updating them moves the repo sync to a new id with a new secret.
+The filter can only be reformatted or commented, the canonical filters must stay the same.`,
+			Args: cobra.NoArgs,
+		},
+		request: &svc.UpdateRepoSyncRequest{
+			FromRepo: &svc.GitRepoIdentifier{},
+			ToRepo:   &svc.GitRepoIdentifier{},
+		},
+	}
+
+	r.Flags().StringVarP(&r.request.Id, "id", "i", r.request.Id, "id of the sync")
+	r.MarkFlagRequired("id")
+	r.Flags().StringVarP(&r.filterFile, "filter", "f", r.filterFile, "file contains the new filters for this repo sync")
+	r.MarkFlagFilename("filter")
+	r.Flags().StringVar(&r.request.FromRepo.RemoteName, "from-remote", r.request.FromRepo.RemoteName, "new from remote")
+	r.Flags().StringVar(&r.request.FromRepo.Owner, "from-owner", r.request.FromRepo.Owner, "new from owner")
+	r.Flags().StringVar(&r.request.FromRepo.Repo, "from-repo", r.request.FromRepo.Repo, "new from repo")
+	r.Flags().StringVar(&r.request.FromBranch, "from-branch", r.request.FromBranch, "new from branch")
+	r.Flags().StringVar(&r.request.ToRepo.RemoteName, "to-remote", r.request.ToRepo.RemoteName, "new to remote")
+	r.Flags().StringVar(&r.request.ToRepo.Owner, "to-owner", r.request.ToRepo.Owner, "new to owner")
+	r.Flags().StringVar(&r.request.ToRepo.Repo, "to-repo", r.request.ToRepo.Repo, "new to repo")
+	r.Flags().StringVar(&r.request.ToBranch, "to-branch", r.request.ToBranch, "new to branch")
+
+	r.Run = torun
+
+	return r
+}
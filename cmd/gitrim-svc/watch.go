@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/fardream/gitrim/svc"
+)
+
+type watchCmd struct {
+	*cobra.Command
+
+	request *svc.WatchSyncEventsRequest
+}
+
+func newWatchCmd(torun func(*cobra.Command, []string)) *watchCmd {
+	r := &watchCmd{
+		Command: &cobra.Command{
+			Use:   "watch",
+			Short: "watch the sync events on the server",
+			Long:  "watch the sync events on the server: commits filtered or expanded, branches pushed, and commits from sub repos rejected",
+			Args:  cobra.NoArgs,
+		},
+		request: &svc.WatchSyncEventsRequest{},
+	}
+
+	r.Flags().StringArrayVarP(&r.request.Ids, "id", "i", r.request.Ids, "only watch the events of the repo syncs")
+	r.Flags().BoolVar(&r.request.Resume, "resume", r.request.Resume, "replay the events kept on the server after --after-sequence first")
+	r.Flags().Uint64Var(&r.request.AfterSequence, "after-sequence", r.request.AfterSequence, "sequence of the last event seen")
+
+	r.Run = torun
+
+	return r
+}
@@ -0,0 +1,626 @@
+package gitrim
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+var ErrUnsupportedFastExportCommand = errors.New("unsupported fast-export command")
+
+// LoadFastExport reads a stream generated by "git fast-export" (or any stream that can be consumed by "git fast-import"), and saves
+// the blobs, trees, commits, and tags into s. The branches and tags are also set as references in s, and if s doesn't have HEAD,
+// HEAD will point to the branch of the last commit in the stream.
+//
+// Commits in the stream generate the exact same hashes as the original repo, unless the original commits have information
+// dropped by "git fast-export", such as gpg signatures.
+//
+// It returns the references updated by the stream.
+func LoadFastExport(ctx context.Context, r io.Reader, s storer.Storer) (map[plumbing.ReferenceName]plumbing.Hash, error) {
+	l := &fastExportLoader{
+		r:     bufio.NewReader(r),
+		s:     s,
+		marks: make(map[int]plumbing.Hash),
+		refs:  make(map[plumbing.ReferenceName]plumbing.Hash),
+	}
+
+	if err := l.load(ctx); err != nil {
+		return nil, errorf(err, "failed at line %d of the stream: %w", l.lineno, err)
+	}
+
+	for name, h := range l.refs {
+		var err error
+		if h.IsZero() {
+			err = s.RemoveReference(name)
+		} else {
+			err = s.SetReference(plumbing.NewHashReference(name, h))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to set reference %s: %w", name, err)
+		}
+	}
+
+	if l.lastRef != "" {
+		if _, err := s.Reference(plumbing.HEAD); errors.Is(err, plumbing.ErrReferenceNotFound) {
+			if err := s.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, l.lastRef)); err != nil {
+				return nil, fmt.Errorf("failed to set HEAD: %w", err)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read HEAD: %w", err)
+		}
+	}
+
+	return l.refs, nil
+}
+
+type fastExportLoader struct {
+	r      *bufio.Reader
+	lineno int
+	// line that is read but not consumed.
+	pending    string
+	hasPending bool
+
+	s storer.Storer
+
+	marks   map[int]plumbing.Hash
+	refs    map[plumbing.ReferenceName]plumbing.Hash
+	lastRef plumbing.ReferenceName
+}
+
+// readLine returns the next line without the trailing LF, io.EOF is returned at the end of the stream.
+func (l *fastExportLoader) readLine() (string, error) {
+	if l.hasPending {
+		l.hasPending = false
+		return l.pending, nil
+	}
+
+	line, err := l.r.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+		return "", err
+	}
+	l.lineno++
+
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+func (l *fastExportLoader) unreadLine(line string) {
+	l.pending = line
+	l.hasPending = true
+}
+
+// readOptional reads the line with the prefix, or leaves the line for the next read if it doesn't have the prefix.
+func (l *fastExportLoader) readOptional(prefix string) (string, bool, error) {
+	line, err := l.readLine()
+	if errors.Is(err, io.EOF) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if rest, found := strings.CutPrefix(line, prefix); found {
+		return rest, true, nil
+	}
+	l.unreadLine(line)
+
+	return "", false, nil
+}
+
+// readData reads the data command, either exact byte count "data <count>", or delimited "data <<<delim>".
+func (l *fastExportLoader) readData(line string) ([]byte, error) {
+	arg, found := strings.CutPrefix(line, "data ")
+	if !found {
+		return nil, fmt.Errorf("expecting data, got %q", line)
+	}
+
+	if delim, isdelim := strings.CutPrefix(arg, "<<"); isdelim {
+		var buf bytes.Buffer
+		for {
+			line, err := l.readLine()
+			if err != nil {
+				return nil, fmt.Errorf("failed to find delimiter %s: %w", delim, err)
+			}
+			if line == delim {
+				return buf.Bytes(), nil
+			}
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+	}
+
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data length %q: %w", arg, err)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(l.r, data); err != nil {
+		return nil, fmt.Errorf("failed to read data of %d bytes: %w", n, err)
+	}
+	l.lineno += bytes.Count(data, []byte{'\n'})
+
+	// optional LF after data
+	if b, err := l.r.Peek(1); err == nil && b[0] == '\n' {
+		l.r.ReadByte()
+		l.lineno++
+	}
+
+	return data, nil
+}
+
+func (l *fastExportLoader) load(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		line, err := l.readLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch cmd {
+		case "", "checkpoint", "progress", "feature", "option":
+		case "done":
+			return nil
+		case "blob":
+			err = l.loadBlob(ctx)
+		case "commit":
+			err = l.loadCommit(ctx, plumbing.ReferenceName(arg))
+		case "reset":
+			err = l.loadReset(plumbing.ReferenceName(arg))
+		case "tag":
+			err = l.loadTag(ctx, arg)
+		default:
+			if strings.HasPrefix(cmd, "#") {
+				continue
+			}
+			err = fmt.Errorf("%w: %s", ErrUnsupportedFastExportCommand, cmd)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readMark reads the optional mark and original-oid lines.
+func (l *fastExportLoader) readMark() (int, error) {
+	mark := 0
+	if m, found, err := l.readOptional("mark :"); err != nil {
+		return 0, err
+	} else if found {
+		mark, err = strconv.Atoi(m)
+		if err != nil {
+			return 0, fmt.Errorf("invalid mark %q: %w", m, err)
+		}
+	}
+	if _, _, err := l.readOptional("original-oid "); err != nil {
+		return 0, err
+	}
+
+	return mark, nil
+}
+
+func (l *fastExportLoader) saveBlob(ctx context.Context, data []byte) (plumbing.Hash, error) {
+	blob := l.s.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+	w, err := blob.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return plumbing.ZeroHash, err
+	}
+	if err := w.Close(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return plumbing.ZeroHash, ctx.Err()
+	default:
+	}
+
+	return l.s.SetEncodedObject(blob)
+}
+
+func (l *fastExportLoader) loadBlob(ctx context.Context) error {
+	mark, err := l.readMark()
+	if err != nil {
+		return err
+	}
+	line, err := l.readLine()
+	if err != nil {
+		return err
+	}
+	data, err := l.readData(line)
+	if err != nil {
+		return err
+	}
+	h, err := l.saveBlob(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to save blob: %w", err)
+	}
+	if mark != 0 {
+		l.marks[mark] = h
+	}
+
+	return nil
+}
+
+// resolve returns the hash of a mark, a hash, or a reference.
+func (l *fastExportLoader) resolve(commitish string) (plumbing.Hash, error) {
+	if m, ismark := strings.CutPrefix(commitish, ":"); ismark {
+		mark, err := strconv.Atoi(m)
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("invalid mark %q: %w", commitish, err)
+		}
+		h, found := l.marks[mark]
+		if !found {
+			return plumbing.ZeroHash, fmt.Errorf("unknown mark %q", commitish)
+		}
+		return h, nil
+	}
+	if plumbing.IsHash(commitish) {
+		return plumbing.NewHash(commitish), nil
+	}
+
+	for _, name := range []plumbing.ReferenceName{plumbing.ReferenceName(commitish), plumbing.NewBranchReferenceName(commitish)} {
+		if h, found := l.refs[name]; found && !h.IsZero() {
+			return h, nil
+		}
+		if ref, err := storer.ResolveReference(l.s, name); err == nil {
+			return ref.Hash(), nil
+		}
+	}
+
+	return plumbing.ZeroHash, fmt.Errorf("cannot resolve %q", commitish)
+}
+
+func (l *fastExportLoader) readSignature(prefix string) (object.Signature, bool, error) {
+	var sig object.Signature
+	line, found, err := l.readOptional(prefix)
+	if err != nil || !found {
+		return sig, found, err
+	}
+	sig.Decode([]byte(line))
+
+	return sig, true, nil
+}
+
+func (l *fastExportLoader) loadCommit(ctx context.Context, ref plumbing.ReferenceName) error {
+	mark, err := l.readMark()
+	if err != nil {
+		return err
+	}
+
+	c := &object.Commit{}
+	author, hasauthor, err := l.readSignature("author ")
+	if err != nil {
+		return err
+	}
+	committer, hascommitter, err := l.readSignature("committer ")
+	if err != nil {
+		return err
+	}
+	if !hascommitter {
+		return fmt.Errorf("missing committer for commit to %s", ref)
+	}
+	c.Committer = committer
+	c.Author = committer
+	if hasauthor {
+		c.Author = author
+	}
+	if encoding, found, err := l.readOptional("encoding "); err != nil {
+		return err
+	} else if found {
+		c.Encoding = object.MessageEncoding(encoding)
+	}
+
+	line, err := l.readLine()
+	if err != nil {
+		return err
+	}
+	message, err := l.readData(line)
+	if err != nil {
+		return err
+	}
+	c.Message = string(message)
+
+	// without from, the commit continues from the current head of the branch.
+	from, hasfrom, err := l.readOptional("from ")
+	if err != nil {
+		return err
+	}
+	var parent plumbing.Hash
+	if hasfrom {
+		parent, err = l.resolve(from)
+		if err != nil {
+			return err
+		}
+	} else if h, found := l.refs[ref]; found {
+		parent = h
+	} else if existing, err := storer.ResolveReference(l.s, ref); err == nil {
+		parent = existing.Hash()
+	}
+
+	if !parent.IsZero() {
+		c.ParentHashes = append(c.ParentHashes, parent)
+	}
+	for {
+		merge, found, err := l.readOptional("merge ")
+		if err != nil {
+			return err
+		}
+		if !found {
+			break
+		}
+		h, err := l.resolve(merge)
+		if err != nil {
+			return err
+		}
+		c.ParentHashes = append(c.ParentHashes, h)
+	}
+
+	var basetree *object.Tree
+	if !parent.IsZero() {
+		parentcommit, err := object.GetCommit(l.s, parent)
+		if err != nil {
+			return fmt.Errorf("failed to obtain parent %s: %w", parent, err)
+		}
+		basetree, err = parentcommit.Tree()
+		if err != nil {
+			return fmt.Errorf("failed to obtain tree of parent %s: %w", parent, err)
+		}
+	} else {
+		basetree = &object.Tree{}
+	}
+
+	editTree, err := newInflightTree(basetree)
+	if err != nil {
+		return err
+	}
+	// make sure the tree is saved even if it's empty.
+	if parent.IsZero() {
+		editTree.changed = true
+	}
+
+	if err := l.loadFileCommands(ctx, editTree); err != nil {
+		return err
+	}
+
+	newtree, err := editTree.BuildTree(ctx, l.s)
+	if err != nil {
+		return errorf(err, "failed to build tree for commit to %s: %w", ref, err)
+	}
+	c.TreeHash = newtree.Hash
+
+	if err := updateHashAndSave(ctx, c, l.s); err != nil {
+		return errorf(err, "failed to save commit to %s: %w", ref, err)
+	}
+
+	logger.Debug("load commit from fast-export", "ref", ref, "mark", mark, "hash", c.Hash)
+
+	if mark != 0 {
+		l.marks[mark] = c.Hash
+	}
+	l.refs[ref] = c.Hash
+	if ref.IsBranch() {
+		l.lastRef = ref
+	}
+
+	return nil
+}
+
+// parseFastExportMode parses the mode of filemodify command, which can be in short forms like 644.
+func parseFastExportMode(mode string) (filemode.FileMode, error) {
+	switch mode {
+	case "644":
+		return filemode.Regular, nil
+	case "755":
+		return filemode.Executable, nil
+	}
+
+	return filemode.New(mode)
+}
+
+// unquoteFastExportPath unquotes the path if it's C style quoted, and returns the rest of the line.
+// If the path is not quoted and last is false, the path ends at the first space.
+func unquoteFastExportPath(s string, last bool) (string, string, error) {
+	if !strings.HasPrefix(s, "\"") {
+		if last {
+			return s, "", nil
+		}
+		p, rest, _ := strings.Cut(s, " ")
+		return p, rest, nil
+	}
+
+	p, rest, err := cutQuoted(s)
+	if err != nil {
+		return "", "", err
+	}
+
+	return p, strings.TrimPrefix(rest, " "), nil
+}
+
+func (l *fastExportLoader) loadFileCommands(ctx context.Context, editTree *inflightTree) error {
+	for {
+		line, err := l.readLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch cmd {
+		case "M":
+			modestr, rest, _ := strings.Cut(arg, " ")
+			dataref, pathstr, _ := strings.Cut(rest, " ")
+			mode, err := parseFastExportMode(modestr)
+			if err != nil {
+				return fmt.Errorf("invalid mode in %q: %w", line, err)
+			}
+			p, _, err := unquoteFastExportPath(pathstr, true)
+			if err != nil {
+				return fmt.Errorf("invalid path in %q: %w", line, err)
+			}
+			var h plumbing.Hash
+			if dataref == "inline" {
+				dataline, err := l.readLine()
+				if err != nil {
+					return err
+				}
+				data, err := l.readData(dataline)
+				if err != nil {
+					return err
+				}
+				h, err = l.saveBlob(ctx, data)
+				if err != nil {
+					return fmt.Errorf("failed to save blob for %s: %w", p, err)
+				}
+			} else {
+				h, err = l.resolve(dataref)
+				if err != nil {
+					return err
+				}
+			}
+			if err := editTree.SetEntry(l.s, object.TreeEntry{Mode: mode, Hash: h}, strings.Split(p, "/")); err != nil {
+				return fmt.Errorf("failed to set %s: %w", p, err)
+			}
+		case "D":
+			p, _, err := unquoteFastExportPath(arg, true)
+			if err != nil {
+				return fmt.Errorf("invalid path in %q: %w", line, err)
+			}
+			editTree.Remove(strings.Split(p, "/"))
+		case "C", "R":
+			src, rest, err := unquoteFastExportPath(arg, false)
+			if err != nil {
+				return fmt.Errorf("invalid path in %q: %w", line, err)
+			}
+			dst, _, err := unquoteFastExportPath(rest, true)
+			if err != nil {
+				return fmt.Errorf("invalid path in %q: %w", line, err)
+			}
+			entry, found, err := editTree.GetEntry(ctx, l.s, strings.Split(src, "/"))
+			if err != nil {
+				return errorf(err, "failed to read %s: %w", src, err)
+			}
+			if !found {
+				return fmt.Errorf("cannot find %s to copy or rename", src)
+			}
+			if cmd == "R" {
+				editTree.Remove(strings.Split(src, "/"))
+			}
+			if err := editTree.SetEntry(l.s, entry, strings.Split(dst, "/")); err != nil {
+				return fmt.Errorf("failed to set %s: %w", dst, err)
+			}
+		case "deleteall":
+			newtree, err := newInflightTree(&object.Tree{})
+			if err != nil {
+				return err
+			}
+			*editTree = *newtree
+			editTree.changed = true
+		case "N":
+			return fmt.Errorf("%w: notemodify", ErrUnsupportedFastExportCommand)
+		default:
+			// end of the commit
+			if line != "" {
+				l.unreadLine(line)
+			}
+			return nil
+		}
+	}
+}
+
+func (l *fastExportLoader) loadReset(ref plumbing.ReferenceName) error {
+	from, found, err := l.readOptional("from ")
+	if err != nil {
+		return err
+	}
+	if !found {
+		l.refs[ref] = plumbing.ZeroHash
+		return nil
+	}
+
+	h, err := l.resolve(from)
+	if err != nil {
+		return err
+	}
+	l.refs[ref] = h
+
+	return nil
+}
+
+func (l *fastExportLoader) loadTag(ctx context.Context, name string) error {
+	mark, err := l.readMark()
+	if err != nil {
+		return err
+	}
+
+	from, found, err := l.readOptional("from ")
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("missing from for tag %s", name)
+	}
+	target, err := l.resolve(from)
+	if err != nil {
+		return err
+	}
+	// original-oid can come after from.
+	if _, _, err := l.readOptional("original-oid "); err != nil {
+		return err
+	}
+
+	tag := &object.Tag{
+		Name:       name,
+		Target:     target,
+		TargetType: plumbing.CommitObject,
+	}
+	if tagger, found, err := l.readSignature("tagger "); err != nil {
+		return err
+	} else if found {
+		tag.Tagger = tagger
+	}
+
+	line, err := l.readLine()
+	if err != nil {
+		return err
+	}
+	message, err := l.readData(line)
+	if err != nil {
+		return err
+	}
+	tag.Message = string(message)
+
+	if err := updateHashAndSave(ctx, tag, l.s); err != nil {
+		return errorf(err, "failed to save tag %s: %w", name, err)
+	}
+
+	if mark != 0 {
+		l.marks[mark] = tag.Hash
+	}
+	l.refs[plumbing.NewTagReferenceName(name)] = tag.Hash
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package gitrim_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/fardream/gitrim"
+)
+
+func TestLoadFastExport_roundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	repo, commits := newTestRepo(t,
+		map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"},
+		map[string]string{"b/y.txt": "y2\n", "c/z.txt": "z\n"},
+		map[string]string{"a/x.txt": ""},
+	)
+
+	var stream bytes.Buffer
+	fw := gitrim.NewFastImportWriter(&stream)
+	if err := fw.WriteCommits(ctx, repo.Storer, plumbing.NewBranchReferenceName("main"), commits); err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	s := memory.NewStorage()
+	refs, err := gitrim.LoadFastExport(ctx, &stream, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last := commits[len(commits)-1].Hash
+	if got := refs[plumbing.NewBranchReferenceName("main")]; got != last {
+		t.Errorf("want main at %s, got %s", last, got)
+	}
+	for _, c := range commits {
+		if _, err := object.GetCommit(s, c.Hash); err != nil {
+			t.Errorf("commit %s is not reproduced: %s", c.Hash, err)
+		}
+	}
+	head, err := s.Reference(plumbing.HEAD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head.Target() != plumbing.NewBranchReferenceName("main") {
+		t.Errorf("want HEAD to point to main, got %s", head.Target())
+	}
+}
+
+const testFastExport = `feature done
+blob
+mark :1
+data 6
+hello
+
+commit refs/heads/main
+mark :2
+author A U Thor <author@example.com> 1709604672 +0800
+committer C O Mitter <committer@example.com> 1709604732 +0800
+data <<END
+first
+END
+M 644 :1 dir/hello.txt
+M 100755 inline "dir/with \"quote\".sh"
+data 10
+#!/bin/sh
+
+commit refs/heads/main
+mark :3
+committer C O Mitter <committer@example.com> 1709604792 +0800
+data 7
+second
+from :2
+C dir/hello.txt copy/hello.txt
+R dir/hello.txt moved.txt
+
+reset refs/heads/other
+from :2
+
+tag v1
+from :3
+tagger C O Mitter <committer@example.com> 1709604792 +0800
+data 4
+tag
+
+done
+`
+
+func TestLoadFastExport(t *testing.T) {
+	ctx := context.Background()
+
+	s := memory.NewStorage()
+	refs, err := gitrim.LoadFastExport(ctx, strings.NewReader(testFastExport), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(refs) != 3 {
+		t.Errorf("want 3 refs, got %v", refs)
+	}
+
+	c, err := object.GetCommit(s, refs[plumbing.NewBranchReferenceName("main")])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Message != "second\n" || c.Author.Email != "committer@example.com" {
+		t.Errorf("unexpected commit: %s", c.String())
+	}
+	if c.NumParents() != 1 || c.ParentHashes[0] != refs[plumbing.NewBranchReferenceName("other")] {
+		t.Errorf("want parent %s, got %v", refs[plumbing.NewBranchReferenceName("other")], c.ParentHashes)
+	}
+
+	for name, want := range map[string]string{
+		"copy/hello.txt":      "hello\n",
+		"moved.txt":           "hello\n",
+		`dir/with "quote".sh`: "#!/bin/sh\n",
+	} {
+		f, err := c.File(name)
+		if err != nil {
+			t.Fatalf("failed to find %s: %s", name, err)
+		}
+		got, err := f.Contents()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%s: want %q, got %q", name, want, got)
+		}
+	}
+	if _, err := c.File("dir/hello.txt"); err == nil {
+		t.Error("renamed file still exists")
+	}
+
+	tag, err := object.GetTag(s, refs[plumbing.NewTagReferenceName("v1")])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Target != c.Hash || tag.Message != "tag\n" {
+		t.Errorf("unexpected tag: %s", tag.String())
+	}
+}
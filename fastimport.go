@@ -0,0 +1,292 @@
+package gitrim
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+)
+
+// FastImportWriter writes commits as a stream that can be consumed by "git fast-import".
+//
+// Each blob and commit written gets a mark, and blobs and commits that are already written
+// will be referred to by their marks. Parents that are not written by the writer are referred
+// to by their hashes, and they must exist in the repo that imports the stream.
+type FastImportWriter struct {
+	w        *bufio.Writer
+	nextMark int
+	marks    map[plumbing.Hash]int
+}
+
+// NewFastImportWriter creates a new [FastImportWriter] writing to w.
+// Call [FastImportWriter.Flush] after all the commits are written.
+func NewFastImportWriter(w io.Writer) *FastImportWriter {
+	return &FastImportWriter{
+		w:        bufio.NewWriter(w),
+		nextMark: 1,
+		marks:    make(map[plumbing.Hash]int),
+	}
+}
+
+// Flush flushes the buffered stream into the underlying writer.
+func (fw *FastImportWriter) Flush() error {
+	return fw.w.Flush()
+}
+
+// Marks returns the mapping from hashes of blobs and commits to their marks.
+func (fw *FastImportWriter) Marks() map[plumbing.Hash]int {
+	return fw.marks
+}
+
+// WriteMarks writes the marks in the format of "git fast-import --export-marks", each line is
+//
+//	:<mark> <hash>
+//
+// The marks are ordered by their numbers.
+func (fw *FastImportWriter) WriteMarks(w io.Writer) error {
+	type markpair struct {
+		mark int
+		hash plumbing.Hash
+	}
+	pairs := make([]markpair, 0, len(fw.marks))
+	for h, m := range fw.marks {
+		pairs = append(pairs, markpair{mark: m, hash: h})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].mark < pairs[j].mark })
+
+	bw := bufio.NewWriter(w)
+	for _, p := range pairs {
+		if _, err := fmt.Fprintf(bw, ":%d %s\n", p.mark, p.hash); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// WriteCommits writes the commits and the blobs they contain into the stream, and update refname to each of the commits.
+// The commits must have earlier commits before the later commits, and the objects are read from s.
+// A commit that is already written will be skipped.
+//
+// The changes of each commit are calculated against its first parent, and the other parents become merges.
+// refname is reset before each commit without parents, so it stays a root commit.
+func (fw *FastImportWriter) WriteCommits(
+	ctx context.Context,
+	s storer.EncodedObjectStorer,
+	refname plumbing.ReferenceName,
+	commits []*object.Commit,
+) error {
+	n := len(commits)
+	for i, c := range commits {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if c == nil {
+			continue
+		}
+		if _, found := fw.marks[c.Hash]; found {
+			continue
+		}
+
+		logger.Debug("write commit to fast-import stream", "id", i, "total", n, "hash", c.Hash)
+
+		if err := fw.writeCommit(ctx, s, refname, c); err != nil {
+			return errorf(err, "failed to write commit %s: %w", c.Hash, err)
+		}
+	}
+
+	return nil
+}
+
+func (fw *FastImportWriter) newMark(h plumbing.Hash) int {
+	m := fw.nextMark
+	fw.nextMark++
+	fw.marks[h] = m
+	return m
+}
+
+// commitish returns the mark of the commit if it's written, otherwise the hash.
+func (fw *FastImportWriter) commitish(h plumbing.Hash) string {
+	if m, found := fw.marks[h]; found {
+		return ":" + strconv.Itoa(m)
+	}
+	return h.String()
+}
+
+func (fw *FastImportWriter) writeCommit(ctx context.Context, s storer.EncodedObjectStorer, refname plumbing.ReferenceName, c *object.Commit) error {
+	// the commit may not be decoded from the storage, obtain it from the storage so its tree can be read.
+	c, err := object.GetCommit(s, c.Hash)
+	if err != nil {
+		return err
+	}
+
+	tree, err := c.Tree()
+	if err != nil {
+		return fmt.Errorf("failed to obtain tree: %w", err)
+	}
+	var parenttree *object.Tree
+	if c.NumParents() > 0 {
+		parent, err := object.GetCommit(s, c.ParentHashes[0])
+		if err != nil {
+			return fmt.Errorf("failed to obtain parent %s: %w", c.ParentHashes[0], err)
+		}
+		parenttree, err = parent.Tree()
+		if err != nil {
+			return fmt.Errorf("failed to obtain tree of parent %s: %w", parent.Hash, err)
+		}
+	}
+
+	changes, err := object.DiffTreeWithOptions(ctx, parenttree, tree, nil)
+	if err != nil {
+		return fmt.Errorf("failed to diff tree against parent: %w", err)
+	}
+
+	var filecmds strings.Builder
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return err
+		}
+		if action == merkletrie.Delete {
+			fmt.Fprintf(&filecmds, "D %s\n", quoteFastImportPath(change.From.Name))
+			continue
+		}
+
+		entry := change.To.TreeEntry
+		if entry.Mode == filemode.Submodule {
+			fmt.Fprintf(&filecmds, "M %o %s %s\n", entry.Mode, entry.Hash, quoteFastImportPath(change.To.Name))
+			continue
+		}
+		blobmark, found := fw.marks[entry.Hash]
+		if !found {
+			blobmark, err = fw.writeBlob(s, entry.Hash)
+			if err != nil {
+				return fmt.Errorf("failed to write blob %s for %s: %w", entry.Hash, change.To.Name, err)
+			}
+		}
+		fmt.Fprintf(&filecmds, "M %o :%d %s\n", entry.Mode, blobmark, quoteFastImportPath(change.To.Name))
+	}
+
+	mark := fw.newMark(c.Hash)
+
+	// a commit without from continues from the tip of refname, which exists if a commit is written to refname before,
+	// or if refname is in the repo importing the stream. reset refname so the root commit has no parents.
+	if c.NumParents() == 0 {
+		fmt.Fprintf(fw.w, "reset %s\n\n", refname)
+	}
+	fmt.Fprintf(fw.w, "commit %s\n", refname)
+	fmt.Fprintf(fw.w, "mark :%d\n", mark)
+	fmt.Fprintf(fw.w, "original-oid %s\n", c.Hash)
+	fmt.Fprintf(fw.w, "author %s\n", fastImportSignature(&c.Author))
+	fmt.Fprintf(fw.w, "committer %s\n", fastImportSignature(&c.Committer))
+	fmt.Fprintf(fw.w, "data %d\n%s\n", len(c.Message), c.Message)
+	for i, p := range c.ParentHashes {
+		if i == 0 {
+			fmt.Fprintf(fw.w, "from %s\n", fw.commitish(p))
+		} else {
+			fmt.Fprintf(fw.w, "merge %s\n", fw.commitish(p))
+		}
+	}
+	fw.w.WriteString(filecmds.String())
+	_, err = fw.w.WriteString("\n")
+
+	return err
+}
+
+func (fw *FastImportWriter) writeBlob(s storer.EncodedObjectStorer, h plumbing.Hash) (int, error) {
+	obj, err := s.EncodedObject(plumbing.BlobObject, h)
+	if err != nil {
+		return 0, err
+	}
+	r, err := obj.Reader()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	mark := fw.newMark(h)
+	fmt.Fprintf(fw.w, "blob\nmark :%d\noriginal-oid %s\ndata %d\n", mark, h, obj.Size())
+	if _, err := io.Copy(fw.w, r); err != nil {
+		return 0, err
+	}
+	_, err = fw.w.WriteString("\n")
+
+	return mark, err
+}
+
+func fastImportSignature(s *object.Signature) string {
+	return fmt.Sprintf("%s <%s> %d %s", s.Name, s.Email, s.When.Unix(), s.When.Format("-0700"))
+}
+
+// quoteFastImportPath quotes the path in C style if it starts with double quote or contains LF, which is mandatory for fast-import.
+func quoteFastImportPath(p string) string {
+	if !strings.HasPrefix(p, "\"") && !strings.Contains(p, "\n") {
+		return p
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(p); i++ {
+		switch p[i] {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteByte(p[i])
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+var ErrEmptyFilteredDFS = errors.New("filtered dfs contains no commits")
+
+// WriteFastImport writes the filtered commits in dfs into fw, and sets refname to the commit the last unfiltered commit is mapped to.
+func (dfs *FilteredDFS) WriteFastImport(ctx context.Context, fw *FastImportWriter, refname plumbing.ReferenceName) error {
+	if dfs.toStorage == nil {
+		return ErrNilToStorage
+	}
+
+	commits, err := dfs.ToDFS.GetPath()
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		return ErrEmptyFilteredDFS
+	}
+
+	if err := fw.WriteCommits(ctx, dfs.toStorage, refname, commits); err != nil {
+		return err
+	}
+
+	// the commit fast-import leaves refname at is the last one written, which may not be the filtered head
+	// if the history contains branches, so reset refname to the commit the head is mapped to.
+	if len(dfs.FromDFS.Path) == 0 {
+		return nil
+	}
+	head := dfs.FromToTo[dfs.FromDFS.Path[len(dfs.FromDFS.Path)-1].Hash]
+	if head.IsZero() {
+		return nil
+	}
+
+	_, err = fmt.Fprintf(fw.w, "reset %s\nfrom %s\n\n", refname, fw.commitish(head))
+
+	return err
+}
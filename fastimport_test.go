@@ -0,0 +1,169 @@
+package gitrim_test
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/fardream/gitrim"
+)
+
+// multiRootFastExport is a history of two unrelated roots merged together, both roots touch the filtered a/.
+const multiRootFastExport = `blob
+mark :1
+data 2
+x
+
+blob
+mark :2
+data 2
+y
+
+commit refs/heads/main
+mark :3
+committer C O Mitter <committer@example.com> 1709604672 +0800
+data 6
+first
+M 644 :1 a/x.txt
+M 644 :2 b/y.txt
+
+reset refs/heads/main
+
+commit refs/heads/main
+mark :4
+committer C O Mitter <committer@example.com> 1709604732 +0800
+data 11
+other root
+M 644 :2 a/z.txt
+
+commit refs/heads/main
+mark :5
+committer C O Mitter <committer@example.com> 1709604792 +0800
+data 6
+merge
+from :3
+merge :4
+M 644 :2 a/z.txt
+
+`
+
+// gitFastImport imports the stream into a new bare repo with git, and returns the commits reachable from refname.
+func gitFastImport(t *testing.T, stream []byte, refname plumbing.ReferenceName) []string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "--bare", "--quiet", dir).CombinedOutput(); err != nil {
+		t.Fatalf("failed to init repo: %s\n%s", err, out)
+	}
+	cmd := exec.Command("git", "-C", dir, "fast-import", "--quiet")
+	cmd.Stdin = bytes.NewReader(stream)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to fast-import: %s\n%s\nstream:\n%s", err, out, stream)
+	}
+	out, err := exec.Command("git", "-C", dir, "rev-list", refname.String()).Output()
+	if err != nil {
+		t.Fatalf("failed to list commits: %s", err)
+	}
+	r := strings.Fields(string(out))
+	slices.Sort(r)
+
+	return r
+}
+
+func TestFilteredDFS_WriteFastImport(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not found")
+	}
+	ctx := context.Background()
+
+	linear := func(t *testing.T) (storer.Storer, []*object.Commit) {
+		repo, commits := newTestRepo(t,
+			map[string]string{"a/x.txt": "x\n", "a/y.txt": "y\n", "b/z.txt": "z\n"},
+			map[string]string{"b/z.txt": "z2\n"},
+			map[string]string{"a/x.txt": "x2\n", "a/y.txt": ""},
+		)
+		return repo.Storer, commits
+	}
+	multiroot := func(t *testing.T) (storer.Storer, []*object.Commit) {
+		s := memory.NewStorage()
+		refs, err := gitrim.LoadFastExport(ctx, strings.NewReader(multiRootFastExport), s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		head, err := object.GetCommit(s, refs[plumbing.NewBranchReferenceName("main")])
+		if err != nil {
+			t.Fatal(err)
+		}
+		commits, err := gitrim.GetDFSPath(ctx, head, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s, commits
+	}
+
+	for name, test := range map[string]struct {
+		history     func(t *testing.T) (storer.Storer, []*object.Commit)
+		wantCommits int
+	}{
+		"linear":         {history: linear, wantCommits: 2},
+		"multiple roots": {history: multiroot, wantCommits: 3},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, commits := test.history(t)
+			filter, err := gitrim.NewOrFilterForPatterns("a/")
+			if err != nil {
+				t.Fatal(err)
+			}
+			dfs, err := gitrim.NewFilteredDFS(ctx, commits, s, memory.NewStorage(), filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var stream bytes.Buffer
+			fw := gitrim.NewFastImportWriter(&stream)
+			refname := plumbing.NewBranchReferenceName("main")
+			if err := dfs.WriteFastImport(ctx, fw, refname); err != nil {
+				t.Fatal(err)
+			}
+			if err := fw.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			tocommits, err := dfs.ToDFS.GetPath()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want []string
+			for _, c := range tocommits {
+				want = append(want, c.Hash.String())
+			}
+			slices.Sort(want)
+			if len(want) != test.wantCommits {
+				t.Fatalf("want %d filtered commits, got %d", test.wantCommits, len(want))
+			}
+
+			// the imported commits are the filtered ones.
+			if got := gitFastImport(t, stream.Bytes(), refname); !slices.Equal(got, want) {
+				t.Errorf("want imported commits %v, got %v\nstream:\n%s", want, got, stream.String())
+			}
+
+			var marksfile bytes.Buffer
+			if err := fw.WriteMarks(&marksfile); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tocommits {
+				if !strings.Contains(marksfile.String(), " "+c.Hash.String()+"\n") {
+					t.Errorf("commit %s is not in marks:\n%s", c.Hash, marksfile.String())
+				}
+			}
+		})
+	}
+}
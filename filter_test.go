@@ -100,3 +100,22 @@ func TestPatternFilter_Filter_many(t *testing.T) {
 		}
 	}
 }
+
+func TestCachedFilter_Stats(t *testing.T) {
+	f, err := gitrim.NewPatternFilter("a/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached := gitrim.NewCachedFilter(f)
+
+	for _, name := range []string{"a/x.txt", "b/y.txt", "a/x.txt"} {
+		cached.Filter(strings.Split(name, "/"), false)
+	}
+	cached.Filter([]string{"a"}, true)
+	cached.Reset()
+	cached.Filter([]string{"a"}, true)
+
+	if hits, misses := cached.Stats(); hits != 1 || misses != 4 {
+		t.Errorf("want 1 hit and 4 misses, got %d and %d", hits, misses)
+	}
+}
@@ -369,6 +369,11 @@ func (dfs *FilteredDFS) ExpandFilteredCommits(ctx context.Context, commits []*ob
 		if err != nil {
 			return nil, fmt.Errorf("failed to expand commit %s: %w", c.Hash.String(), err)
 		}
+		// read the commit back from storage, so the next commit can use it as parent.
+		newcommit, err = object.GetCommit(dfs.fromStorage, newcommit.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain expanded commit for %s: %w", c.Hash.String(), err)
+		}
 		logger.Info("processing filtered commit", "id", i, "total", n, "hash", c.Hash, "new unfiltered", newcommit.Hash)
 
 		dfs.FromDFS.AddCommit(newcommit)
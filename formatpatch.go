@@ -0,0 +1,226 @@
+package gitrim
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// formatPatchSignature is the signature line at the end of each patch, in place of the git version.
+const formatPatchSignature = "gitrim"
+
+// FormatPatch writes commit c as a patch email in the format of "git format-patch", which can be
+// applied by "git am" or parsed by [ParseMailPatch].
+// The diff is generated against parent, or against an empty tree if parent is nil.
+// subjectPrefix is prepended to the subject, for example "[PATCH 1/3]".
+func FormatPatch(ctx context.Context, w io.Writer, c *object.Commit, parent *object.Commit, subjectPrefix string) error {
+	tree, err := c.Tree()
+	if err != nil {
+		return fmt.Errorf("failed to obtain tree of %s: %w", c.Hash, err)
+	}
+	var parenttree *object.Tree
+	if parent != nil {
+		parenttree, err = parent.Tree()
+		if err != nil {
+			return fmt.Errorf("failed to obtain tree of %s: %w", parent.Hash, err)
+		}
+	}
+
+	changes, err := object.DiffTreeWithOptions(ctx, parenttree, tree, nil)
+	if err != nil {
+		return fmt.Errorf("failed to diff %s: %w", c.Hash, err)
+	}
+	patch, err := changes.PatchContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate patch for %s: %w", c.Hash, err)
+	}
+
+	var diffbuf bytes.Buffer
+	if err := diff.NewUnifiedEncoder(&diffbuf, diff.DefaultContextLines).Encode(patch); err != nil {
+		return fmt.Errorf("failed to encode patch for %s: %w", c.Hash, err)
+	}
+
+	subject, description := splitCommitMessage(c.Message)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From %s Mon Sep 17 00:00:00 2001\n", c.Hash)
+	fmt.Fprintf(&buf, "From: %s\n", formatAddress(c.Author.Name, c.Author.Email))
+	fmt.Fprintf(&buf, "Date: %s\n", c.Author.When.Format("Mon, 2 Jan 2006 15:04:05 -0700"))
+	if subjectPrefix != "" {
+		subject = subjectPrefix + " " + subject
+	}
+	fmt.Fprintf(&buf, "Subject: %s\n", mime.QEncoding.Encode("UTF-8", subject))
+	if !isASCII(description) || !isASCII(diffbuf.String()) {
+		buf.WriteString("MIME-Version: 1.0\nContent-Type: text/plain; charset=UTF-8\nContent-Transfer-Encoding: 8bit\n")
+	}
+	buf.WriteString("\n")
+	if description != "" {
+		buf.WriteString(description)
+		buf.WriteString("\n")
+	}
+	buf.WriteString("---\n")
+	writePatchStat(&buf, patch)
+	buf.WriteString("\n")
+	buf.Write(diffbuf.Bytes())
+	fmt.Fprintf(&buf, "-- \n%s\n\n", formatPatchSignature)
+
+	_, err = w.Write(buf.Bytes())
+
+	return err
+}
+
+// splitCommitMessage splits the commit message into the subject and the description,
+// in the same way as git: the first paragraph is the subject.
+func splitCommitMessage(message string) (string, string) {
+	message = strings.TrimLeft(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	subject, description, _ := strings.Cut(message, "\n\n")
+
+	return strings.Join(strings.Fields(subject), " "), strings.Trim(description, "\n")
+}
+
+// formatAddress formats the address like git, which only quotes the name when necessary.
+func formatAddress(name, email string) string {
+	if name != "" && isASCII(name) && !strings.ContainsAny(name, `()<>[]:;@\,."`) {
+		return fmt.Sprintf("%s <%s>", name, email)
+	}
+
+	return (&mail.Address{Name: name, Address: email}).String()
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// writePatchStat writes the diffstat and summary in the same format as "git format-patch".
+func writePatchStat(buf *bytes.Buffer, patch *object.Patch) {
+	stats := patch.Stats()
+	buf.WriteString(stats.String())
+
+	insertions, deletions := 0, 0
+	for _, s := range stats {
+		insertions += s.Addition
+		deletions += s.Deletion
+	}
+	nfiles := len(patch.FilePatches())
+
+	fmt.Fprintf(buf, " %d %s changed", nfiles, plural(nfiles, "file", "files"))
+	if insertions > 0 || deletions == 0 {
+		fmt.Fprintf(buf, ", %d %s(+)", insertions, plural(insertions, "insertion", "insertions"))
+	}
+	if deletions > 0 || insertions == 0 {
+		fmt.Fprintf(buf, ", %d %s(-)", deletions, plural(deletions, "deletion", "deletions"))
+	}
+	buf.WriteString("\n")
+
+	for _, fp := range patch.FilePatches() {
+		from, to := fp.Files()
+		switch {
+		case from == nil && to != nil:
+			fmt.Fprintf(buf, " create mode %o %s\n", to.Mode(), to.Path())
+		case from != nil && to == nil:
+			fmt.Fprintf(buf, " delete mode %o %s\n", from.Mode(), from.Path())
+		case from != nil && to != nil && from.Mode() != to.Mode() && from.Mode() != filemode.Empty:
+			fmt.Fprintf(buf, " mode change %o => %o %s\n", from.Mode(), to.Mode(), to.Path())
+		}
+	}
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// FormatPatches writes the filtered version of fromcommits as patch emails into w, in the format of "git format-patch".
+//
+// fromcommits are commits from the unfiltered repo, and must have earlier commits before the later commits.
+// Commits not yet in dfs will be added by [FilteredDFS.AppendCommits] - to get the correct parent for the first commit,
+// history before fromcommits should be already added to dfs.
+// Commits that are empty after filtering, or merge commits, are skipped.
+//
+// It returns the filtered commits that are written.
+func (dfs *FilteredDFS) FormatPatches(ctx context.Context, w io.Writer, fromcommits []*object.Commit) ([]*object.Commit, error) {
+	if _, err := dfs.AppendCommits(ctx, fromcommits); err != nil {
+		return nil, err
+	}
+
+	tocommits := make([]*object.Commit, 0, len(fromcommits))
+	for _, c := range fromcommits {
+		if c == nil {
+			continue
+		}
+		toh, found := dfs.FromToTo[c.Hash]
+		// empty after filtering
+		if !found || toh.IsZero() {
+			continue
+		}
+		// all changes filtered out, and the commit is mapped to one of its parents.
+		if isMappedToParent(dfs.FromToTo, c, toh) {
+			continue
+		}
+		// the commit in dfs may not be decoded from the storage, obtain it from the storage so its tree can be read.
+		toc, err := object.GetCommit(dfs.toStorage, toh)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain filtered commit %s for %s: %w", toh, c.Hash, err)
+		}
+		if toc.NumParents() > 1 {
+			logger.Debug("skip merge commit", "hash", c.Hash, "newcommit", toc.Hash)
+			continue
+		}
+		tocommits = append(tocommits, toc)
+	}
+
+	n := len(tocommits)
+	for i, c := range tocommits {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		var parent *object.Commit
+		if c.NumParents() > 0 {
+			var err error
+			parent, err = c.Parent(0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to obtain parent of %s: %w", c.Hash, err)
+			}
+		}
+
+		prefix := "[PATCH]"
+		if n > 1 {
+			prefix = fmt.Sprintf("[PATCH %d/%d]", i+1, n)
+		}
+
+		if err := FormatPatch(ctx, w, c, parent, prefix); err != nil {
+			return nil, err
+		}
+	}
+
+	return tocommits, nil
+}
+
+func isMappedToParent(fromToTo map[plumbing.Hash]plumbing.Hash, c *object.Commit, toh plumbing.Hash) bool {
+	for _, p := range c.ParentHashes {
+		if fromToTo[p] == toh {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package gitrim_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/fardream/gitrim"
+)
+
+func TestFilteredDFS_FormatPatches(t *testing.T) {
+	ctx := context.Background()
+
+	repo, commits := newTestRepo(t,
+		map[string]string{"a/x.txt": "line 1\nline 2\nline 3\n", "b/y.txt": "y\n"},
+		map[string]string{"b/y.txt": "y2\n"},
+		map[string]string{"a/x.txt": "line 1\nline two\nline 3\n", "b/y.txt": "y3\n"},
+		map[string]string{"a/z.txt": "hello world"},
+	)
+
+	filter, err := gitrim.NewOrFilterForPatterns("a/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tostorage := memory.NewStorage()
+	dfs, err := gitrim.NewFilteredDFS(ctx, commits[:1], repo.Storer, tostorage, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mbox bytes.Buffer
+	written, err := dfs.FormatPatches(ctx, &mbox, commits[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the second commit only touches b/, and is skipped.
+	if len(written) != 2 {
+		t.Fatalf("want 2 patches written, got %d:\n%s", len(written), mbox.String())
+	}
+
+	patches, err := gitrim.ParseMbox(&mbox)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("want 2 patches parsed, got %d", len(patches))
+	}
+
+	base, err := object.GetCommit(tostorage, dfs.FromToTo[commits[0].Hash])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := base.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, p := range patches {
+		if p.Hash != written[i].Hash {
+			t.Errorf("patch %d: want hash %s, got %s", i, written[i].Hash, p.Hash)
+		}
+		if p.Message != written[i].Message {
+			t.Errorf("patch %d: want message %q, got %q", i, written[i].Message, p.Message)
+		}
+		if !p.Author.When.Equal(written[i].Author.When) || p.Author.Email != written[i].Author.Email {
+			t.Errorf("patch %d: want author %s, got %s", i, written[i].Author.String(), p.Author.String())
+		}
+		tree, err = gitrim.ApplyTextPatches(ctx, tree, p.FilePatches, tostorage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tree.Hash != written[i].TreeHash {
+			t.Errorf("patch %d: want tree %s, got %s", i, written[i].TreeHash, tree.Hash)
+		}
+	}
+}
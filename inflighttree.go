@@ -227,3 +227,114 @@ func (it *inflightTree) Delete(ctx context.Context, hash plumbing.Hash, mode fil
 
 	return nil
 }
+
+// SetEntry sets the entry at the path, without copying any objects.
+// Anything at the path, file or folder, is replaced.
+// If the entry is a folder, its content is read from s.
+func (it *inflightTree) SetEntry(s storer.EncodedObjectStorer, entry object.TreeEntry, pathsegs []string) error {
+	if len(pathsegs) == 0 {
+		return fmt.Errorf("zero length path segment for entry: %s", entry.Hash.String())
+	}
+
+	name := pathsegs[0]
+	if len(pathsegs) == 1 {
+		entry.Name = name
+		if entry.Mode == filemode.Dir {
+			subtree, err := object.GetTree(s, entry.Hash)
+			if err != nil {
+				return fmt.Errorf("failed to obtain tree %s: %w", entry.Hash.String(), err)
+			}
+			subinflight, err := newInflightTree(subtree)
+			if err != nil {
+				return fmt.Errorf("failed to create sub inflight tree at %s: %w", name, err)
+			}
+			delete(it.nonTrees, name)
+			it.trees[name] = subinflight
+		} else {
+			delete(it.trees, name)
+			it.nonTrees[name] = entry
+		}
+		it.changed = true
+		return nil
+	}
+
+	subtree, found := it.trees[name]
+	if !found {
+		var err error
+		subtree, err = newInflightTree(&object.Tree{})
+		if err != nil {
+			return fmt.Errorf("failed to create an empty inflight tree: %w", err)
+		}
+		delete(it.nonTrees, name)
+		it.trees[name] = subtree
+	}
+
+	if err := subtree.SetEntry(s, entry, pathsegs[1:]); err != nil {
+		return err
+	}
+
+	it.changed = true
+
+	return nil
+}
+
+// Remove removes the file or the folder at the path, and returns false if nothing is at the path.
+// Folders become empty are also removed.
+func (it *inflightTree) Remove(pathsegs []string) bool {
+	if len(pathsegs) == 0 {
+		return false
+	}
+
+	name := pathsegs[0]
+	if len(pathsegs) == 1 {
+		_, isfile := it.nonTrees[name]
+		_, isdir := it.trees[name]
+		if !isfile && !isdir {
+			return false
+		}
+		delete(it.nonTrees, name)
+		delete(it.trees, name)
+		it.changed = true
+		return true
+	}
+
+	subtree, found := it.trees[name]
+	if !found || !subtree.Remove(pathsegs[1:]) {
+		return false
+	}
+	if subtree.IsEmpty() {
+		delete(it.trees, name)
+	}
+	it.changed = true
+
+	return true
+}
+
+// GetEntry returns the entry at the path. For a folder, the tree is built and saved into s so its hash is available.
+func (it *inflightTree) GetEntry(ctx context.Context, s storer.Storer, pathsegs []string) (object.TreeEntry, bool, error) {
+	if len(pathsegs) == 0 {
+		return object.TreeEntry{}, false, nil
+	}
+
+	name := pathsegs[0]
+	subtree, isdir := it.trees[name]
+	if len(pathsegs) == 1 {
+		if e, found := it.nonTrees[name]; found {
+			return e, true, nil
+		}
+		if !isdir {
+			return object.TreeEntry{}, false, nil
+		}
+		t, err := subtree.BuildTree(ctx, s)
+		if err != nil {
+			return object.TreeEntry{}, false, err
+		}
+		return object.TreeEntry{Name: name, Mode: filemode.Dir, Hash: t.Hash}, true, nil
+	}
+
+	if !isdir {
+		return object.TreeEntry{}, false, nil
+	}
+
+	return subtree.GetEntry(ctx, s, pathsegs[1:])
+}
@@ -0,0 +1,198 @@
+package gitrim
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// MailPatch is a patch email generated by "git format-patch".
+type MailPatch struct {
+	// Hash of the commit the patch is generated from, taken from the "From <hash>" line of the mbox.
+	// It will be zero if the line is missing.
+	Hash plumbing.Hash
+	// Author of the patch, from the "From" and "Date" headers.
+	Author object.Signature
+	// Message is the commit message, which is the subject with "[PATCH]" prefix removed,
+	// followed by the body of the email before the "---" separator line.
+	Message string
+	// FilePatches are the changes contained in the patch.
+	FilePatches []*TextFilePatch
+}
+
+// DiffFilePatches returns the file patches as [diff.FilePatch], which can be used with [CheckFilePatchAgainstFilter].
+func (p *MailPatch) DiffFilePatches() []diff.FilePatch {
+	result := make([]diff.FilePatch, 0, len(p.FilePatches))
+	for _, fp := range p.FilePatches {
+		result = append(result, fp)
+	}
+	return result
+}
+
+// mboxFromLine matches the separator line "git format-patch" puts at the start of each email.
+var mboxFromLine = regexp.MustCompile(`^From ([0-9a-f]{40}) `)
+
+// subjectPrefix matches the "[PATCH v2 1/3]" prefix of the subject.
+var subjectPrefix = regexp.MustCompile(`^(\s*\[[^\]]*\])+\s*`)
+
+var ErrEmptyMbox = errors.New("mbox contains no patches")
+
+// ParseMbox parses the mbox generated by "git format-patch", which can contain multiple emails.
+//
+// Each email is separated by line starting with "From <commit hash>". A single email without
+// the separator line is also accepted.
+func ParseMbox(r io.Reader) ([]*MailPatch, error) {
+	var result []*MailPatch
+
+	var current bytes.Buffer
+	var currenthash plumbing.Hash
+	hasmessage := false
+
+	finish := func() error {
+		if !hasmessage {
+			return nil
+		}
+		p, err := ParseMailPatch(current.Bytes())
+		if err != nil {
+			return fmt.Errorf("failed to parse patch %d: %w", len(result)+1, err)
+		}
+		if p.Hash.IsZero() {
+			p.Hash = currenthash
+		}
+		result = append(result, p)
+		current.Reset()
+		hasmessage = false
+		return nil
+	}
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			if m := mboxFromLine.FindStringSubmatch(line); m != nil {
+				if err := finish(); err != nil {
+					return nil, err
+				}
+				currenthash = plumbing.NewHash(m[1])
+				hasmessage = true
+			} else {
+				if !hasmessage && strings.TrimSpace(line) != "" {
+					hasmessage = true
+				}
+				if hasmessage {
+					current.WriteString(line)
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, ErrEmptyMbox
+	}
+
+	return result, nil
+}
+
+// ParseMailPatch parses a single patch email generated by "git format-patch".
+func ParseMailPatch(data []byte) (*MailPatch, error) {
+	// the separator line is not part of the email
+	var hash plumbing.Hash
+	if m := mboxFromLine.FindSubmatch(data); m != nil {
+		hash = plumbing.NewHash(string(m[1]))
+		_, data, _ = bytes.Cut(data, []byte("\n"))
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email: %w", err)
+	}
+
+	decoder := new(mime.WordDecoder)
+
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse from header: %w", err)
+	}
+	date, err := msg.Header.Date()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse date header: %w", err)
+	}
+	subject, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode subject: %w", err)
+	}
+	subject = subjectPrefix.ReplaceAllString(subject, "")
+	subject = strings.TrimSpace(strings.Join(strings.Fields(subject), " "))
+
+	var body io.Reader = msg.Body
+	switch strings.ToLower(msg.Header.Get("Content-Transfer-Encoding")) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	bodybytes, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email body: %w", err)
+	}
+
+	bodytext := strings.ReplaceAll(string(bodybytes), "\r\n", "\n")
+	description, patchtext := splitMailBody(bodytext)
+
+	message := subject + "\n"
+	if description = strings.TrimSpace(description); description != "" {
+		message = message + "\n" + description + "\n"
+	}
+
+	filepatches, err := ParseTextPatch(patchtext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailPatch{
+		Hash: hash,
+		Author: object.Signature{
+			Name:  from.Name,
+			Email: from.Address,
+			When:  date,
+		},
+		Message:     message,
+		FilePatches: filepatches,
+	}, nil
+}
+
+// splitMailBody splits the body into description and the patch. The description ends
+// at the "---" line, or at the first line of the diff if the separator is missing.
+func splitMailBody(body string) (string, string) {
+	lines := strings.SplitAfter(body, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimRight(line, "\n")
+		if trimmed == "---" || strings.HasPrefix(trimmed, "diff --git ") {
+			return strings.Join(lines[:i], ""), strings.Join(lines[i:], "")
+		}
+	}
+
+	return body, ""
+}
@@ -0,0 +1,193 @@
+package svc
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// triggers of the operations without a caller or a webhook delivery.
+const (
+	triggeredByLocal     = "local"
+	triggeredByScheduler = "scheduler"
+)
+
+// auditTriggerKey is the key of the [auditTrigger] in the context.
+type auditTriggerKey struct{}
+
+// auditTrigger is who triggers the operations running with the context.
+type auditTrigger struct {
+	triggeredBy string
+	jobId       uint64
+}
+
+// withTriggeredBy returns a context for the operations triggered by triggeredBy.
+func withTriggeredBy(ctx context.Context, triggeredBy string) context.Context {
+	return context.WithValue(ctx, auditTriggerKey{}, &auditTrigger{triggeredBy: triggeredBy})
+}
+
+// withJob returns a context for the operations of the job, which are triggered by who queued the job.
+func withJob(ctx context.Context, job *Job) context.Context {
+	return context.WithValue(ctx, auditTriggerKey{}, &auditTrigger{triggeredBy: job.TriggeredBy, jobId: job.Id})
+}
+
+// triggerFromContext returns who triggers the operations running with ctx. Without a trigger set on ctx, the
+// operations are triggered by the caller of the rpc, identified by the authenticated identity or the address of the
+// peer, or locally if ctx is not from the grpc server.
+func triggerFromContext(ctx context.Context) *auditTrigger {
+	if t, ok := ctx.Value(auditTriggerKey{}).(*auditTrigger); ok {
+		return t
+	}
+	if identity, ok := identityFromContext(ctx); ok {
+		return &auditTrigger{triggeredBy: "rpc:" + identity}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return &auditTrigger{triggeredBy: "rpc:" + p.Addr.String()}
+	}
+
+	return &auditTrigger{triggeredBy: triggeredByLocal}
+}
+
+func auditEventKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
+
+// auditLog appends the audit events to the db. The events are never changed or deleted.
+type auditLog struct {
+	db *bbolt.DB
+}
+
+func newAuditLog(db *bbolt.DB) *auditLog {
+	return &auditLog{db: db}
+}
+
+// append assigns the sequence to the event and saves it. Failures are logged since the push has happened.
+func (a *auditLog) append(e *AuditEvent) {
+	if a == nil {
+		return
+	}
+
+	e.CreatedAt = time.Now().Unix()
+
+	if err := a.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(AUDIT_EVENT_BUCKET))
+		if err != nil {
+			return err
+		}
+		if e.Sequence, err = b.NextSequence(); err != nil {
+			return err
+		}
+		data, err := proto.Marshal(e)
+		if err != nil {
+			return err
+		}
+		return b.Put(auditEventKey(e.Sequence), data)
+	}); err != nil {
+		logger.Error("failed to save audit event", "id", e.RepoSyncId, "operation", e.Operation.String(), "new-head", e.NewHead, "err", err)
+	}
+}
+
+// pushed records the audit event for the push of the branch of the workspace from oldhead, and returns the sync
+// event for the push.
+func (sw *syncWorkspace) pushed(
+	ctx context.Context,
+	repo *GitRepoIdentifier,
+	wksp *workspace,
+	oldhead *object.Commit,
+	commits []*object.Commit,
+	force bool,
+) *SyncEvent {
+	e := pushedEvent(repo, wksp, oldhead, force)
+
+	trigger := triggerFromContext(ctx)
+	audit := &AuditEvent{
+		RepoSyncId:  sw.db.SyncData.Id,
+		Operation:   sw.operation,
+		TriggeredBy: trigger.triggeredBy,
+		JobId:       trigger.jobId,
+		Repo:        repo,
+		Branch:      e.Branch,
+		OldHead:     e.OldHead,
+		NewHead:     e.NewHead,
+		Force:       force,
+	}
+	for _, c := range commits {
+		audit.Commits = append(audit.Commits, c.Hash.String())
+	}
+	sw.audit.append(audit)
+
+	return e
+}
+
+// observeSyncWorkspace sets up the workspace to publish the sync events and record the audit events of the
+// operation.
+func (s *Svc) observeSyncWorkspace(sw *syncWorkspace, operation AuditEvent_Operation) {
+	sw.events = s.events
+	sw.audit = s.audit
+	sw.operation = operation
+}
+
+func (s *Svc) ListAuditEvents(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	pagesize, err := getPageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// page token is the sequence of the last event in the previous page.
+	after := req.AfterSequence
+	if req.PageToken != "" {
+		seq, err := strconv.ParseUint(req.PageToken, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %s", err.Error())
+		}
+		after = max(after, seq)
+	}
+
+	canread := s.canReadFunc(ctx)
+	resp := &ListAuditEventsResponse{}
+
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(AUDIT_EVENT_BUCKET))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		for k, v := c.Seek(auditEventKey(after + 1)); k != nil; k, v = c.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			e := &AuditEvent{}
+			if err := proto.Unmarshal(v, e); err != nil {
+				return err
+			}
+			if req.RepoSyncId != "" && e.RepoSyncId != req.RepoSyncId {
+				continue
+			}
+			if !canread(e.RepoSyncId) {
+				continue
+			}
+
+			if len(resp.Events) == pagesize {
+				resp.NextPageToken = strconv.FormatUint(resp.Events[len(resp.Events)-1].Sequence, 10)
+				return nil
+			}
+			resp.Events = append(resp.Events, e)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,135 @@
+package svc
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestSvc_ListAuditEvents(t *testing.T) {
+	ctx := context.Background()
+
+	fromroot := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(fromroot, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+
+	toroot := t.TempDir()
+	todir := filepath.Join(toroot, "org", "to.git")
+	newLocalRepo(t, todir, true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"from": {RemoteName: "from", RemoteType: RemoteConfig_LOCAL, RemoteUrl: fromroot},
+			"to":   {RemoteName: "to", RemoteType: RemoteConfig_LOCAL, RemoteUrl: toroot},
+		},
+	})
+	conn, _, _ := serveTestSvc(t, s)
+	client := NewGiTrimClient(conn)
+
+	initresp, err := client.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "from", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "to", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	pushLocal(t, fromwork)
+	syncresp, err := s.SyncToSubRepo(withTriggeredBy(ctx, triggeredByScheduler), &SyncToSubRepoRequest{Id: initresp.Id, Force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	towork, err := git.PlainClone(filepath.Join(t.TempDir(), "towork"), false, &git.CloneOptions{URL: todir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitLocalFiles(t, towork, map[string]string{"a/z.txt": "z\n"}, "contribution")
+	pushLocal(t, towork)
+	fromsubresp, err := s.CommitsFromSubRepo(ctx, &CommitsFromSubRepoRequest{Id: initresp.Id, DoPush: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fromsubresp.NewCommits) != 1 {
+		t.Fatalf("want 1 new commit, got %v", fromsubresp)
+	}
+
+	list, err := client.ListAuditEvents(ctx, &ListAuditEventsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Events) != 3 {
+		t.Fatalf("want 3 events, got %v", list.Events)
+	}
+
+	initevent, syncevent, fromsubevent := list.Events[0], list.Events[1], list.Events[2]
+	if initevent.Operation != AuditEvent_INIT_REPO_SYNC || !strings.HasPrefix(initevent.TriggeredBy, "rpc:") ||
+		initevent.Repo.RemoteName != "to" || initevent.OldHead != "" || len(initevent.Commits) != 1 {
+		t.Errorf("unexpected event of init: %v", initevent)
+	}
+	if syncevent.Operation != AuditEvent_SYNC_TO_SUB_REPO || syncevent.TriggeredBy != triggeredByScheduler || !syncevent.Force ||
+		syncevent.OldHead != initevent.NewHead || syncevent.NewHead != syncresp.NewHead || len(syncevent.Commits) != 1 {
+		t.Errorf("unexpected event of sync to sub repo: %v", syncevent)
+	}
+	if fromsubevent.Operation != AuditEvent_COMMITS_FROM_SUB_REPO || fromsubevent.TriggeredBy != triggeredByLocal ||
+		fromsubevent.Repo.RemoteName != "from" || fromsubevent.Branch != "main" ||
+		!slices.Equal(fromsubevent.Commits, fromsubresp.NewCommits) || fromsubevent.NewHead != fromsubresp.NewCommits[0] {
+		t.Errorf("unexpected event of commits from sub repo: %v", fromsubevent)
+	}
+	for i, e := range list.Events {
+		if e.Sequence != uint64(i+1) || e.RepoSyncId != initresp.Id || e.CreatedAt == 0 {
+			t.Errorf("unexpected event %d: %v", i, e)
+		}
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		var seqs []uint64
+		req := &ListAuditEventsRequest{RepoSyncId: initresp.Id, AfterSequence: 1, PageSize: 1}
+		for {
+			resp, err := s.ListAuditEvents(ctx, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range resp.Events {
+				seqs = append(seqs, e.Sequence)
+			}
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+		if !slices.Equal(seqs, []uint64{2, 3}) {
+			t.Errorf("want events after 1, got %v", seqs)
+		}
+
+		other, err := s.ListAuditEvents(ctx, &ListAuditEventsRequest{RepoSyncId: "other"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(other.Events) != 0 {
+			t.Errorf("want no events of other repo syncs, got %v", other.Events)
+		}
+	})
+
+	t.Run("kept after delete", func(t *testing.T) {
+		if _, err := s.DeleteRepoSync(ctx, &DeleteRepoSyncRequest{Id: initresp.Id}); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := s.ListAuditEvents(ctx, &ListAuditEventsRequest{RepoSyncId: initresp.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Events) != 3 {
+			t.Errorf("want 3 events kept, got %d", len(resp.Events))
+		}
+	})
+}
@@ -0,0 +1,456 @@
+package svc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNoCredentials is returned by an [Authenticator] if the caller doesn't present the credentials it handles.
+	ErrNoCredentials = errors.New("no credentials")
+
+	ErrStatusUnauthenticated = status.Error(codes.Unauthenticated, "missing or invalid credentials")
+	ErrInvalidAuthConfig     = errors.New("invalid auth config")
+)
+
+// Authenticator identifies the callers of the rpcs.
+type Authenticator interface {
+	// Authenticate returns the identity of the caller of the rpc with ctx, or [ErrNoCredentials] if the caller
+	// doesn't present the credentials handled by the authenticator.
+	Authenticate(ctx context.Context) (string, error)
+}
+
+// TokenAuthenticator identifies the callers by the bearer tokens in the authorization header.
+type TokenAuthenticator struct {
+	// identities by the sha256 of the tokens.
+	identities map[[sha256.Size]byte]string
+}
+
+var _ Authenticator = (*TokenAuthenticator)(nil)
+
+func NewTokenAuthenticator(tokens []*TokenIdentity) (*TokenAuthenticator, error) {
+	a := &TokenAuthenticator{identities: make(map[[sha256.Size]byte]string)}
+
+	for _, t := range tokens {
+		if t.Identity == "" {
+			return nil, fmt.Errorf("%w: empty identity for token", ErrInvalidAuthConfig)
+		}
+
+		var sum [sha256.Size]byte
+		switch {
+		case t.Token != "" && t.TokenSha256 != "":
+			return nil, fmt.Errorf("%w: both token and token_sha256 are set for %s", ErrInvalidAuthConfig, t.Identity)
+		case t.Token != "":
+			sum = sha256.Sum256([]byte(t.Token))
+		case t.TokenSha256 != "":
+			b, err := hex.DecodeString(t.TokenSha256)
+			if err != nil || len(b) != sha256.Size {
+				return nil, fmt.Errorf("%w: token_sha256 of %s is not a hex sha256", ErrInvalidAuthConfig, t.Identity)
+			}
+			copy(sum[:], b)
+		default:
+			return nil, fmt.Errorf("%w: empty token for %s", ErrInvalidAuthConfig, t.Identity)
+		}
+
+		a.identities[sum] = t.Identity
+	}
+
+	return a, nil
+}
+
+func (a *TokenAuthenticator) Authenticate(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", ErrNoCredentials
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") {
+		return "", ErrNoCredentials
+	}
+
+	// the tokens are compared by their hashes, so the time doesn't depend on the matching prefix.
+	identity, ok := a.identities[sha256.Sum256([]byte(strings.TrimSpace(token)))]
+	if !ok {
+		return "", ErrStatusUnauthenticated
+	}
+
+	return identity, nil
+}
+
+// ClientCertAuthenticator identifies the callers by the client certificates verified by the tls of the server.
+type ClientCertAuthenticator struct {
+	certs []*ClientCertIdentity
+}
+
+var _ Authenticator = (*ClientCertAuthenticator)(nil)
+
+func NewClientCertAuthenticator(certs []*ClientCertIdentity) (*ClientCertAuthenticator, error) {
+	for _, c := range certs {
+		if c.Identity == "" {
+			return nil, fmt.Errorf("%w: empty identity for client certificate", ErrInvalidAuthConfig)
+		}
+		if c.CommonName == "" && c.DnsName == "" && c.Uri == "" {
+			return nil, fmt.Errorf("%w: nothing to match the client certificate of %s", ErrInvalidAuthConfig, c.Identity)
+		}
+	}
+
+	return &ClientCertAuthenticator{certs: certs}, nil
+}
+
+func (a *ClientCertAuthenticator) Authenticate(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", ErrNoCredentials
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", ErrNoCredentials
+	}
+
+	leaf := info.State.VerifiedChains[0][0]
+	for _, c := range a.certs {
+		if (c.CommonName != "" && c.CommonName == leaf.Subject.CommonName) ||
+			(c.DnsName != "" && slices.Contains(leaf.DNSNames, c.DnsName)) ||
+			(c.Uri != "" && slices.ContainsFunc(leaf.URIs, func(u *url.URL) bool { return u.String() == c.Uri })) {
+			return c.Identity, nil
+		}
+	}
+
+	// the certificate is valid, but other authenticators may know the caller.
+	return "", ErrNoCredentials
+}
+
+// chainAuthenticator tries the authenticators in order.
+type chainAuthenticator []Authenticator
+
+// ChainAuthenticators returns an [Authenticator] returning the result of the first authenticator that handles
+// the credentials of the caller.
+func ChainAuthenticators(authenticators ...Authenticator) Authenticator {
+	return chainAuthenticator(authenticators)
+}
+
+func (c chainAuthenticator) Authenticate(ctx context.Context) (string, error) {
+	for _, a := range c {
+		identity, err := a.Authenticate(ctx)
+		if !errors.Is(err, ErrNoCredentials) {
+			return identity, err
+		}
+	}
+
+	return "", ErrNoCredentials
+}
+
+// identityKey is the key of the identity of the caller in the context.
+type identityKey struct{}
+
+// identityFromContext returns the identity of the authenticated caller.
+func identityFromContext(ctx context.Context) (string, bool) {
+	identity, ok := ctx.Value(identityKey{}).(string)
+	return identity, ok
+}
+
+// setupAuth creates the authenticator from the auth config, and checks the role bindings.
+func (s *Svc) setupAuth() error {
+	auth := s.config.Auth
+	if auth == nil {
+		return nil
+	}
+
+	for _, b := range auth.RoleBindings {
+		if _, valid := RoleBinding_Role_name[int32(b.Role)]; !valid || b.Role == RoleBinding_UNKNOWN {
+			return fmt.Errorf("%w: unknown role %d for %v", ErrInvalidAuthConfig, b.Role, b.Identities)
+		}
+	}
+
+	tokens, err := NewTokenAuthenticator(auth.Tokens)
+	if err != nil {
+		return err
+	}
+	certs, err := NewClientCertAuthenticator(auth.ClientCerts)
+	if err != nil {
+		return err
+	}
+
+	s.authenticator = ChainAuthenticators(certs, tokens)
+
+	return nil
+}
+
+// SetAuthenticator replaces the authenticator of the gRPC API, which is created from the auth config by default.
+// The role bindings in the auth config still decide what the identities are allowed to do, and a nil a disables
+// the auth.
+func (s *Svc) SetAuthenticator(a Authenticator) {
+	s.authenticator = a
+}
+
+// roleGrants checks if granted includes the permissions of role.
+func roleGrants(granted RoleBinding_Role, role RoleBinding_Role) bool {
+	return granted == role || granted == RoleBinding_ADMIN || (role == RoleBinding_READ && granted != RoleBinding_UNKNOWN)
+}
+
+// isAllowed checks if the identity is granted the role on the repo sync. Empty id is allowed only by the role
+// bindings on all the repo syncs.
+func (s *Svc) isAllowed(identity string, id string, role RoleBinding_Role) bool {
+	if s.config.Auth == nil {
+		return true
+	}
+
+	for _, b := range s.config.Auth.RoleBindings {
+		if !roleGrants(b.Role, role) || !slices.Contains(b.Identities, identity) {
+			continue
+		}
+		if len(b.RepoSyncIds) == 0 || (id != "" && slices.Contains(b.RepoSyncIds, id)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func permissionDenied(identity string, id string, role RoleBinding_Role) error {
+	if id == "" {
+		return status.Errorf(codes.PermissionDenied, "%s is not granted %s on all repo syncs", identity, role.String())
+	}
+	return status.Errorf(codes.PermissionDenied, "%s is not granted %s on repo sync %s", identity, role.String(), id)
+}
+
+// requiredRole returns the role needed on the repo syncs for the request. UNKNOWN means the caller only needs to be
+// authenticated, and the responses only contain the repo syncs the caller can read.
+func requiredRole(req any) (RoleBinding_Role, []string) {
+	switch r := req.(type) {
+	case *InitRepoSyncRequest:
+		if r.FromRepo == nil || r.ToRepo == nil {
+			return RoleBinding_ADMIN, []string{""}
+		}
+		return RoleBinding_ADMIN, []string{hex.EncodeToString(NewRepoSyncId(r.FromRepo, r.FromBranch, r.ToRepo, r.ToBranch))}
+	case *SyncToSubRepoRequest:
+		// the overridden branches can be any branches of the repos.
+		if r.Force || HasOverrides(r) {
+			return RoleBinding_ADMIN, []string{r.Id}
+		}
+		return RoleBinding_SYNC, []string{r.Id}
+	case *SyncToSubRepoBundleRequest:
+		if HasOverrides(r) {
+			return RoleBinding_ADMIN, []string{r.Id}
+		}
+		return RoleBinding_SYNC, []string{r.Id}
+	case *CommitsFromSubRepoRequest:
+		switch {
+		case HasOverrides(r):
+			return RoleBinding_ADMIN, []string{r.Id}
+		case r.DoPush:
+			return RoleBinding_CONTRIBUTE, []string{r.Id}
+		}
+		return RoleBinding_READ, []string{r.Id}
+	case *CommitsFromPatchesRequest:
+		if HasOverrides(r) {
+			return RoleBinding_ADMIN, []string{r.Id}
+		}
+		if r.DoPush {
+			return RoleBinding_CONTRIBUTE, []string{r.Id}
+		}
+		return RoleBinding_READ, []string{r.Id}
+	case *CheckRepoSyncUpToDateRequest:
+		return RoleBinding_READ, []string{r.Id}
+	case *CheckCommitsFromSubRepoRequest:
+		return RoleBinding_READ, []string{r.Id}
+	case *GetRepoSyncRequest:
+		return RoleBinding_READ, []string{r.Id}
+	case *UpdateRepoSyncRequest:
+		return RoleBinding_ADMIN, []string{r.Id}
+	case *DeleteRepoSyncRequest:
+		return RoleBinding_ADMIN, []string{r.Id}
+	case *RotateSecretRequest:
+		return RoleBinding_ADMIN, []string{r.Id}
+	case *UpdateRepoSyncFilterRequest:
+		if r.DoPush {
+			return RoleBinding_ADMIN, []string{r.Id}
+		}
+		return RoleBinding_READ, []string{r.Id}
+	case *EnqueueJobRequest:
+		switch v := r.Request.(type) {
+		case *EnqueueJobRequest_SyncToSubRepo:
+			return requiredRole(v.SyncToSubRepo)
+		case *EnqueueJobRequest_CommitsFromSubRepo:
+			return requiredRole(v.CommitsFromSubRepo)
+		}
+	case *ListJobsRequest:
+		if r.RepoSyncId != "" {
+			return RoleBinding_READ, []string{r.RepoSyncId}
+		}
+	case *ListAuditEventsRequest:
+		if r.RepoSyncId != "" {
+			return RoleBinding_READ, []string{r.RepoSyncId}
+		}
+	case *WatchSyncEventsRequest:
+		if len(r.Ids) > 0 {
+			return RoleBinding_READ, r.Ids
+		}
+	case *BackupRequest, *ExportRequest, *ImportRequest:
+		// they read or write the secrets of all the repo syncs.
+		return RoleBinding_ADMIN, []string{""}
+	}
+
+	return RoleBinding_UNKNOWN, nil
+}
+
+// authorize checks the identity is granted the role needed for the request.
+func (s *Svc) authorize(identity string, req any) error {
+	role, ids := requiredRole(req)
+	if role == RoleBinding_UNKNOWN {
+		return nil
+	}
+	for _, id := range ids {
+		if !s.isAllowed(identity, id, role) {
+			return permissionDenied(identity, id, role)
+		}
+	}
+
+	return nil
+}
+
+// canReadFunc returns the check of the repo syncs the caller in ctx can read. The lists skip the repo syncs in their
+// cursor loops, so the pages are filled with the ones the caller can read. Everything can be read by a caller without
+// an identity, which doesn't go through the interceptors.
+func (s *Svc) canReadFunc(ctx context.Context) func(id string) bool {
+	identity, ok := identityFromContext(ctx)
+	if !ok {
+		return func(string) bool { return true }
+	}
+
+	return func(id string) bool { return s.isAllowed(identity, id, RoleBinding_READ) }
+}
+
+// filterResponse removes the job the identity cannot read, and the secrets if the identity is not an admin.
+func (s *Svc) filterResponse(identity string, resp any) (any, error) {
+	canread := func(id string) bool { return s.isAllowed(identity, id, RoleBinding_READ) }
+
+	switch r := resp.(type) {
+	case *GetJobResponse:
+		if !canread(r.Job.GetRepoSyncId()) {
+			return nil, permissionDenied(identity, r.Job.GetRepoSyncId(), RoleBinding_READ)
+		}
+	case *GetRepoSyncResponse:
+		if !s.isAllowed(identity, r.RepoSync.GetId(), RoleBinding_ADMIN) {
+			r.Secret = ""
+		}
+	}
+
+	return resp, nil
+}
+
+// isAuthExempt checks if the method is allowed without authentication.
+func isAuthExempt(fullmethod string) bool {
+	return strings.HasPrefix(fullmethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
+// authenticate returns the context with the identity of the caller.
+func (s *Svc) authenticate(ctx context.Context, fullmethod string) (context.Context, string, error) {
+	identity, err := s.authenticator.Authenticate(ctx)
+	switch {
+	case errors.Is(err, ErrNoCredentials):
+		logger.Warn("rejected rpc without credentials", "method", fullmethod)
+		return nil, "", ErrStatusUnauthenticated
+	case err != nil:
+		logger.Warn("rejected rpc with invalid credentials", "method", fullmethod, "err", err)
+		if _, ok := status.FromError(err); ok {
+			return nil, "", err
+		}
+		return nil, "", ErrStatusUnauthenticated
+	}
+
+	return context.WithValue(ctx, identityKey{}, identity), identity, nil
+}
+
+func (s *Svc) authUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isAuthExempt(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	ctx, identity, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(identity, req); err != nil {
+		logger.Warn("denied rpc", "method", info.FullMethod, "identity", identity, "err", err)
+		return nil, err
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.filterResponse(identity, resp)
+}
+
+// authServerStream authorizes the requests received on the stream, and drops the sync events the caller cannot
+// read.
+type authServerStream struct {
+	grpc.ServerStream
+
+	ctx      context.Context
+	s        *Svc
+	identity string
+}
+
+func (ss *authServerStream) Context() context.Context {
+	return ss.ctx
+}
+
+func (ss *authServerStream) RecvMsg(m any) error {
+	if err := ss.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return ss.s.authorize(ss.identity, m)
+}
+
+func (ss *authServerStream) SendMsg(m any) error {
+	if e, ok := m.(*SyncEvent); ok && !ss.s.isAllowed(ss.identity, e.RepoSyncId, RoleBinding_READ) {
+		return nil
+	}
+
+	return ss.ServerStream.SendMsg(m)
+}
+
+func (s *Svc) authStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isAuthExempt(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	ctx, identity, err := s.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx, s: s, identity: identity})
+}
+
+// authServerOptions returns the interceptors checking the callers, nil if the auth is disabled.
+func (s *Svc) authServerOptions() []grpc.ServerOption {
+	if s.authenticator == nil {
+		return nil
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.authUnaryInterceptor),
+		grpc.ChainStreamInterceptor(s.authStreamInterceptor),
+	}
+}
@@ -0,0 +1,353 @@
+package svc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testCert is a certificate generated for the tests.
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key, Leaf: c.cert}
+}
+
+// newTestCert creates a certificate from the template, signed by parent, or self-signed if parent is nil.
+func newTestCert(t *testing.T, template *x509.Certificate, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.SerialNumber = serial
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	signer, signerkey := template, key
+	if parent != nil {
+		signer, signerkey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCert{cert: cert, key: key}
+}
+
+func newTestCA(t *testing.T) *testCert {
+	return newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "gitrim test ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+}
+
+func newTestServerCert(t *testing.T, ca *testCert, dnsname string) *testCert {
+	return newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsname},
+		DNSNames:    []string{dnsname},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+	}, ca)
+}
+
+func newTestClientCert(t *testing.T, ca *testCert, commonname string) *testCert {
+	return newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonname},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+	}, ca)
+}
+
+func withTestToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func wantCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+
+	if status.Code(err) != code {
+		t.Errorf("want %s, got %v", code, err)
+	}
+}
+
+func TestSvc_Auth_Tokens(t *testing.T) {
+	ctx := context.Background()
+
+	fromroot := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(fromroot, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+
+	toroot := t.TempDir()
+	newLocalRepo(t, filepath.Join(toroot, "org", "to.git"), true)
+	newLocalRepo(t, filepath.Join(toroot, "org", "other.git"), true)
+
+	from := &GitRepoIdentifier{RemoteName: "from", Owner: "org", Repo: "from"}
+	to := &GitRepoIdentifier{RemoteName: "to", Owner: "org", Repo: "to"}
+	other := &GitRepoIdentifier{RemoteName: "to", Owner: "org", Repo: "other"}
+	toid := hex.EncodeToString(NewRepoSyncId(from, "main", to, "main"))
+
+	hashed := sha256.Sum256([]byte("syncer-token"))
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"from": {RemoteName: "from", RemoteType: RemoteConfig_LOCAL, RemoteUrl: fromroot},
+			"to":   {RemoteName: "to", RemoteType: RemoteConfig_LOCAL, RemoteUrl: toroot},
+		},
+		Auth: &AuthConfig{
+			Tokens: []*TokenIdentity{
+				{Identity: "admin", Token: "admin-token"},
+				{Identity: "syncer", TokenSha256: hex.EncodeToString(hashed[:])},
+				{Identity: "nobody", Token: "nobody-token"},
+			},
+			RoleBindings: []*RoleBinding{
+				{Identities: []string{"admin"}, Role: RoleBinding_ADMIN},
+				{Identities: []string{"syncer"}, Role: RoleBinding_SYNC, RepoSyncIds: []string{toid}},
+			},
+		},
+	})
+	conn, _, _ := serveTestSvc(t, s)
+	client := NewGiTrimClient(conn)
+
+	admin := withTestToken(ctx, "admin-token")
+	syncer := withTestToken(ctx, "syncer-token")
+	nobody := withTestToken(ctx, "nobody-token")
+
+	if _, err := client.ListRepoSyncs(ctx, &ListRepoSyncsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("want unauthenticated without token, got %v", err)
+	}
+	_, err := client.ListRepoSyncs(withTestToken(ctx, "wrong-token"), &ListRepoSyncsRequest{})
+	wantCode(t, err, codes.Unauthenticated)
+
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Errorf("health check without token: %v", err)
+	}
+
+	initreq := &InitRepoSyncRequest{FromRepo: from, FromBranch: "main", ToRepo: to, ToBranch: "main", Filter: "a/"}
+	_, err = client.InitRepoSync(syncer, initreq)
+	wantCode(t, err, codes.PermissionDenied)
+	if _, err := client.InitRepoSync(admin, initreq); err != nil {
+		t.Fatal(err)
+	}
+	otherresp, err := client.InitRepoSync(admin, &InitRepoSyncRequest{FromRepo: from, FromBranch: "main", ToRepo: other, ToBranch: "main", Filter: "b/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("roles", func(t *testing.T) {
+		if _, err := client.SyncToSubRepo(syncer, &SyncToSubRepoRequest{Id: toid}); err != nil {
+			t.Errorf("sync with sync role: %v", err)
+		}
+		_, err := client.SyncToSubRepo(syncer, &SyncToSubRepoRequest{Id: toid, Force: true})
+		wantCode(t, err, codes.PermissionDenied)
+		_, err = client.SyncToSubRepo(syncer, &SyncToSubRepoRequest{Id: otherresp.Id})
+		wantCode(t, err, codes.PermissionDenied)
+		_, err = client.SyncToSubRepo(syncer, &SyncToSubRepoRequest{Id: toid, OverrideToBranch: "other"})
+		wantCode(t, err, codes.PermissionDenied)
+		_, err = client.CommitsFromSubRepo(syncer, &CommitsFromSubRepoRequest{Id: toid, DoPush: true})
+		wantCode(t, err, codes.PermissionDenied)
+		_, err = client.CommitsFromSubRepo(syncer, &CommitsFromSubRepoRequest{Id: toid, OverrideFromBranch: "other"})
+		wantCode(t, err, codes.PermissionDenied)
+		if _, err := client.CommitsFromSubRepo(syncer, &CommitsFromSubRepoRequest{Id: toid}); err != nil {
+			t.Errorf("check commits from sub repo with sync role: %v", err)
+		}
+		_, err = client.DeleteRepoSync(syncer, &DeleteRepoSyncRequest{Id: toid})
+		wantCode(t, err, codes.PermissionDenied)
+		_, err = client.GetRepoSync(nobody, &GetRepoSyncRequest{Id: toid})
+		wantCode(t, err, codes.PermissionDenied)
+		_, err = client.EnqueueJob(syncer, &EnqueueJobRequest{
+			Request: &EnqueueJobRequest_SyncToSubRepo{SyncToSubRepo: &SyncToSubRepoRequest{Id: otherresp.Id}},
+		})
+		wantCode(t, err, codes.PermissionDenied)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		list, err := client.ListRepoSyncs(syncer, &ListRepoSyncsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.RepoSyncs) != 1 || list.RepoSyncs[0].Id != toid {
+			t.Errorf("want only %s listed, got %v", toid, list.RepoSyncs)
+		}
+		list, err = client.ListRepoSyncs(admin, &ListRepoSyncsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.RepoSyncs) != 2 {
+			t.Errorf("want 2 repo syncs for admin, got %v", list.RepoSyncs)
+		}
+
+		get, err := client.GetRepoSync(syncer, &GetRepoSyncRequest{Id: toid})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if get.Secret != "" {
+			t.Errorf("want secret cleared for non-admin")
+		}
+		get, err = client.GetRepoSync(admin, &GetRepoSyncRequest{Id: toid})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if get.Secret == "" {
+			t.Errorf("want secret for admin")
+		}
+
+		events, err := client.ListAuditEvents(syncer, &ListAuditEventsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range events.Events {
+			if e.RepoSyncId != toid {
+				t.Errorf("want only the events of %s, got %v", toid, e)
+			}
+		}
+		if len(events.Events) == 0 || events.Events[0].TriggeredBy != "rpc:admin" {
+			t.Errorf("want events triggered by rpc:admin, got %v", events.Events)
+		}
+
+		// the pages are filled with the entries the caller can read.
+		list, err = client.ListRepoSyncs(syncer, &ListRepoSyncsRequest{PageSize: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.RepoSyncs) != 1 || list.NextPageToken != "" {
+			t.Errorf("want only %s in one page, got %v", toid, list)
+		}
+		var token string
+		var paged []*AuditEvent
+		for {
+			page, err := client.ListAuditEvents(syncer, &ListAuditEventsRequest{PageSize: 1, PageToken: token})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page.NextPageToken != "" && len(page.Events) != 1 {
+				t.Fatalf("want a full page before the next page token, got %v", page)
+			}
+			paged = append(paged, page.Events...)
+			if page.NextPageToken == "" {
+				break
+			}
+			token = page.NextPageToken
+		}
+		if len(paged) != len(events.Events) {
+			t.Errorf("want %d events in pages, got %d", len(events.Events), len(paged))
+		}
+	})
+}
+
+func TestSvc_Auth_ClientCerts(t *testing.T) {
+	ctx := context.Background()
+
+	ca := newTestCA(t)
+	servercert := newTestServerCert(t, ca, "gitrim.test")
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Auth: &AuthConfig{
+			ClientCerts: []*ClientCertIdentity{{Identity: "ci", CommonName: "ci.gitrim.test"}},
+			Tokens:      []*TokenIdentity{{Identity: "admin", Token: "admin-token"}},
+			RoleBindings: []*RoleBinding{
+				{Identities: []string{"ci"}, Role: RoleBinding_READ},
+			},
+		},
+	})
+
+	lis := bufconn.Listen(1 << 20)
+	servectx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+	go s.ServeListener(servectx, lis, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{servercert.tlsCertificate()},
+		ClientCAs:    pool,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+	})))
+
+	dial := func(t *testing.T, cert *testCert) GiTrimClient {
+		t.Helper()
+
+		clienttls := &tls.Config{RootCAs: pool, ServerName: "gitrim.test"}
+		if cert != nil {
+			clienttls.Certificates = []tls.Certificate{cert.tlsCertificate()}
+		}
+		conn, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(credentials.NewTLS(clienttls)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+
+		return NewGiTrimClient(conn)
+	}
+
+	if _, err := dial(t, newTestClientCert(t, ca, "ci.gitrim.test")).ListRepoSyncs(ctx, &ListRepoSyncsRequest{}); err != nil {
+		t.Errorf("list with the client certificate: %v", err)
+	}
+
+	_, err := dial(t, newTestClientCert(t, ca, "ci.gitrim.test")).DeleteRepoSync(ctx, &DeleteRepoSyncRequest{Id: "any"})
+	wantCode(t, err, codes.PermissionDenied)
+
+	_, err = dial(t, newTestClientCert(t, ca, "unknown.gitrim.test")).ListRepoSyncs(ctx, &ListRepoSyncsRequest{})
+	wantCode(t, err, codes.Unauthenticated)
+
+	_, err = dial(t, nil).ListRepoSyncs(ctx, &ListRepoSyncsRequest{})
+	wantCode(t, err, codes.Unauthenticated)
+
+	// the tokens are still accepted over tls without a client certificate.
+	if _, err := dial(t, nil).ListRepoSyncs(withTestToken(ctx, "admin-token"), &ListRepoSyncsRequest{}); err != nil {
+		t.Errorf("list with the token: %v", err)
+	}
+}
+
+func TestSvc_Auth_InvalidConfig(t *testing.T) {
+	for name, auth := range map[string]*AuthConfig{
+		"empty token":  {Tokens: []*TokenIdentity{{Identity: "a"}}},
+		"bad sha256":   {Tokens: []*TokenIdentity{{Identity: "a", TokenSha256: "xyz"}}},
+		"no identity":  {Tokens: []*TokenIdentity{{Token: "t"}}},
+		"no cert name": {ClientCerts: []*ClientCertIdentity{{Identity: "a"}}},
+		"unknown role": {RoleBindings: []*RoleBinding{{Identities: []string{"a"}}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New(&GiTrimConfig{DbPath: filepath.Join(t.TempDir(), "gitrim.db"), Auth: auth}); err == nil {
+				t.Errorf("want error for %v", auth)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package svc
+
+import (
+	"bufio"
+	"bytes"
+
+	"go.etcd.io/bbolt"
+)
+
+// streamChunkSize is the max size of the data in a response of a streamed file, like [BackupResponse].
+const streamChunkSize = 1 << 20
+
+// chunkWriter sends the data written to it with the function, in chunks of at most streamChunkSize.
+type chunkWriter func(data []byte) error
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	for i := 0; i < len(p); i += streamChunkSize {
+		// the buffer of the writer may be reused after Write returns.
+		if err := w(bytes.Clone(p[i:min(i+streamChunkSize, len(p))])); err != nil {
+			return i, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// newChunkWriter returns a writer sending the data written to it with send, in chunks of streamChunkSize except
+// the last one. The writer must be flushed.
+func newChunkWriter(send func(data []byte) error) *bufio.Writer {
+	return bufio.NewWriterSize(chunkWriter(send), streamChunkSize)
+}
+
+func (s *Svc) Backup(req *BackupRequest, stream GiTrim_BackupServer) error {
+	return s.db.View(func(tx *bbolt.Tx) error {
+		size := tx.Size()
+		sent := false
+		w := newChunkWriter(func(data []byte) error {
+			resp := &BackupResponse{Data: data}
+			if !sent {
+				// the size is sent with the first chunk.
+				resp.Size, sent = size, true
+			}
+			return stream.Send(resp)
+		})
+		if _, err := tx.WriteTo(w); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		logger.Info("streamed backup", "size", size)
+
+		return nil
+	})
+}
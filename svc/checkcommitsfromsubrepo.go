@@ -26,10 +26,12 @@ func (s *Svc) CheckCommitsFromSubRepo(
 	ctx context.Context,
 	req *CheckCommitsFromSubRepoRequest,
 ) (*CheckCommitsFromSubRepoResponse, error) {
-	sw, err := loadSyncWorkspaceFroReq(ctx, s.config.Remotes, s.db, req, true)
+	sw, err := loadSyncWorkspaceFroReq(ctx, s.config.Remotes, s.objectCache, s.db, req, true)
 	if err != nil {
 		return nil, err
 	}
+	defer sw.close()
+	sw.events = s.events
 
 	status := checkSubHistoryForSyncToFrom(sw.fromStatus, sw.toStatus)
 	var rejectedfiles []string
@@ -37,7 +39,7 @@ func (s *Svc) CheckCommitsFromSubRepo(
 	var isgpg bool
 
 	if status == SubRepoCommitsCheck_CHECK_PASSED {
-		fileerrors, isgpg, err = sw.checkCommits(ctx)
+		fileerrors, isgpg, err = sw.checkCommits(ctx, req.AllowPgpSignature)
 		if err != nil {
 			return nil, err
 		}
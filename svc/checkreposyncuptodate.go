@@ -6,10 +6,11 @@ func (s *Svc) CheckRepoSyncUpToDate(
 	ctx context.Context,
 	req *CheckRepoSyncUpToDateRequest,
 ) (*CheckRepoSyncUpToDateResponse, error) {
-	sw, err := loadSyncWorkspaceFromDb(ctx, s.config.Remotes, req.Id, s.db, true)
+	sw, err := loadSyncWorkspaceFromDb(ctx, s.config.Remotes, s.objectCache, req.Id, s.db, true)
 	if err != nil {
 		return nil, err
 	}
+	defer sw.close()
 	return &CheckRepoSyncUpToDateResponse{
 		FromRepoStatus: sw.fromStatus,
 		ToRepoStatus:   sw.toStatus,
@@ -0,0 +1,114 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/fardream/gitrim"
+)
+
+func (s *Svc) CommitsFromPatches(ctx context.Context, req *CommitsFromPatchesRequest) (*CommitsFromPatchesResponse, error) {
+	resp, err := s.commitsFromPatches(ctx, req)
+
+	outcome := syncOutcomeSynced
+	switch {
+	case err != nil:
+		outcome = syncOutcomeFailed
+	case resp.Result == SubRepoCommitsCheck_COMMITS_REJECTED:
+		outcome = syncOutcomeRejected
+	case resp.Result != SubRepoCommitsCheck_CHECK_PASSED:
+		outcome = syncOutcomeNotInSync
+	case !req.DoPush || HasOverrides(req):
+		outcome = syncOutcomeChecked
+	case len(resp.NewCommits) == 0:
+		outcome = syncOutcomeUpToDate
+	}
+	observeSync(req.Id, syncOperationFromPatches, outcome)
+
+	return resp, err
+}
+
+func (s *Svc) commitsFromPatches(ctx context.Context, req *CommitsFromPatchesRequest) (*CommitsFromPatchesResponse, error) {
+	patches, err := gitrim.ParseMbox(bytes.NewReader(req.Mbox))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse mbox: %s", err.Error())
+	}
+
+	sw, err := loadSyncWorkspaceFroReq(ctx, s.config.Remotes, s.objectCache, s.db, req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer sw.close()
+	s.observeSyncWorkspace(sw, AuditEvent_COMMITS_FROM_PATCHES)
+
+	result := checkSubHistoryForPatches(sw.fromStatus, sw.toStatus)
+
+	var rejectedfiles []string
+	if result == SubRepoCommitsCheck_CHECK_PASSED {
+		checkresults := make([]*gitrim.FilePatchCheckResult, 0, len(patches))
+		for _, p := range patches {
+			checkresults = append(checkresults, gitrim.CheckFilePatchAgainstFilter(p.DiffFilePatches(), sw.filter))
+		}
+		rejectedfiles = getRejectedFiles(checkresults)
+		if len(rejectedfiles) > 0 {
+			result = SubRepoCommitsCheck_COMMITS_REJECTED
+		}
+	}
+
+	resp := &CommitsFromPatchesResponse{
+		Result:         result,
+		FromRepoStatus: sw.fromStatus,
+		ToRepoStatus:   sw.toStatus,
+		RejectedFiles:  rejectedfiles,
+	}
+
+	if result != SubRepoCommitsCheck_CHECK_PASSED {
+		return resp, nil
+	}
+
+	dopush := !HasOverrides(req) && req.DoPush
+	if dopush {
+		idwaiter, err := s.lockId(ctx, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		defer s.unlockId(req.Id, idwaiter)
+	}
+
+	fromcommits, tocommits, err := sw.syncPatchesToFrom(ctx, patches, dopush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply patches: %w", err)
+	}
+
+	if dopush {
+		id, err := hex.DecodeString(req.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode id: %w", err)
+		}
+		if err := s.db.Update(func(tx *bbolt.Tx) error {
+			return sw.putFunc(id)(tx)
+		}); err != nil {
+			return nil, err
+		}
+		if err := s.db.Sync(); err != nil {
+			return nil, ErrStatusDBFailure
+		}
+	} else {
+		logger.Info("not updating due to has override or not pushing", "has-override", HasOverrides(req), "do-push", req.DoPush)
+	}
+
+	for _, c := range fromcommits {
+		resp.NewCommits = append(resp.NewCommits, c.Hash.String())
+	}
+	for _, c := range tocommits {
+		resp.NewSubCommits = append(resp.NewSubCommits, c.Hash.String())
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,199 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/fardream/gitrim"
+)
+
+// getLocalHead returns the head of the main branch of the repo at dir.
+func getLocalHead(t *testing.T, dir string) plumbing.Hash {
+	t.Helper()
+
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ref.Hash()
+}
+
+// formatLocalPatch clones the repo at dir, commits the files on top of its main branch, and returns the mbox of the
+// commit, which is not pushed.
+func formatLocalPatch(t *testing.T, dir string, files map[string]string, msg string) []byte {
+	t.Helper()
+
+	work, err := git.PlainClone(t.TempDir(), false, &git.CloneOptions{URL: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := commitLocalFiles(t, work, files, msg)
+	c, err := work.CommitObject(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, err := c.Parent(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := gitrim.FormatPatch(context.Background(), &buf, c, parent, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestSvc_CommitsFromPatches(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	frompath, topath := filepath.Join(root, "org", "from"), filepath.Join(root, "org", "to")
+	fromwork := newLocalWorkRepo(t, frompath)
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+	newLocalRepo(t, topath, true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"local": {RemoteName: "local", RemoteUrl: root},
+		},
+	})
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := initresp.Id
+
+	checkHeads := func(t *testing.T, fromhead, tohead plumbing.Hash) {
+		t.Helper()
+
+		if got := getLocalHead(t, frompath); got != fromhead {
+			t.Errorf("want from head %s, got %s", fromhead, got)
+		}
+		if got := getLocalHead(t, topath); got != tohead {
+			t.Errorf("want to head %s, got %s", tohead, got)
+		}
+	}
+	checkStat := func(t *testing.T) {
+		t.Helper()
+
+		got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.SyncStat.LastSyncFromCommit != getLocalHead(t, frompath).String() || got.SyncStat.LastSyncToCommit != getLocalHead(t, topath).String() {
+			t.Errorf("stat %v is not at the heads of the repos", got.SyncStat)
+		}
+	}
+
+	oldfrom, oldto := getLocalHead(t, frompath), getLocalHead(t, topath)
+	mbox := formatLocalPatch(t, topath, map[string]string{"a/x.txt": "x2\n"}, "change x")
+
+	t.Run("dry run", func(t *testing.T) {
+		resp, err := s.CommitsFromPatches(ctx, &CommitsFromPatchesRequest{Id: id, Mbox: mbox})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Result != SubRepoCommitsCheck_CHECK_PASSED || len(resp.NewCommits) != 1 || len(resp.NewSubCommits) != 1 {
+			t.Errorf("want one new commit in each repo, got %v", resp)
+		}
+		checkHeads(t, oldfrom, oldto)
+		checkStat(t)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		resp, err := s.CommitsFromPatches(ctx, &CommitsFromPatchesRequest{
+			Id:     id,
+			Mbox:   formatLocalPatch(t, topath, map[string]string{"b/y.txt": "y2\n"}, "change y"),
+			DoPush: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Result != SubRepoCommitsCheck_COMMITS_REJECTED || len(resp.RejectedFiles) != 1 || resp.RejectedFiles[0] != "b/y.txt" {
+			t.Errorf("want b/y.txt rejected, got %v", resp)
+		}
+		checkHeads(t, oldfrom, oldto)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		resp, err := s.CommitsFromPatches(ctx, &CommitsFromPatchesRequest{Id: id, Mbox: mbox, DoPush: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.NewCommits) != 1 || len(resp.NewSubCommits) != 1 {
+			t.Fatalf("want one new commit in each repo, got %v", resp)
+		}
+		checkHeads(t, plumbing.NewHash(resp.NewCommits[0]), plumbing.NewHash(resp.NewSubCommits[0]))
+		checkStat(t)
+	})
+
+	t.Run("to push fails", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git is not found")
+		}
+
+		// the to repo rejects the push.
+		hook := filepath.Join(topath, "hooks", "pre-receive")
+		if err := os.MkdirAll(filepath.Dir(hook), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		oldfrom, oldto := getLocalHead(t, frompath), getLocalHead(t, topath)
+		_, err := s.CommitsFromPatches(ctx, &CommitsFromPatchesRequest{
+			Id:     id,
+			Mbox:   formatLocalPatch(t, topath, map[string]string{"a/x.txt": "x3\n"}, "change x again"),
+			DoPush: true,
+		})
+		newfrom := getLocalHead(t, frompath)
+		if err == nil || !strings.Contains(err.Error(), "pushed 1 new commits to local/org/from branch main with new head "+newfrom.String()) {
+			t.Fatalf("want the error to tell the pushed commits, got %v", err)
+		}
+		if newfrom == oldfrom || getLocalHead(t, topath) != oldto {
+			t.Fatalf("want only from repo pushed")
+		}
+
+		if err := os.Remove(hook); err != nil {
+			t.Fatal(err)
+		}
+		syncresp, err := s.SyncToSubRepo(ctx, &SyncToSubRepoRequest{Id: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if syncresp.NumberOfNewCommits != 1 {
+			t.Errorf("want the pushed commit synced to the to repo, got %v", syncresp)
+		}
+		checkStat(t)
+	})
+
+	for outcome, want := range map[string]float64{syncOutcomeChecked: 1, syncOutcomeRejected: 1, syncOutcomeSynced: 1, syncOutcomeFailed: 1} {
+		if got := getTestCounter(t, syncsTotal.WithLabelValues(id, syncOperationFromPatches, outcome)); got != want {
+			t.Errorf("want %v syncs %s, got %v", want, outcome, got)
+		}
+	}
+}
@@ -11,10 +11,35 @@ import (
 )
 
 func (s *Svc) CommitsFromSubRepo(ctx context.Context, req *CommitsFromSubRepoRequest) (*CommitsFromSubRepoResponse, error) {
-	sw, err := loadSyncWorkspaceFroReq(ctx, s.config.Remotes, s.db, req, true)
+	resp, err := s.commitsFromSubRepo(ctx, req)
+
+	outcome := syncOutcomeSynced
+	switch {
+	case err != nil:
+		outcome = syncOutcomeFailed
+	case resp.Result == SubRepoCommitsCheck_COMMITS_REJECTED:
+		outcome = syncOutcomeRejected
+	case resp.Result == SubRepoCommitsCheck_TO_NO_NEW_COMMITS:
+		outcome = syncOutcomeUpToDate
+	case resp.Result != SubRepoCommitsCheck_CHECK_PASSED:
+		outcome = syncOutcomeNotInSync
+	case !req.DoPush || HasOverrides(req):
+		outcome = syncOutcomeChecked
+	case len(resp.NewCommits) == 0:
+		outcome = syncOutcomeUpToDate
+	}
+	observeSync(req.Id, syncOperationFromSubRepo, outcome)
+
+	return resp, err
+}
+
+func (s *Svc) commitsFromSubRepo(ctx context.Context, req *CommitsFromSubRepoRequest) (*CommitsFromSubRepoResponse, error) {
+	sw, err := loadSyncWorkspaceFroReq(ctx, s.config.Remotes, s.objectCache, s.db, req, true)
 	if err != nil {
 		return nil, err
 	}
+	defer sw.close()
+	s.observeSyncWorkspace(sw, AuditEvent_COMMITS_FROM_SUB_REPO)
 
 	status := checkSubHistoryForSyncToFrom(sw.fromStatus, sw.toStatus)
 	var rejectedfiles []string
@@ -22,7 +47,7 @@ func (s *Svc) CommitsFromSubRepo(ctx context.Context, req *CommitsFromSubRepoReq
 	var isgpg bool
 
 	if status == SubRepoCommitsCheck_CHECK_PASSED {
-		fileerrors, isgpg, err = sw.checkCommits(ctx)
+		fileerrors, isgpg, err = sw.checkCommits(ctx, req.AllowPgpSignature)
 		if err != nil {
 			return nil, err
 		}
@@ -66,7 +91,7 @@ func (s *Svc) CommitsFromSubRepo(ctx context.Context, req *CommitsFromSubRepoReq
 			return nil, fmt.Errorf("failed to decode id: %w", err)
 		}
 		if err := s.db.Update(func(tx *bbolt.Tx) error {
-			return putRepoSyncFunc(id, sw.db)(tx)
+			return sw.putFunc(id)(tx)
 		}); err != nil {
 			return nil, err
 		}
@@ -20,12 +20,81 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type RoleBinding_Role int32
+
+const (
+	RoleBinding_UNKNOWN RoleBinding_Role = 0
+	// get and list the repo syncs, the jobs, the sync events, and the audit
+	// events, and check the commits without pushing.
+	RoleBinding_READ RoleBinding_Role = 1
+	// read, and sync the new commits from the original repo to the sub repo.
+	RoleBinding_SYNC RoleBinding_Role = 2
+	// read, and push the commits from the sub repo or the patches to the
+	// original repo.
+	RoleBinding_CONTRIBUTE RoleBinding_Role = 3
+	// everything, including creating, updating, and deleting the repo syncs,
+	// force pushes, overriding the branches, and reading the secrets.
+	RoleBinding_ADMIN RoleBinding_Role = 4
+)
+
+// Enum value maps for RoleBinding_Role.
+var (
+	RoleBinding_Role_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "READ",
+		2: "SYNC",
+		3: "CONTRIBUTE",
+		4: "ADMIN",
+	}
+	RoleBinding_Role_value = map[string]int32{
+		"UNKNOWN":    0,
+		"READ":       1,
+		"SYNC":       2,
+		"CONTRIBUTE": 3,
+		"ADMIN":      4,
+	}
+)
+
+func (x RoleBinding_Role) Enum() *RoleBinding_Role {
+	p := new(RoleBinding_Role)
+	*p = x
+	return p
+}
+
+func (x RoleBinding_Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoleBinding_Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_config_proto_enumTypes[0].Descriptor()
+}
+
+func (RoleBinding_Role) Type() protoreflect.EnumType {
+	return &file_config_proto_enumTypes[0]
+}
+
+func (x RoleBinding_Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoleBinding_Role.Descriptor instead.
+func (RoleBinding_Role) EnumDescriptor() ([]byte, []int) {
+	return file_config_proto_rawDescGZIP(), []int{6, 0}
+}
+
 type RemoteConfig_RemoteType int32
 
 const (
 	RemoteConfig_UNKNOWN RemoteConfig_RemoteType = 0
 	RemoteConfig_GITEA   RemoteConfig_RemoteType = 1
 	RemoteConfig_GITHUB  RemoteConfig_RemoteType = 2
+	// LOCAL remotes are bare repos on disk. remote_url is a directory or a
+	// file:// url, and the repos are at {owner}/{repo} or {owner}/{repo}.git
+	// under it. No auth can be configured.
+	RemoteConfig_LOCAL  RemoteConfig_RemoteType = 3
+	RemoteConfig_GITLAB RemoteConfig_RemoteType = 4
+	// GENERIC remotes are plain git servers without a forge api.
+	RemoteConfig_GENERIC RemoteConfig_RemoteType = 5
 )
 
 // Enum value maps for RemoteConfig_RemoteType.
@@ -34,11 +103,17 @@ var (
 		0: "UNKNOWN",
 		1: "GITEA",
 		2: "GITHUB",
+		3: "LOCAL",
+		4: "GITLAB",
+		5: "GENERIC",
 	}
 	RemoteConfig_RemoteType_value = map[string]int32{
 		"UNKNOWN": 0,
 		"GITEA":   1,
 		"GITHUB":  2,
+		"LOCAL":   3,
+		"GITLAB":  4,
+		"GENERIC": 5,
 	}
 )
 
@@ -53,11 +128,11 @@ func (x RemoteConfig_RemoteType) String() string {
 }
 
 func (RemoteConfig_RemoteType) Descriptor() protoreflect.EnumDescriptor {
-	return file_config_proto_enumTypes[0].Descriptor()
+	return file_config_proto_enumTypes[1].Descriptor()
 }
 
 func (RemoteConfig_RemoteType) Type() protoreflect.EnumType {
-	return &file_config_proto_enumTypes[0]
+	return &file_config_proto_enumTypes[1]
 }
 
 func (x RemoteConfig_RemoteType) Number() protoreflect.EnumNumber {
@@ -66,7 +141,7 @@ func (x RemoteConfig_RemoteType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RemoteConfig_RemoteType.Descriptor instead.
 func (RemoteConfig_RemoteType) EnumDescriptor() ([]byte, []int) {
-	return file_config_proto_rawDescGZIP(), []int{1, 0}
+	return file_config_proto_rawDescGZIP(), []int{7, 0}
 }
 
 // GiTrimConfig contains the configurations for GiTrim service.
@@ -76,12 +151,84 @@ type GiTrimConfig struct {
 	unknownFields protoimpl.UnknownFields
 
 	// All information for GitTrim service is contained in a badger db.
-	DbPath           string                   `protobuf:"bytes,1,opt,name=db_path,json=dbPath,proto3" json:"db_path,omitempty"`
-	Remotes          map[string]*RemoteConfig `protobuf:"bytes,11,rep,name=remotes,proto3" json:"remotes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	AdminAddress     string                   `protobuf:"bytes,21,opt,name=admin_address,json=adminAddress,proto3" json:"admin_address,omitempty"`
-	WebhookAddress   string                   `protobuf:"bytes,22,opt,name=webhook_address,json=webhookAddress,proto3" json:"webhook_address,omitempty"`
-	ShutdownWaitSecs int32                    `protobuf:"varint,23,opt,name=shutdown_wait_secs,json=shutdownWaitSecs,proto3" json:"shutdown_wait_secs,omitempty"`
-	AesKey           string                   `protobuf:"bytes,31,opt,name=aes_key,json=aesKey,proto3" json:"aes_key,omitempty"`
+	DbPath string `protobuf:"bytes,1,opt,name=db_path,json=dbPath,proto3" json:"db_path,omitempty"`
+	// cache_dir is the directory to keep a bare repo for each remote repo, so
+	// the objects are fetched incrementally. Empty disables the cache and the
+	// branches are fetched into memory for each operation.
+	CacheDir string `protobuf:"bytes,2,opt,name=cache_dir,json=cacheDir,proto3" json:"cache_dir,omitempty"`
+	// cache_max_bytes is the limit of the total size of cache_dir. The least
+	// recently fetched repos are deleted when the limit is exceeded. Zero means
+	// no limit.
+	CacheMaxBytes int64 `protobuf:"varint,3,opt,name=cache_max_bytes,json=cacheMaxBytes,proto3" json:"cache_max_bytes,omitempty"`
+	// db_backup_dir is the directory of the copy of the db taken before the db
+	// is migrated to a new schema version. Empty means the directory of
+	// db_path.
+	DbBackupDir    string                   `protobuf:"bytes,4,opt,name=db_backup_dir,json=dbBackupDir,proto3" json:"db_backup_dir,omitempty"`
+	Remotes        map[string]*RemoteConfig `protobuf:"bytes,11,rep,name=remotes,proto3" json:"remotes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	AdminAddress   string                   `protobuf:"bytes,21,opt,name=admin_address,json=adminAddress,proto3" json:"admin_address,omitempty"`
+	WebhookAddress string                   `protobuf:"bytes,22,opt,name=webhook_address,json=webhookAddress,proto3" json:"webhook_address,omitempty"`
+	// webhook_public_url is the url the forges deliver the webhooks to, like
+	// https://gitrim.example.com. Empty means http://{webhook_address}, or
+	// https://{webhook_address} with webhook_tls.
+	WebhookPublicUrl string `protobuf:"bytes,24,opt,name=webhook_public_url,json=webhookPublicUrl,proto3" json:"webhook_public_url,omitempty"`
+	// metrics_address serves the prometheus metrics at /metrics. Empty disables
+	// the metrics.
+	MetricsAddress string `protobuf:"bytes,25,opt,name=metrics_address,json=metricsAddress,proto3" json:"metrics_address,omitempty"`
+	// admin_tls serves the gRPC API on admin_address with TLS. Empty serves it
+	// in plain text.
+	AdminTls *TlsConfig `protobuf:"bytes,26,opt,name=admin_tls,json=adminTls,proto3" json:"admin_tls,omitempty"`
+	// webhook_tls serves the webhooks on webhook_address with TLS. Empty serves
+	// them in plain text.
+	WebhookTls       *TlsConfig `protobuf:"bytes,27,opt,name=webhook_tls,json=webhookTls,proto3" json:"webhook_tls,omitempty"`
+	ShutdownWaitSecs int32      `protobuf:"varint,23,opt,name=shutdown_wait_secs,json=shutdownWaitSecs,proto3" json:"shutdown_wait_secs,omitempty"`
+	// poll_interval_secs is the interval the background scheduler polls each
+	// repo sync, and syncs the new commits of from repo to to repo. Zero
+	// disables the scheduler.
+	PollIntervalSecs int32 `protobuf:"varint,41,opt,name=poll_interval_secs,json=pollIntervalSecs,proto3" json:"poll_interval_secs,omitempty"`
+	// poll_jitter_secs is the max random delay added to each poll, so the polls
+	// are spread out. Zero means 1/10 of poll_interval_secs.
+	PollJitterSecs int32 `protobuf:"varint,42,opt,name=poll_jitter_secs,json=pollJitterSecs,proto3" json:"poll_jitter_secs,omitempty"`
+	// poll_concurrency is the max number of polls running at the same time.
+	// Zero means 4.
+	PollConcurrency int32 `protobuf:"varint,43,opt,name=poll_concurrency,json=pollConcurrency,proto3" json:"poll_concurrency,omitempty"`
+	// poll_max_backoff_secs is the max interval between polls of a repo sync
+	// that keeps failing. The interval doubles on each failure. Zero means 24
+	// times poll_interval_secs.
+	PollMaxBackoffSecs int32 `protobuf:"varint,44,opt,name=poll_max_backoff_secs,json=pollMaxBackoffSecs,proto3" json:"poll_max_backoff_secs,omitempty"`
+	// job_workers is the number of workers running the queued jobs. Zero means
+	// 2.
+	JobWorkers int32 `protobuf:"varint,51,opt,name=job_workers,json=jobWorkers,proto3" json:"job_workers,omitempty"`
+	// job_max_attempts is the max number of times a job runs when it keeps
+	// failing to fetch or push. Zero means 5.
+	JobMaxAttempts int32 `protobuf:"varint,52,opt,name=job_max_attempts,json=jobMaxAttempts,proto3" json:"job_max_attempts,omitempty"`
+	// job_retry_secs is the delay before the first retry of a job, and the
+	// delay doubles on each retry. Zero means 10.
+	JobRetrySecs int32 `protobuf:"varint,53,opt,name=job_retry_secs,json=jobRetrySecs,proto3" json:"job_retry_secs,omitempty"`
+	// job_max_retry_secs is the max delay between the retries of a job. Zero
+	// means 600.
+	JobMaxRetrySecs int32 `protobuf:"varint,54,opt,name=job_max_retry_secs,json=jobMaxRetrySecs,proto3" json:"job_max_retry_secs,omitempty"`
+	// job_retention_secs is how long the finished jobs are kept. Zero means 7
+	// days.
+	JobRetentionSecs int32 `protobuf:"varint,55,opt,name=job_retention_secs,json=jobRetentionSecs,proto3" json:"job_retention_secs,omitempty"`
+	// max_sync_events is the number of the most recent sync events kept in the
+	// db for WatchSyncEvents to resume from. Zero means 10000.
+	MaxSyncEvents int32 `protobuf:"varint,61,opt,name=max_sync_events,json=maxSyncEvents,proto3" json:"max_sync_events,omitempty"`
+	// auth enables the authentication and the authorization of the gRPC API.
+	// Without auth, every caller is allowed to call every rpc.
+	Auth *AuthConfig `protobuf:"bytes,71,opt,name=auth,proto3" json:"auth,omitempty"`
+	// aes_key is the hex of the 16 bytes AES key sealing the secrets, with key
+	// id 0. Empty means the all-zero key, unless aes_keys are set.
+	AesKey string `protobuf:"bytes,31,opt,name=aes_key,json=aesKey,proto3" json:"aes_key,omitempty"`
+	// aes_keys is the keyring of the AES keys. The secrets embed the id of the
+	// key sealing them, so the old keys are kept in the keyring until the
+	// secrets sealed by them are rotated.
+	AesKeys []*AesKey `protobuf:"bytes,32,rep,name=aes_keys,json=aesKeys,proto3" json:"aes_keys,omitempty"`
+	// active_aes_key_id is the id of the key sealing the new secrets. Zero
+	// means the largest id in aes_keys, or aes_key without aes_keys.
+	ActiveAesKeyId uint32 `protobuf:"varint,33,opt,name=active_aes_key_id,json=activeAesKeyId,proto3" json:"active_aes_key_id,omitempty"`
+	// require_aes_key refuses to start if the active key is the all-zero key,
+	// including when both aes_key and aes_keys are empty.
+	RequireAesKey bool `protobuf:"varint,34,opt,name=require_aes_key,json=requireAesKey,proto3" json:"require_aes_key,omitempty"`
 }
 
 func (x *GiTrimConfig) Reset() {
@@ -123,6 +270,27 @@ func (x *GiTrimConfig) GetDbPath() string {
 	return ""
 }
 
+func (x *GiTrimConfig) GetCacheDir() string {
+	if x != nil {
+		return x.CacheDir
+	}
+	return ""
+}
+
+func (x *GiTrimConfig) GetCacheMaxBytes() int64 {
+	if x != nil {
+		return x.CacheMaxBytes
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetDbBackupDir() string {
+	if x != nil {
+		return x.DbBackupDir
+	}
+	return ""
+}
+
 func (x *GiTrimConfig) GetRemotes() map[string]*RemoteConfig {
 	if x != nil {
 		return x.Remotes
@@ -144,6 +312,34 @@ func (x *GiTrimConfig) GetWebhookAddress() string {
 	return ""
 }
 
+func (x *GiTrimConfig) GetWebhookPublicUrl() string {
+	if x != nil {
+		return x.WebhookPublicUrl
+	}
+	return ""
+}
+
+func (x *GiTrimConfig) GetMetricsAddress() string {
+	if x != nil {
+		return x.MetricsAddress
+	}
+	return ""
+}
+
+func (x *GiTrimConfig) GetAdminTls() *TlsConfig {
+	if x != nil {
+		return x.AdminTls
+	}
+	return nil
+}
+
+func (x *GiTrimConfig) GetWebhookTls() *TlsConfig {
+	if x != nil {
+		return x.WebhookTls
+	}
+	return nil
+}
+
 func (x *GiTrimConfig) GetShutdownWaitSecs() int32 {
 	if x != nil {
 		return x.ShutdownWaitSecs
@@ -151,6 +347,83 @@ func (x *GiTrimConfig) GetShutdownWaitSecs() int32 {
 	return 0
 }
 
+func (x *GiTrimConfig) GetPollIntervalSecs() int32 {
+	if x != nil {
+		return x.PollIntervalSecs
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetPollJitterSecs() int32 {
+	if x != nil {
+		return x.PollJitterSecs
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetPollConcurrency() int32 {
+	if x != nil {
+		return x.PollConcurrency
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetPollMaxBackoffSecs() int32 {
+	if x != nil {
+		return x.PollMaxBackoffSecs
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetJobWorkers() int32 {
+	if x != nil {
+		return x.JobWorkers
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetJobMaxAttempts() int32 {
+	if x != nil {
+		return x.JobMaxAttempts
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetJobRetrySecs() int32 {
+	if x != nil {
+		return x.JobRetrySecs
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetJobMaxRetrySecs() int32 {
+	if x != nil {
+		return x.JobMaxRetrySecs
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetJobRetentionSecs() int32 {
+	if x != nil {
+		return x.JobRetentionSecs
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetMaxSyncEvents() int32 {
+	if x != nil {
+		return x.MaxSyncEvents
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetAuth() *AuthConfig {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
 func (x *GiTrimConfig) GetAesKey() string {
 	if x != nil {
 		return x.AesKey
@@ -158,6 +431,446 @@ func (x *GiTrimConfig) GetAesKey() string {
 	return ""
 }
 
+func (x *GiTrimConfig) GetAesKeys() []*AesKey {
+	if x != nil {
+		return x.AesKeys
+	}
+	return nil
+}
+
+func (x *GiTrimConfig) GetActiveAesKeyId() uint32 {
+	if x != nil {
+		return x.ActiveAesKeyId
+	}
+	return 0
+}
+
+func (x *GiTrimConfig) GetRequireAesKey() bool {
+	if x != nil {
+		return x.RequireAesKey
+	}
+	return false
+}
+
+type AesKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id must be positive, and is never reused for a different key.
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// hex of the 16 bytes key.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *AesKey) Reset() {
+	*x = AesKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AesKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AesKey) ProtoMessage() {}
+
+func (x *AesKey) ProtoReflect() protoreflect.Message {
+	mi := &file_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AesKey.ProtoReflect.Descriptor instead.
+func (*AesKey) Descriptor() ([]byte, []int) {
+	return file_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AesKey) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AesKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// TlsConfig contains the certificate of a listener. The files are reloaded
+// when they change, so the renewed certificates are used for the new
+// connections without a restart.
+type TlsConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// PEM files of the certificate chain and the private key.
+	CertFile string `protobuf:"bytes,1,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	KeyFile  string `protobuf:"bytes,2,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	// client_ca_file is the PEM file of the CAs verifying the client
+	// certificates. Empty doesn't ask for client certificates.
+	ClientCaFile string `protobuf:"bytes,3,opt,name=client_ca_file,json=clientCaFile,proto3" json:"client_ca_file,omitempty"`
+	// require_client_cert rejects the connections without a client certificate
+	// verified by client_ca_file. Otherwise the client certificates are
+	// optional, and the callers may use the tokens instead.
+	RequireClientCert bool `protobuf:"varint,4,opt,name=require_client_cert,json=requireClientCert,proto3" json:"require_client_cert,omitempty"`
+	// min_version is the minimum TLS version, "1.2" or "1.3". Empty means
+	// "1.2".
+	MinVersion string `protobuf:"bytes,5,opt,name=min_version,json=minVersion,proto3" json:"min_version,omitempty"`
+}
+
+func (x *TlsConfig) Reset() {
+	*x = TlsConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TlsConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TlsConfig) ProtoMessage() {}
+
+func (x *TlsConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_config_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TlsConfig.ProtoReflect.Descriptor instead.
+func (*TlsConfig) Descriptor() ([]byte, []int) {
+	return file_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TlsConfig) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *TlsConfig) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+func (x *TlsConfig) GetClientCaFile() string {
+	if x != nil {
+		return x.ClientCaFile
+	}
+	return ""
+}
+
+func (x *TlsConfig) GetRequireClientCert() bool {
+	if x != nil {
+		return x.RequireClientCert
+	}
+	return false
+}
+
+func (x *TlsConfig) GetMinVersion() string {
+	if x != nil {
+		return x.MinVersion
+	}
+	return ""
+}
+
+// AuthConfig contains the identities of the callers of the gRPC API, and the
+// roles granted to them. The callers without a known identity are rejected
+// with UNAUTHENTICATED, and the callers without the role needed for an rpc are
+// rejected with PERMISSION_DENIED. The health service is not authenticated.
+type AuthConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tokens sent as "authorization: Bearer {token}".
+	Tokens []*TokenIdentity `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	// client certificates verified against client_ca_file of admin_tls.
+	ClientCerts  []*ClientCertIdentity `protobuf:"bytes,2,rep,name=client_certs,json=clientCerts,proto3" json:"client_certs,omitempty"`
+	RoleBindings []*RoleBinding        `protobuf:"bytes,11,rep,name=role_bindings,json=roleBindings,proto3" json:"role_bindings,omitempty"`
+}
+
+func (x *AuthConfig) Reset() {
+	*x = AuthConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthConfig) ProtoMessage() {}
+
+func (x *AuthConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_config_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthConfig.ProtoReflect.Descriptor instead.
+func (*AuthConfig) Descriptor() ([]byte, []int) {
+	return file_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AuthConfig) GetTokens() []*TokenIdentity {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *AuthConfig) GetClientCerts() []*ClientCertIdentity {
+	if x != nil {
+		return x.ClientCerts
+	}
+	return nil
+}
+
+func (x *AuthConfig) GetRoleBindings() []*RoleBinding {
+	if x != nil {
+		return x.RoleBindings
+	}
+	return nil
+}
+
+type TokenIdentity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// either the token, or the hex of the sha256 of the token.
+	Token       string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	TokenSha256 string `protobuf:"bytes,3,opt,name=token_sha256,json=tokenSha256,proto3" json:"token_sha256,omitempty"`
+}
+
+func (x *TokenIdentity) Reset() {
+	*x = TokenIdentity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TokenIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenIdentity) ProtoMessage() {}
+
+func (x *TokenIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_config_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenIdentity.ProtoReflect.Descriptor instead.
+func (*TokenIdentity) Descriptor() ([]byte, []int) {
+	return file_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TokenIdentity) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *TokenIdentity) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TokenIdentity) GetTokenSha256() string {
+	if x != nil {
+		return x.TokenSha256
+	}
+	return ""
+}
+
+type ClientCertIdentity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identity string `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// the certificate maps to the identity if any of the set fields matches the
+	// subject common name, or the dns or uri subject alternative names.
+	CommonName string `protobuf:"bytes,2,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	DnsName    string `protobuf:"bytes,3,opt,name=dns_name,json=dnsName,proto3" json:"dns_name,omitempty"`
+	Uri        string `protobuf:"bytes,4,opt,name=uri,proto3" json:"uri,omitempty"`
+}
+
+func (x *ClientCertIdentity) Reset() {
+	*x = ClientCertIdentity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientCertIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientCertIdentity) ProtoMessage() {}
+
+func (x *ClientCertIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_config_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientCertIdentity.ProtoReflect.Descriptor instead.
+func (*ClientCertIdentity) Descriptor() ([]byte, []int) {
+	return file_config_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ClientCertIdentity) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *ClientCertIdentity) GetCommonName() string {
+	if x != nil {
+		return x.CommonName
+	}
+	return ""
+}
+
+func (x *ClientCertIdentity) GetDnsName() string {
+	if x != nil {
+		return x.DnsName
+	}
+	return ""
+}
+
+func (x *ClientCertIdentity) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// RoleBinding grants a role to the identities.
+type RoleBinding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identities []string         `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+	Role       RoleBinding_Role `protobuf:"varint,2,opt,name=role,proto3,enum=gitrim.svc.RoleBinding_Role" json:"role,omitempty"`
+	// the repo syncs the role is granted on, all the repo syncs if empty.
+	// Creating a repo sync needs ADMIN on its id.
+	RepoSyncIds []string `protobuf:"bytes,3,rep,name=repo_sync_ids,json=repoSyncIds,proto3" json:"repo_sync_ids,omitempty"`
+}
+
+func (x *RoleBinding) Reset() {
+	*x = RoleBinding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_config_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoleBinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleBinding) ProtoMessage() {}
+
+func (x *RoleBinding) ProtoReflect() protoreflect.Message {
+	mi := &file_config_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleBinding.ProtoReflect.Descriptor instead.
+func (*RoleBinding) Descriptor() ([]byte, []int) {
+	return file_config_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RoleBinding) GetIdentities() []string {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+func (x *RoleBinding) GetRole() RoleBinding_Role {
+	if x != nil {
+		return x.Role
+	}
+	return RoleBinding_UNKNOWN
+}
+
+func (x *RoleBinding) GetRepoSyncIds() []string {
+	if x != nil {
+		return x.RepoSyncIds
+	}
+	return nil
+}
+
 type RemoteConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -166,14 +879,38 @@ type RemoteConfig struct {
 	RemoteName string                  `protobuf:"bytes,1,opt,name=remote_name,json=remoteName,proto3" json:"remote_name,omitempty"`
 	RemoteUrl  string                  `protobuf:"bytes,2,opt,name=remote_url,json=remoteUrl,proto3" json:"remote_url,omitempty"`
 	RemoteType RemoteConfig_RemoteType `protobuf:"varint,3,opt,name=remote_type,json=remoteType,proto3,enum=gitrim.svc.RemoteConfig_RemoteType" json:"remote_type,omitempty"`
-	Secret     string                  `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
-	Username   string                  `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+	// secret is the password for http basic auth with username.
+	Secret string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	// username for http basic auth, or the user for ssh, which defaults to git.
+	Username string `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+	// url_template constructs the url of a repo on the remote. {remote_url},
+	// {owner}, and {repo} are replaced by the remote_url, the owner, and the
+	// name of the repo. For example, ssh://git@example.com:2222/{owner}/{repo}.git
+	// Empty means {remote_url}/{owner}/{repo}.
+	UrlTemplate string `protobuf:"bytes,6,opt,name=url_template,json=urlTemplate,proto3" json:"url_template,omitempty"`
+	// bearer_token is sent in the Authorization header of http requests.
+	BearerToken string `protobuf:"bytes,11,opt,name=bearer_token,json=bearerToken,proto3" json:"bearer_token,omitempty"`
+	// ssh_key_path is the path to the private key for ssh.
+	SshKeyPath string `protobuf:"bytes,21,opt,name=ssh_key_path,json=sshKeyPath,proto3" json:"ssh_key_path,omitempty"`
+	// ssh_key_passphrase decrypts the private key if it is encrypted.
+	SshKeyPassphrase string `protobuf:"bytes,22,opt,name=ssh_key_passphrase,json=sshKeyPassphrase,proto3" json:"ssh_key_passphrase,omitempty"`
+	// ssh_known_hosts_path is the known_hosts file to verify the host keys.
+	// Empty means the files in SSH_KNOWN_HOSTS, or ~/.ssh/known_hosts.
+	SshKnownHostsPath string `protobuf:"bytes,23,opt,name=ssh_known_hosts_path,json=sshKnownHostsPath,proto3" json:"ssh_known_hosts_path,omitempty"`
+	// api_url is the base url of the forge api for GITHUB, GITEA, and GITLAB
+	// remotes. Empty means https://api.github.com for https://github.com,
+	// {remote_url}/api/v3 for other GITHUB remotes, {remote_url}/api/v1 for
+	// GITEA, and {remote_url}/api/v4 for GITLAB.
+	ApiUrl string `protobuf:"bytes,31,opt,name=api_url,json=apiUrl,proto3" json:"api_url,omitempty"`
+	// api_token authenticates the api requests. Empty means bearer_token, or
+	// secret if bearer_token is empty.
+	ApiToken string `protobuf:"bytes,32,opt,name=api_token,json=apiToken,proto3" json:"api_token,omitempty"`
 }
 
 func (x *RemoteConfig) Reset() {
 	*x = RemoteConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_config_proto_msgTypes[1]
+		mi := &file_config_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -186,7 +923,7 @@ func (x *RemoteConfig) String() string {
 func (*RemoteConfig) ProtoMessage() {}
 
 func (x *RemoteConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_config_proto_msgTypes[1]
+	mi := &file_config_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -199,7 +936,7 @@ func (x *RemoteConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoteConfig.ProtoReflect.Descriptor instead.
 func (*RemoteConfig) Descriptor() ([]byte, []int) {
-	return file_config_proto_rawDescGZIP(), []int{1}
+	return file_config_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RemoteConfig) GetRemoteName() string {
@@ -237,33 +974,192 @@ func (x *RemoteConfig) GetUsername() string {
 	return ""
 }
 
+func (x *RemoteConfig) GetUrlTemplate() string {
+	if x != nil {
+		return x.UrlTemplate
+	}
+	return ""
+}
+
+func (x *RemoteConfig) GetBearerToken() string {
+	if x != nil {
+		return x.BearerToken
+	}
+	return ""
+}
+
+func (x *RemoteConfig) GetSshKeyPath() string {
+	if x != nil {
+		return x.SshKeyPath
+	}
+	return ""
+}
+
+func (x *RemoteConfig) GetSshKeyPassphrase() string {
+	if x != nil {
+		return x.SshKeyPassphrase
+	}
+	return ""
+}
+
+func (x *RemoteConfig) GetSshKnownHostsPath() string {
+	if x != nil {
+		return x.SshKnownHostsPath
+	}
+	return ""
+}
+
+func (x *RemoteConfig) GetApiUrl() string {
+	if x != nil {
+		return x.ApiUrl
+	}
+	return ""
+}
+
+func (x *RemoteConfig) GetApiToken() string {
+	if x != nil {
+		return x.ApiToken
+	}
+	return ""
+}
+
 var File_config_proto protoreflect.FileDescriptor
 
 var file_config_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a,
-	0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x22, 0xd3, 0x02, 0x0a, 0x0c, 0x47,
+	0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x22, 0xd7, 0x09, 0x0a, 0x0c, 0x47,
 	0x69, 0x54, 0x72, 0x69, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x64,
 	0x62, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x62,
-	0x50, 0x61, 0x74, 0x68, 0x12, 0x3f, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x18,
-	0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73,
-	0x76, 0x63, 0x2e, 0x47, 0x69, 0x54, 0x72, 0x69, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
-	0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65,
-	0x6d, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x64,
-	0x6d, 0x69, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x77, 0x65,
-	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x16, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0e, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x41, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x5f,
-	0x77, 0x61, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x10, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x57, 0x61, 0x69, 0x74, 0x53, 0x65, 0x63,
-	0x73, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x65, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x1f, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x61, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x1a, 0x54, 0x0a, 0x0c, 0x52, 0x65,
-	0x6d, 0x6f, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x69,
-	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0xfa, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x64, 0x69,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x63, 0x68, 0x65, 0x44, 0x69,
+	0x72, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x4d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x64, 0x62, 0x5f,
+	0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x62, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x12, 0x3f, 0x0a,
+	0x07, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25,
+	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x54, 0x72,
+	0x69, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x77, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x12,
+	0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x75,
+	0x72, 0x6c, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x55, 0x72, 0x6c, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x19, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x6c, 0x73,
+	0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
+	0x73, 0x76, 0x63, 0x2e, 0x54, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x08, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x54, 0x6c, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x77, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x5f, 0x74, 0x6c, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67,
+	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x54, 0x6c, 0x73, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x0a, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x54, 0x6c, 0x73, 0x12,
+	0x2c, 0x0a, 0x12, 0x73, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x77, 0x61, 0x69, 0x74,
+	0x5f, 0x73, 0x65, 0x63, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x73, 0x68, 0x75,
+	0x74, 0x64, 0x6f, 0x77, 0x6e, 0x57, 0x61, 0x69, 0x74, 0x53, 0x65, 0x63, 0x73, 0x12, 0x2c, 0x0a,
+	0x12, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73,
+	0x65, 0x63, 0x73, 0x18, 0x29, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x70, 0x6f, 0x6c, 0x6c, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x70,
+	0x6f, 0x6c, 0x6c, 0x5f, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x73, 0x18,
+	0x2a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x6f, 0x6c, 0x6c, 0x4a, 0x69, 0x74, 0x74, 0x65,
+	0x72, 0x53, 0x65, 0x63, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x63, 0x6f,
+	0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0f, 0x70, 0x6f, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x12, 0x31, 0x0a, 0x15, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63,
+	0x6b, 0x6f, 0x66, 0x66, 0x5f, 0x73, 0x65, 0x63, 0x73, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x12, 0x70, 0x6f, 0x6c, 0x6c, 0x4d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x53,
+	0x65, 0x63, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6a, 0x6f, 0x62, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x73, 0x18, 0x33, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6a, 0x6f, 0x62, 0x57, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6a, 0x6f, 0x62, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
+	0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x34, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x6a, 0x6f, 0x62, 0x4d, 0x61, 0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x24,
+	0x0a, 0x0e, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x73,
+	0x18, 0x35, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6a, 0x6f, 0x62, 0x52, 0x65, 0x74, 0x72, 0x79,
+	0x53, 0x65, 0x63, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x6a, 0x6f, 0x62, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
+	0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x73, 0x18, 0x36, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0f, 0x6a, 0x6f, 0x62, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x79, 0x53, 0x65, 0x63,
+	0x73, 0x12, 0x2c, 0x0a, 0x12, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x73, 0x18, 0x37, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x6a,
+	0x6f, 0x62, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x3d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x53, 0x79, 0x6e,
+	0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18,
+	0x47, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73,
+	0x76, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x04, 0x61,
+	0x75, 0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x65, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x1f,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x08,
+	0x61, 0x65, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x65, 0x73, 0x4b,
+	0x65, 0x79, 0x52, 0x07, 0x61, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x29, 0x0a, 0x11, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x61, 0x65, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64,
+	0x18, 0x21, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x41, 0x65,
+	0x73, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x5f, 0x61, 0x65, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x22, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x41, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x1a, 0x54,
+	0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x2a, 0x0a, 0x06, 0x41, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x22, 0xba, 0x01, 0x0a, 0x09, 0x54, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x65, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b,
+	0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b,
+	0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x63, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x13,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63,
+	0x65, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xc0, 0x01,
+	0x0a, 0x0a, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x31, 0x0a, 0x06,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12,
+	0x41, 0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73,
+	0x76, 0x63, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72,
+	0x74, 0x73, 0x12, 0x3c, 0x0a, 0x0d, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x62, 0x69, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x69, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x52, 0x0c, 0x72, 0x6f, 0x6c, 0x65, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x22, 0x64, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x73, 0x68, 0x61,
+	0x32, 0x35, 0x36, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x22, 0x7e, 0x0a, 0x12, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x43, 0x65, 0x72, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x6e, 0x73,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x6e, 0x73,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x22, 0xc7, 0x01, 0x0a, 0x0b, 0x52, 0x6f, 0x6c, 0x65, 0x42,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x6f,
+	0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6f,
+	0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x72, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x64, 0x73, 0x22, 0x42, 0x0a, 0x04,
+	0x52, 0x6f, 0x6c, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x45, 0x41, 0x44, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53,
+	0x59, 0x4e, 0x43, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x49, 0x42,
+	0x55, 0x54, 0x45, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x44, 0x4d, 0x49, 0x4e, 0x10, 0x04,
+	0x22, 0x9b, 0x04, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
 	0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4e, 0x61,
 	0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x75, 0x72, 0x6c,
@@ -275,13 +1171,31 @@ var file_config_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
 	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12,
 	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x30, 0x0a, 0x0a, 0x52,
-	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b,
-	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x49, 0x54, 0x45, 0x41, 0x10,
-	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x49, 0x54, 0x48, 0x55, 0x42, 0x10, 0x02, 0x42, 0x20, 0x5a,
-	0x1e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x72, 0x64,
-	0x72, 0x65, 0x61, 0x6d, 0x2f, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2f, 0x73, 0x76, 0x63, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x75,
+	0x72, 0x6c, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x75, 0x72, 0x6c, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x70,
+	0x61, 0x73, 0x73, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x73, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x73, 0x73, 0x70, 0x68, 0x72, 0x61, 0x73,
+	0x65, 0x12, 0x2f, 0x0a, 0x14, 0x73, 0x73, 0x68, 0x5f, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x68,
+	0x6f, 0x73, 0x74, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x73, 0x73, 0x68, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x70, 0x69, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x1f, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x70, 0x69, 0x55, 0x72, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x61,
+	0x70, 0x69, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x61, 0x70, 0x69, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x54, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x49, 0x54, 0x45, 0x41, 0x10, 0x01, 0x12, 0x0a,
+	0x0a, 0x06, 0x47, 0x49, 0x54, 0x48, 0x55, 0x42, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x4f,
+	0x43, 0x41, 0x4c, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x49, 0x54, 0x4c, 0x41, 0x42, 0x10,
+	0x04, 0x12, 0x0b, 0x0a, 0x07, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x49, 0x43, 0x10, 0x05, 0x42, 0x20,
+	0x5a, 0x1e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x72,
+	0x64, 0x72, 0x65, 0x61, 0x6d, 0x2f, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2f, 0x73, 0x76, 0x63,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -296,23 +1210,38 @@ func file_config_proto_rawDescGZIP() []byte {
 	return file_config_proto_rawDescData
 }
 
-var file_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_config_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_config_proto_goTypes = []interface{}{
-	(RemoteConfig_RemoteType)(0), // 0: gitrim.svc.RemoteConfig.RemoteType
-	(*GiTrimConfig)(nil),         // 1: gitrim.svc.GiTrimConfig
-	(*RemoteConfig)(nil),         // 2: gitrim.svc.RemoteConfig
-	nil,                          // 3: gitrim.svc.GiTrimConfig.RemotesEntry
+	(RoleBinding_Role)(0),        // 0: gitrim.svc.RoleBinding.Role
+	(RemoteConfig_RemoteType)(0), // 1: gitrim.svc.RemoteConfig.RemoteType
+	(*GiTrimConfig)(nil),         // 2: gitrim.svc.GiTrimConfig
+	(*AesKey)(nil),               // 3: gitrim.svc.AesKey
+	(*TlsConfig)(nil),            // 4: gitrim.svc.TlsConfig
+	(*AuthConfig)(nil),           // 5: gitrim.svc.AuthConfig
+	(*TokenIdentity)(nil),        // 6: gitrim.svc.TokenIdentity
+	(*ClientCertIdentity)(nil),   // 7: gitrim.svc.ClientCertIdentity
+	(*RoleBinding)(nil),          // 8: gitrim.svc.RoleBinding
+	(*RemoteConfig)(nil),         // 9: gitrim.svc.RemoteConfig
+	nil,                          // 10: gitrim.svc.GiTrimConfig.RemotesEntry
 }
 var file_config_proto_depIdxs = []int32{
-	3, // 0: gitrim.svc.GiTrimConfig.remotes:type_name -> gitrim.svc.GiTrimConfig.RemotesEntry
-	0, // 1: gitrim.svc.RemoteConfig.remote_type:type_name -> gitrim.svc.RemoteConfig.RemoteType
-	2, // 2: gitrim.svc.GiTrimConfig.RemotesEntry.value:type_name -> gitrim.svc.RemoteConfig
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	10, // 0: gitrim.svc.GiTrimConfig.remotes:type_name -> gitrim.svc.GiTrimConfig.RemotesEntry
+	4,  // 1: gitrim.svc.GiTrimConfig.admin_tls:type_name -> gitrim.svc.TlsConfig
+	4,  // 2: gitrim.svc.GiTrimConfig.webhook_tls:type_name -> gitrim.svc.TlsConfig
+	5,  // 3: gitrim.svc.GiTrimConfig.auth:type_name -> gitrim.svc.AuthConfig
+	3,  // 4: gitrim.svc.GiTrimConfig.aes_keys:type_name -> gitrim.svc.AesKey
+	6,  // 5: gitrim.svc.AuthConfig.tokens:type_name -> gitrim.svc.TokenIdentity
+	7,  // 6: gitrim.svc.AuthConfig.client_certs:type_name -> gitrim.svc.ClientCertIdentity
+	8,  // 7: gitrim.svc.AuthConfig.role_bindings:type_name -> gitrim.svc.RoleBinding
+	0,  // 8: gitrim.svc.RoleBinding.role:type_name -> gitrim.svc.RoleBinding.Role
+	1,  // 9: gitrim.svc.RemoteConfig.remote_type:type_name -> gitrim.svc.RemoteConfig.RemoteType
+	9,  // 10: gitrim.svc.GiTrimConfig.RemotesEntry.value:type_name -> gitrim.svc.RemoteConfig
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_config_proto_init() }
@@ -334,6 +1263,78 @@ func file_config_proto_init() {
 			}
 		}
 		file_config_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AesKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TlsConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenIdentity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientCertIdentity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoleBinding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_config_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RemoteConfig); i {
 			case 0:
 				return &v.state
@@ -351,8 +1352,8 @@ func file_config_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_config_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
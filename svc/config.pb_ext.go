@@ -10,47 +10,77 @@ import (
 var (
 	_ yaml.BytesMarshaler   = RemoteConfig_UNKNOWN
 	_ yaml.BytesUnmarshaler = (*RemoteConfig_RemoteType)(nil)
+	_ yaml.BytesMarshaler   = RoleBinding_UNKNOWN
+	_ yaml.BytesUnmarshaler = (*RoleBinding_Role)(nil)
 )
 
-func (t RemoteConfig_RemoteType) MarshalYAML() ([]byte, error) {
-	name, valid := RemoteConfig_RemoteType_name[int32(t)]
+// marshalYAMLEnum marshals the enum as its name, or the integer if it has no name.
+func marshalYAMLEnum(v int32, names map[int32]string) ([]byte, error) {
+	name, valid := names[v]
 	if !valid {
-		return yaml.Marshal(int32(t))
+		return yaml.Marshal(v)
 	}
 
 	return yaml.Marshal(name)
 }
 
-func (t *RemoteConfig_RemoteType) UnmarshalYAML(data []byte) error {
+// unmarshalYAMLEnum parses the enum from the integer or the case insensitive name.
+func unmarshalYAMLEnum(data []byte, names map[int32]string, values map[string]int32, kind string) (int32, error) {
 	var i int32
 	err := yaml.Unmarshal(data, &i)
 	// err is nil, the value is successfully parsed.
 	if err == nil {
-		_, valid := RemoteConfig_RemoteType_name[i]
+		_, valid := names[i]
 		if !valid {
-			return fmt.Errorf("integer value %d is not a valid RemoteType", i)
+			return 0, fmt.Errorf("integer value %d is not a valid %s", i, kind)
 		}
 
-		*t = RemoteConfig_RemoteType(i)
-		return nil
+		return i, nil
 	}
 
 	var s string
 	err = yaml.Unmarshal(data, &s)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal as string or int: %w", err)
+		return 0, fmt.Errorf("failed to unmarshal as string or int: %w", err)
 	}
 
 	uppedS := strings.ToUpper(s)
-	v, valid := RemoteConfig_RemoteType_value[uppedS]
+	v, valid := values[uppedS]
 	if !valid {
-		return fmt.Errorf("string %s is not a valid remote type", s)
+		return 0, fmt.Errorf("string %s is not a valid %s", s, kind)
+	}
+
+	return v, nil
+}
+
+func (t RemoteConfig_RemoteType) MarshalYAML() ([]byte, error) {
+	return marshalYAMLEnum(int32(t), RemoteConfig_RemoteType_name)
+}
+
+func (t *RemoteConfig_RemoteType) UnmarshalYAML(data []byte) error {
+	v, err := unmarshalYAMLEnum(data, RemoteConfig_RemoteType_name, RemoteConfig_RemoteType_value, "remote type")
+	if err != nil {
+		return err
 	}
 
 	*t = RemoteConfig_RemoteType(v)
 	return nil
 }
 
+func (r RoleBinding_Role) MarshalYAML() ([]byte, error) {
+	return marshalYAMLEnum(int32(r), RoleBinding_Role_name)
+}
+
+func (r *RoleBinding_Role) UnmarshalYAML(data []byte) error {
+	v, err := unmarshalYAMLEnum(data, RoleBinding_Role_name, RoleBinding_Role_value, "role")
+	if err != nil {
+		return err
+	}
+
+	*r = RoleBinding_Role(v)
+	return nil
+}
+
 func (c *GiTrimConfig) GetProperShutdownWaitSecs() int {
 	if c.ShutdownWaitSecs == 0 {
 		return 90
@@ -1,6 +1,7 @@
 package svc
 
 import (
+	"bytes"
 	"errors"
 	"os"
 
@@ -112,9 +113,16 @@ func (s *Svc) DeleteTmpDb() error {
 }
 
 const (
-	REPO_SYNC_BUCKET    = "reposyncs"
-	SECRET_TO_ID_BUCKET = "secrets-to-id"
-	ID_TO_SECRET_BUCKET = "id-to-secrets"
+	REPO_SYNC_BUCKET       = "reposyncs"
+	SECRET_TO_ID_BUCKET    = "secrets-to-id"
+	ID_TO_SECRET_BUCKET    = "id-to-secrets"
+	POLL_STATE_BUCKET      = "poll-states"
+	JOB_BUCKET             = "jobs"
+	SYNC_EVENT_BUCKET      = "sync-events"
+	AUDIT_EVENT_BUCKET     = "audit-events"
+	PREVIOUS_SECRET_BUCKET = "previous-secrets"
+	SYNC_STAT_BUCKET       = "sync-stats"
+	META_BUCKET            = "meta"
 )
 
 func putSecretFunc(id []byte, secret []byte) func(tx *bbolt.Tx) error {
@@ -177,3 +185,69 @@ func getSecretForId(db *bbolt.DB, id []byte) ([]byte, error) {
 
 	return s, nil
 }
+
+// deleteRepoSyncFunc deletes the repo sync, its poll state, its stat, and its secrets.
+func deleteRepoSyncFunc(id []byte) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		for _, bucket := range []string{REPO_SYNC_BUCKET, POLL_STATE_BUCKET, PREVIOUS_SECRET_BUCKET} {
+			if b := tx.Bucket([]byte(bucket)); b != nil {
+				if err := b.Delete(id); err != nil {
+					return err
+				}
+			}
+		}
+		if err := deleteSyncStatFunc(id)(tx); err != nil {
+			return err
+		}
+
+		idtosecretbucket := tx.Bucket([]byte(ID_TO_SECRET_BUCKET))
+		if idtosecretbucket == nil {
+			return nil
+		}
+		// the secret must be copied since the value is only valid in the transaction, and is invalid after delete.
+		secret := bytes.Clone(idtosecretbucket.Get(id))
+		if secret == nil {
+			return nil
+		}
+		if err := idtosecretbucket.Delete(id); err != nil {
+			return err
+		}
+		if b := tx.Bucket([]byte(SECRET_TO_ID_BUCKET)); b != nil {
+			return b.Delete(secret)
+		}
+
+		return nil
+	}
+}
+
+// putPollStateFunc saves the poll state, unless the repo sync no longer exists.
+func putPollStateFunc(id []byte, state *PollState) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		reposyncbucket := tx.Bucket([]byte(REPO_SYNC_BUCKET))
+		if reposyncbucket == nil || reposyncbucket.Get(id) == nil {
+			return nil
+		}
+
+		b, err := proto.Marshal(state)
+		if err != nil {
+			return err
+		}
+
+		pollstatebucket, err := tx.CreateBucketIfNotExists([]byte(POLL_STATE_BUCKET))
+		if err != nil {
+			return err
+		}
+
+		return pollstatebucket.Put(id, b)
+	}
+}
+
+func getPollStateFromDb(db *bbolt.DB, id []byte) (*PollState, error) {
+	return getFromDb(
+		db,
+		[]byte(POLL_STATE_BUCKET),
+		id,
+		func(d []byte, v *PollState) error {
+			return proto.Unmarshal(d, v)
+		})
+}
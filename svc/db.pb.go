@@ -27,6 +27,12 @@ type DbRepoSync struct {
 
 	SyncData *RepoSync `protobuf:"bytes,1,opt,name=sync_data,json=syncData,proto3" json:"sync_data,omitempty"`
 	Stat     *SyncStat `protobuf:"bytes,2,opt,name=stat,proto3" json:"stat,omitempty"`
+	// filters replaced by UpdateRepoSyncFilter, oldest first.
+	PreviousFilters []*FilterRevision `protobuf:"bytes,3,rep,name=previous_filters,json=previousFilters,proto3" json:"previous_filters,omitempty"`
+	// head of the last bundle of SyncToSubRepoBundle, the prerequisite of the
+	// next incremental bundle. It is kept apart from stat, since the sub repo
+	// is not pushed by the bundles.
+	LastBundleToCommit string `protobuf:"bytes,4,opt,name=last_bundle_to_commit,json=lastBundleToCommit,proto3" json:"last_bundle_to_commit,omitempty"`
 }
 
 func (x *DbRepoSync) Reset() {
@@ -75,21 +81,156 @@ func (x *DbRepoSync) GetStat() *SyncStat {
 	return nil
 }
 
+func (x *DbRepoSync) GetPreviousFilters() []*FilterRevision {
+	if x != nil {
+		return x.PreviousFilters
+	}
+	return nil
+}
+
+func (x *DbRepoSync) GetLastBundleToCommit() string {
+	if x != nil {
+		return x.LastBundleToCommit
+	}
+	return ""
+}
+
+// DbPreviousSecrets are the secrets of a repo sync replaced by RotateSecret,
+// which are accepted until they expire.
+type DbPreviousSecrets struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secrets []*DbPreviousSecrets_Secret `protobuf:"bytes,1,rep,name=secrets,proto3" json:"secrets,omitempty"`
+}
+
+func (x *DbPreviousSecrets) Reset() {
+	*x = DbPreviousSecrets{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DbPreviousSecrets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbPreviousSecrets) ProtoMessage() {}
+
+func (x *DbPreviousSecrets) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbPreviousSecrets.ProtoReflect.Descriptor instead.
+func (*DbPreviousSecrets) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DbPreviousSecrets) GetSecrets() []*DbPreviousSecrets_Secret {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+type DbPreviousSecrets_Secret struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secret    []byte `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *DbPreviousSecrets_Secret) Reset() {
+	*x = DbPreviousSecrets_Secret{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DbPreviousSecrets_Secret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DbPreviousSecrets_Secret) ProtoMessage() {}
+
+func (x *DbPreviousSecrets_Secret) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DbPreviousSecrets_Secret.ProtoReflect.Descriptor instead.
+func (*DbPreviousSecrets_Secret) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *DbPreviousSecrets_Secret) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *DbPreviousSecrets_Secret) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 var File_db_proto protoreflect.FileDescriptor
 
 var file_db_proto_rawDesc = []byte{
 	0x0a, 0x08, 0x64, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x67, 0x69, 0x74, 0x72,
 	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x1a, 0x09, 0x73, 0x76, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x69, 0x0a, 0x0a, 0x44, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x12,
-	0x31, 0x0a, 0x09, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
-	0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x08, 0x73, 0x79, 0x6e, 0x63, 0x44, 0x61,
-	0x74, 0x61, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x74, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79,
-	0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x52, 0x04, 0x73, 0x74, 0x61, 0x74, 0x42, 0x20, 0x5a, 0x1e,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x72, 0x64, 0x72,
-	0x65, 0x61, 0x6d, 0x2f, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2f, 0x73, 0x76, 0x63, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x22, 0xe3, 0x01, 0x0a, 0x0a, 0x44, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63,
+	0x12, 0x31, 0x0a, 0x09, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x08, 0x73, 0x79, 0x6e, 0x63, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x74, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53,
+	0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x52, 0x04, 0x73, 0x74, 0x61, 0x74, 0x12, 0x45, 0x0a,
+	0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d,
+	0x2e, 0x73, 0x76, 0x63, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x31, 0x0a, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x62, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x54,
+	0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x94, 0x01, 0x0a, 0x11, 0x44, 0x62, 0x50, 0x72,
+	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x3e, 0x0a,
+	0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x44, 0x62, 0x50, 0x72,
+	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x07, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x1a, 0x3f, 0x0a,
+	0x06, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x42, 0x20,
+	0x5a, 0x1e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x72,
+	0x64, 0x72, 0x65, 0x61, 0x6d, 0x2f, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2f, 0x73, 0x76, 0x63,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -104,20 +245,25 @@ func file_db_proto_rawDescGZIP() []byte {
 	return file_db_proto_rawDescData
 }
 
-var file_db_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_db_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_db_proto_goTypes = []interface{}{
-	(*DbRepoSync)(nil), // 0: gitrim.svc.DbRepoSync
-	(*RepoSync)(nil),   // 1: gitrim.svc.RepoSync
-	(*SyncStat)(nil),   // 2: gitrim.svc.SyncStat
+	(*DbRepoSync)(nil),               // 0: gitrim.svc.DbRepoSync
+	(*DbPreviousSecrets)(nil),        // 1: gitrim.svc.DbPreviousSecrets
+	(*DbPreviousSecrets_Secret)(nil), // 2: gitrim.svc.DbPreviousSecrets.Secret
+	(*RepoSync)(nil),                 // 3: gitrim.svc.RepoSync
+	(*SyncStat)(nil),                 // 4: gitrim.svc.SyncStat
+	(*FilterRevision)(nil),           // 5: gitrim.svc.FilterRevision
 }
 var file_db_proto_depIdxs = []int32{
-	1, // 0: gitrim.svc.DbRepoSync.sync_data:type_name -> gitrim.svc.RepoSync
-	2, // 1: gitrim.svc.DbRepoSync.stat:type_name -> gitrim.svc.SyncStat
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 0: gitrim.svc.DbRepoSync.sync_data:type_name -> gitrim.svc.RepoSync
+	4, // 1: gitrim.svc.DbRepoSync.stat:type_name -> gitrim.svc.SyncStat
+	5, // 2: gitrim.svc.DbRepoSync.previous_filters:type_name -> gitrim.svc.FilterRevision
+	2, // 3: gitrim.svc.DbPreviousSecrets.secrets:type_name -> gitrim.svc.DbPreviousSecrets.Secret
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_db_proto_init() }
@@ -139,6 +285,30 @@ func file_db_proto_init() {
 				return nil
 			}
 		}
+		file_db_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DbPreviousSecrets); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DbPreviousSecrets_Secret); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -146,7 +316,7 @@ func file_db_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_db_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,27 @@
+package svc
+
+import "context"
+
+func (s *Svc) DeleteRepoSync(ctx context.Context, req *DeleteRepoSyncRequest) (*DeleteRepoSyncResponse, error) {
+	idwaiter, err := s.lockId(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlockId(req.Id, idwaiter)
+
+	reposync, id, err := getRepoSync(s.db, req.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Update(deleteRepoSyncFunc(id)); err != nil {
+		return nil, err
+	}
+	if err := s.db.Sync(); err != nil {
+		return nil, ErrStatusDBFailure
+	}
+
+	logger.Info("deleted repo sync", "id", req.Id)
+
+	return &DeleteRepoSyncResponse{RepoSync: reposync.SyncData}, nil
+}
@@ -0,0 +1,219 @@
+package svc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultMaxSyncEvents = 10000
+	// syncEventBufferSize is the number of events buffered for a watcher, the watcher is dropped once the buffer
+	// is full.
+	syncEventBufferSize = 256
+	// syncEventReplayBatchSize is the number of stored events read in one transaction when resuming.
+	syncEventReplayBatchSize = 256
+	// maxSyncEventCommits is the max number of commits in an event.
+	maxSyncEventCommits = 100
+)
+
+var (
+	ErrStatusWatcherTooSlow = status.Error(codes.ResourceExhausted, "watcher falls too far behind, resume from the last sequence")
+	ErrStatusShuttingDown   = status.Error(codes.Unavailable, "server is shutting down")
+)
+
+// eventBus saves the sync events in the db, and sends them to the watchers.
+type eventBus struct {
+	db        *bbolt.DB
+	maxEvents int
+
+	// mu serializes the publishing, so the watchers receive the events in the order of the sequence.
+	mu       sync.Mutex
+	watchers map[*eventWatcher]struct{}
+}
+
+// eventWatcher receives the events of the repo syncs in ids, or all the events if ids is empty.
+type eventWatcher struct {
+	ids []string
+	c   chan *SyncEvent
+
+	// done is closed when the watcher is dropped from the bus, and err is the reason.
+	done chan struct{}
+	err  error
+}
+
+func newEventBus(db *bbolt.DB, maxevents int32) *eventBus {
+	if maxevents <= 0 {
+		maxevents = defaultMaxSyncEvents
+	}
+
+	return &eventBus{
+		db:        db,
+		maxEvents: int(maxevents),
+		watchers:  make(map[*eventWatcher]struct{}),
+	}
+}
+
+func syncEventKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
+
+func (w *eventWatcher) match(e *SyncEvent) bool {
+	return len(w.ids) == 0 || slices.Contains(w.ids, e.RepoSyncId)
+}
+
+// publish assigns the sequences to the events, saves them, and sends them to the watchers. Failures are logged
+// since the events must not fail the syncs.
+func (b *eventBus) publish(events ...*SyncEvent) {
+	if b == nil || len(events) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now().Unix()
+
+	if err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(SYNC_EVENT_BUCKET))
+		if err != nil {
+			return err
+		}
+
+		var last uint64
+		for _, e := range events {
+			if last, err = bucket.NextSequence(); err != nil {
+				return err
+			}
+			e.Sequence = last
+			e.CreatedAt = now
+			data, err := proto.Marshal(e)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(syncEventKey(e.Sequence), data); err != nil {
+				return err
+			}
+		}
+
+		// drop the oldest events.
+		if last <= uint64(b.maxEvents) {
+			return nil
+		}
+		oldest := syncEventKey(last - uint64(b.maxEvents) + 1)
+		c := bucket.Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k, oldest) < 0; k, _ = c.First() {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error("failed to save sync events", "err", err)
+		return
+	}
+
+	for w := range b.watchers {
+		for _, e := range events {
+			if !w.match(e) {
+				continue
+			}
+			select {
+			case w.c <- e:
+			default:
+				logger.Warn("dropping slow sync event watcher", "sequence", e.Sequence)
+				b.dropLocked(w, ErrStatusWatcherTooSlow)
+			}
+			if _, ok := b.watchers[w]; !ok {
+				break
+			}
+		}
+	}
+}
+
+func (b *eventBus) subscribe(ids []string) *eventWatcher {
+	w := &eventWatcher{
+		ids:  ids,
+		c:    make(chan *SyncEvent, syncEventBufferSize),
+		done: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.watchers[w] = struct{}{}
+
+	return w
+}
+
+func (b *eventBus) unsubscribe(w *eventWatcher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.watchers, w)
+}
+
+// dropLocked removes the watcher with the error, b.mu must be held.
+func (b *eventBus) dropLocked(w *eventWatcher, err error) {
+	if _, ok := b.watchers[w]; !ok {
+		return
+	}
+	delete(b.watchers, w)
+	w.err = err
+	close(w.done)
+}
+
+// dropAll removes all the watchers, so the streams end when the server shuts down.
+func (b *eventBus) dropAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for w := range b.watchers {
+		b.dropLocked(w, ErrStatusShuttingDown)
+	}
+}
+
+// stored returns at most limit events after the sequence from the db.
+func (b *eventBus) stored(after uint64, ids []string, limit int) ([]*SyncEvent, error) {
+	var events []*SyncEvent
+	w := &eventWatcher{ids: ids}
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(SYNC_EVENT_BUCKET))
+		if bucket == nil {
+			return nil
+		}
+
+		c := bucket.Cursor()
+		for k, v := c.Seek(syncEventKey(after + 1)); k != nil && len(events) < limit; k, v = c.Next() {
+			e := &SyncEvent{}
+			if err := proto.Unmarshal(v, e); err != nil {
+				return err
+			}
+			if w.match(e) {
+				events = append(events, e)
+			}
+		}
+
+		return nil
+	})
+
+	return events, err
+}
+
+// commitHashes returns the hashes of the first [maxSyncEventCommits] commits, and the number of the commits.
+func commitHashes(commits []*object.Commit) ([]string, int32) {
+	var hashes []string
+	for _, c := range commits[:min(len(commits), maxSyncEventCommits)] {
+		hashes = append(hashes, c.Hash.String())
+	}
+
+	return hashes, int32(len(commits))
+}
@@ -0,0 +1,404 @@
+package svc
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// exportStatChunkSize is the max number of the commits and the mappings in an [ExportedStatChunk].
+var exportStatChunkSize = 10000
+
+// exportStat sends the synced commits and their mappings of the repo sync in chunks, followed by the ones of its
+// previous filters.
+func exportStat(tx *bbolt.Tx, id []byte, stream GiTrim_ExportServer) error {
+	b := getSyncStatBucket(tx, id)
+	if b == nil {
+		return nil
+	}
+	if err := exportStatBucket(b, 0, stream); err != nil {
+		return err
+	}
+
+	previous := b.Bucket(previousFiltersBucket)
+	if previous == nil {
+		return nil
+	}
+	return previous.ForEachBucket(func(k []byte) error {
+		if len(k) != 8 {
+			return status.Errorf(codes.Internal, "invalid key %x of previous filter of repo sync %x", k, id)
+		}
+		return exportStatBucket(previous.Bucket(k), uint32(binary.BigEndian.Uint64(k))+1, stream)
+	})
+}
+
+// exportStatBucket sends the maps in b in chunks, previousfilter is set in the chunks.
+func exportStatBucket(b *bbolt.Bucket, previousfilter uint32, stream GiTrim_ExportServer) error {
+	chunk := &ExportedStatChunk{PreviousFilter: previousfilter}
+	n := 0
+	flush := func() error {
+		if n == 0 {
+			return nil
+		}
+		if err := stream.Send(&ExportedRecord{Record: &ExportedRecord_StatChunk{StatChunk: chunk}}); err != nil {
+			return err
+		}
+		chunk, n = &ExportedStatChunk{PreviousFilter: previousfilter}, 0
+		return nil
+	}
+	// the keys and the values are only valid in the transaction, and are copied.
+	add := func(f func()) error {
+		f()
+		n++
+		if n < exportStatChunkSize {
+			return nil
+		}
+		return flush()
+	}
+	mapping := func(k, v []byte) *ExportedStatChunk_Mapping {
+		m := &ExportedStatChunk_Mapping{Key: bytes.Clone(k)}
+		if !bytes.Equal(v, plumbing.ZeroHash[:]) {
+			m.Value = bytes.Clone(v)
+		}
+		return m
+	}
+
+	for name, f := range map[string]func(k, v []byte){
+		string(fromDfsBucket):  func(_, v []byte) { chunk.FromDfs = append(chunk.FromDfs, bytes.Clone(v)) },
+		string(toDfsBucket):    func(_, v []byte) { chunk.ToDfs = append(chunk.ToDfs, bytes.Clone(v)) },
+		string(fromToToBucket): func(k, v []byte) { chunk.FromToTo = append(chunk.FromToTo, mapping(k, v)) },
+		string(toToFromBucket): func(k, v []byte) { chunk.ToToFrom = append(chunk.ToToFrom, mapping(k, v)) },
+	} {
+		sub := b.Bucket([]byte(name))
+		if sub == nil {
+			continue
+		}
+		if err := sub.ForEach(func(k, v []byte) error {
+			return add(func() { f(k, v) })
+		}); err != nil {
+			return err
+		}
+	}
+
+	return flush()
+}
+
+// exportRepoSync sends the repo sync with its secrets sealed by aead, followed by its stat.
+func exportRepoSync(tx *bbolt.Tx, aead cipher.AEAD, id []byte, data []byte, stream GiTrim_ExportServer) error {
+	reposync := &DbRepoSync{}
+	if err := proto.Unmarshal(data, reposync); err != nil {
+		return err
+	}
+
+	var secret []byte
+	if b := tx.Bucket([]byte(ID_TO_SECRET_BUCKET)); b != nil {
+		secret = b.Get(id)
+	}
+	if secret == nil {
+		return status.Errorf(codes.Internal, "secret not found for repo sync %x", id)
+	}
+	sealed, err := newSecret(aead, secret)
+	if err != nil {
+		return err
+	}
+
+	exported := &ExportedRepoSync{
+		SyncData:           reposync.SyncData,
+		Stat:               reposync.Stat,
+		PreviousFilters:    reposync.PreviousFilters,
+		Secret:             sealed,
+		LastBundleToCommit: reposync.LastBundleToCommit,
+	}
+
+	previous, err := getPreviousSecrets(tx, id)
+	if err != nil {
+		return err
+	}
+	for _, v := range previous.Secrets {
+		sealed, err := newSecret(aead, v.Secret)
+		if err != nil {
+			return err
+		}
+		exported.PreviousSecrets = append(exported.PreviousSecrets, &ExportedRepoSync_PreviousSecret{Secret: sealed, ExpiresAt: v.ExpiresAt})
+	}
+
+	if err := stream.Send(&ExportedRecord{Record: &ExportedRecord_RepoSync{RepoSync: exported}}); err != nil {
+		return err
+	}
+
+	return exportStat(tx, id, stream)
+}
+
+func (s *Svc) Export(req *ExportRequest, stream GiTrim_ExportServer) error {
+	aead, _, err := newAesCipher(req.Key)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid key: %s", err.Error())
+	}
+
+	ids := make([][]byte, 0, len(req.Ids))
+	for _, idhex := range req.Ids {
+		id, err := hex.DecodeString(idhex)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to parse id %s: %s", idhex, err.Error())
+		}
+		ids = append(ids, id)
+	}
+
+	n := 0
+	// the repo syncs are exported from one snapshot of the db.
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		reposyncbucket := tx.Bucket([]byte(REPO_SYNC_BUCKET))
+		if reposyncbucket == nil {
+			if len(ids) > 0 {
+				return ErrStatusNotFound
+			}
+			return nil
+		}
+
+		if len(ids) == 0 {
+			return reposyncbucket.ForEach(func(k, v []byte) error {
+				n++
+				return exportRepoSync(tx, aead, k, v, stream)
+			})
+		}
+
+		for _, id := range ids {
+			v := reposyncbucket.Get(id)
+			if v == nil {
+				return status.Errorf(codes.NotFound, "repo sync %x not found", id)
+			}
+			n++
+			if err := exportRepoSync(tx, aead, id, v, stream); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	logger.Info("exported repo syncs", "count", n)
+
+	return nil
+}
+
+// importer adds the records of an export to the db.
+type importer struct {
+	tx         *bbolt.Tx
+	aead       cipher.AEAD
+	onConflict ImportRequest_OnConflict
+	resp       *ImportResponse
+
+	// started is set once a repo sync is read.
+	started bool
+	// stat is the bucket of the stat of the last repo sync, nil if the repo sync is skipped.
+	stat *bbolt.Bucket
+	// id and the number of the previous filters of the last repo sync.
+	id              []byte
+	previousFilters int
+}
+
+func (imp *importer) unseal(idhex string, sealed []byte) ([]byte, error) {
+	secret, err := decodeSecret(imp.aead, sealed)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unseal the secret of repo sync %s, check the key: %s", idhex, err.Error())
+	}
+
+	return secret, nil
+}
+
+func (imp *importer) importRepoSync(exported *ExportedRepoSync) error {
+	imp.started = true
+	imp.stat = nil
+
+	idhex := exported.SyncData.GetId()
+	id, err := hex.DecodeString(idhex)
+	if err != nil || len(id) == 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid id of repo sync: %q", idhex)
+	}
+
+	if b := imp.tx.Bucket([]byte(REPO_SYNC_BUCKET)); b != nil && b.Get(id) != nil {
+		switch imp.onConflict {
+		case ImportRequest_SKIP:
+			logger.Info("skip existing repo sync", "id", idhex)
+			imp.resp.SkippedIds = append(imp.resp.SkippedIds, idhex)
+			return nil
+		case ImportRequest_OVERWRITE:
+			logger.Info("overwrite existing repo sync", "id", idhex)
+			if err := deleteRepoSyncFunc(id)(imp.tx); err != nil {
+				return err
+			}
+		default:
+			return status.Errorf(codes.AlreadyExists, "repo sync %s already exists", idhex)
+		}
+	}
+
+	secret, err := imp.unseal(idhex, exported.Secret)
+	if err != nil {
+		return err
+	}
+	if err := putSecretFunc(id, secret)(imp.tx); err != nil {
+		return err
+	}
+
+	if len(exported.PreviousSecrets) > 0 {
+		previous := &DbPreviousSecrets{}
+		for _, v := range exported.PreviousSecrets {
+			secret, err := imp.unseal(idhex, v.Secret)
+			if err != nil {
+				return err
+			}
+			previous.Secrets = append(previous.Secrets, &DbPreviousSecrets_Secret{Secret: secret, ExpiresAt: v.ExpiresAt})
+		}
+		data, err := proto.Marshal(previous)
+		if err != nil {
+			return err
+		}
+		previousbucket, err := imp.tx.CreateBucketIfNotExists([]byte(PREVIOUS_SECRET_BUCKET))
+		if err != nil {
+			return err
+		}
+		if err := previousbucket.Put(id, data); err != nil {
+			return err
+		}
+	}
+
+	if err := putRepoSyncFunc(id, &DbRepoSync{
+		SyncData:           exported.SyncData,
+		Stat:               exported.Stat,
+		PreviousFilters:    exported.PreviousFilters,
+		LastBundleToCommit: exported.LastBundleToCommit,
+	})(imp.tx); err != nil {
+		return err
+	}
+
+	imp.stat, err = createSyncStatBucket(imp.tx, id)
+	if err != nil {
+		return err
+	}
+	imp.id, imp.previousFilters = id, len(exported.PreviousFilters)
+	imp.resp.ImportedIds = append(imp.resp.ImportedIds, idhex)
+
+	return nil
+}
+
+func decodeExportedHash(v []byte) (plumbing.Hash, error) {
+	h, err := decodeRawHash(v)
+	if err != nil {
+		return h, status.Errorf(codes.InvalidArgument, "invalid commit in stat chunk: %s", err.Error())
+	}
+
+	return h, nil
+}
+
+func (imp *importer) importStatChunk(chunk *ExportedStatChunk) error {
+	if !imp.started {
+		return status.Error(codes.InvalidArgument, "stat chunk before any repo sync")
+	}
+	if imp.stat == nil {
+		return nil
+	}
+
+	b := imp.stat
+	if chunk.PreviousFilter > 0 {
+		index := int(chunk.PreviousFilter - 1)
+		if index >= imp.previousFilters {
+			return status.Errorf(codes.InvalidArgument, "stat chunk of previous filter %d, but repo sync %x has %d previous filters", index, imp.id, imp.previousFilters)
+		}
+		var err error
+		if b, err = createPreviousSyncStatBucket(imp.tx, imp.id, index); err != nil {
+			return err
+		}
+	}
+
+	for name, hashes := range map[string][][]byte{string(fromDfsBucket): chunk.FromDfs, string(toDfsBucket): chunk.ToDfs} {
+		sub := b.Bucket([]byte(name))
+		for _, v := range hashes {
+			h, err := decodeExportedHash(v)
+			if err != nil {
+				return err
+			}
+			if err := appendRawHash(sub, h); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, mappings := range map[string][]*ExportedStatChunk_Mapping{string(fromToToBucket): chunk.FromToTo, string(toToFromBucket): chunk.ToToFrom} {
+		sub := b.Bucket([]byte(name))
+		for _, m := range mappings {
+			k, err := decodeExportedHash(m.Key)
+			if err != nil {
+				return err
+			}
+			v := plumbing.ZeroHash
+			if len(m.Value) > 0 {
+				if v, err = decodeExportedHash(m.Value); err != nil {
+					return err
+				}
+			}
+			if err := putRawMapping(sub, k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Svc) Import(stream GiTrim_ImportServer) error {
+	resp := &ImportResponse{}
+
+	// everything is imported in one transaction, so a failed import changes nothing.
+	if err := s.db.Update(func(tx *bbolt.Tx) error {
+		var imp *importer
+		for {
+			req, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if imp == nil {
+				aead, _, err := newAesCipher(req.Key)
+				if err != nil {
+					return status.Errorf(codes.InvalidArgument, "invalid key: %s", err.Error())
+				}
+				imp = &importer{tx: tx, aead: aead, onConflict: req.OnConflict, resp: resp}
+			}
+
+			switch r := req.Record.GetRecord().(type) {
+			case *ExportedRecord_RepoSync:
+				err = imp.importRepoSync(r.RepoSync)
+			case *ExportedRecord_StatChunk:
+				err = imp.importStatChunk(r.StatChunk)
+			default:
+				err = status.Error(codes.InvalidArgument, "empty record")
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}); err != nil {
+		return err
+	}
+	if err := s.db.Sync(); err != nil {
+		return ErrStatusDBFailure
+	}
+
+	logger.Info("imported repo syncs", "imported", len(resp.ImportedIds), "skipped", len(resp.SkippedIds))
+
+	return stream.SendAndClose(resp)
+}
@@ -0,0 +1,248 @@
+package svc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+const testExportKey = "0f0e0d0c0b0a09080706050403020100"
+
+func TestSvc_Backup(t *testing.T) {
+	s := newTestSvc(t, nil)
+	id := putTestRepoSync(t, s,
+		&GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		&GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		"a/")
+
+	conn, _, _ := serveTestSvc(t, s)
+	stream, err := NewGiTrimClient(conn).Backup(context.Background(), &BackupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data []byte
+	var size int64
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Size > 0 {
+			size = resp.Size
+		}
+		data = append(data, resp.Data...)
+	}
+	if int64(len(data)) != size {
+		t.Fatalf("want %d bytes, got %d", size, len(data))
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.db")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s = newTestSvc(t, &GiTrimConfig{DbPath: path})
+	if _, err := s.GetRepoSync(context.Background(), &GetRepoSyncRequest{Id: id}); err != nil {
+		t.Errorf("repo sync is not in the backup: %v", err)
+	}
+}
+
+// exportTest exports the repo syncs of from with the test key.
+func exportTest(t *testing.T, from *Svc, ids ...string) []*ExportedRecord {
+	t.Helper()
+
+	stream, err := NewLocalClient(from).Export(context.Background(), &ExportRequest{Key: testExportKey, Ids: ids})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []*ExportedRecord
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+}
+
+func importTest(s *Svc, key string, onconflict ImportRequest_OnConflict, records []*ExportedRecord) (*ImportResponse, error) {
+	stream, err := NewLocalClient(s).Import(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for i, record := range records {
+		req := &ImportRequest{Record: record}
+		if i == 0 {
+			req.Key, req.OnConflict = key, onconflict
+		}
+		if err := stream.Send(req); err != nil {
+			break
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+func TestSvc_ExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(root, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	commitLocalFiles(t, fromwork, map[string]string{"b/y.txt": "y2\n"}, "third")
+	pushLocal(t, fromwork)
+	newLocalRepo(t, filepath.Join(root, "org", "to"), true)
+
+	remotes := map[string]*RemoteConfig{"local": {RemoteName: "local", RemoteUrl: root}}
+	from := newTestSvc(t, &GiTrimConfig{Remotes: remotes, AesKey: "000102030405060708090a0b0c0d0e0f"})
+	to := newTestSvc(t, &GiTrimConfig{Remotes: remotes, AesKey: "101112131415161718191a1b1c1d1e1f"})
+
+	initresp, err := from.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := initresp.Id
+	// the maps of the replaced filter are exported too.
+	if _, err := from.UpdateRepoSyncFilter(ctx, &UpdateRepoSyncFilterRequest{Id: id, Filter: "a/\nb/\n", Force: true, DoPush: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := from.RotateSecret(ctx, &RotateSecretRequest{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	other := putTestRepoSync(t, from,
+		&GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		&GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "other"},
+		"b/")
+
+	defer func(size int) { exportStatChunkSize = size }(exportStatChunkSize)
+	exportStatChunkSize = 2
+
+	records := exportTest(t, from)
+	chunks := 0
+	for _, record := range records {
+		if record.GetStatChunk() != nil {
+			chunks++
+		}
+	}
+	if len(records)-chunks != 2 || chunks < 2 {
+		t.Fatalf("want 2 repo syncs with their stats in chunks, got %v", records)
+	}
+
+	resp, err := importTest(to, testExportKey, ImportRequest_FAIL, records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Sorted(slices.Values(resp.ImportedIds)); !slices.Equal(got, slices.Sorted(slices.Values([]string{id, other}))) {
+		t.Errorf("want both repo syncs imported, got %v", resp)
+	}
+
+	check := func(t *testing.T, idhex string) {
+		t.Helper()
+
+		want, err := from.GetRepoSync(ctx, &GetRepoSyncRequest{Id: idhex, WithStatMaps: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := to.GetRepoSync(ctx, &GetRepoSyncRequest{Id: idhex, WithStatMaps: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(want.RepoSync, got.RepoSync) || !proto.Equal(want.SyncStat, got.SyncStat) {
+			t.Errorf("want imported %v, got %v", want, got)
+		}
+		if !slices.EqualFunc(want.PreviousFilters, got.PreviousFilters, func(a, b *FilterRevision) bool { return proto.Equal(a, b) }) {
+			t.Errorf("want imported previous filters %v, got %v", want.PreviousFilters, got.PreviousFilters)
+		}
+
+		rawid, err := hex.DecodeString(idhex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantsecrets, err := getWebhookSecretsForId(from.db, rawid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotsecrets, err := getWebhookSecretsForId(to.db, rawid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.EqualFunc(wantsecrets, gotsecrets, slices.Equal) {
+			t.Errorf("want the secrets kept, got %x, want %x", gotsecrets, wantsecrets)
+		}
+	}
+	check(t, id)
+	check(t, other)
+	if got := getTestSyncStatMaps(t, to, id); got.fromBase != 3 || !equalSyncStatMaps(getTestSyncStatMaps(t, from, id), got) {
+		t.Errorf("unexpected imported stat %v", got)
+	}
+	if got, err := to.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id, WithStatMaps: true}); err != nil || len(got.PreviousFilters) != 1 || len(got.PreviousFilters[0].SyncStat.FromDfs) != 3 {
+		t.Errorf("maps of previous filter are not imported: %v, %v", got, err)
+	}
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := importTest(to, testExportKey, ImportRequest_FAIL, exportTest(t, from, id))
+		wantCode(t, err, codes.AlreadyExists)
+
+		// the failed import is rolled back.
+		if _, err := to.DeleteRepoSync(ctx, &DeleteRepoSyncRequest{Id: other}); err != nil {
+			t.Fatal(err)
+		}
+		_, err = importTest(to, testExportKey, ImportRequest_FAIL, records)
+		wantCode(t, err, codes.AlreadyExists)
+		if _, err := to.GetRepoSync(ctx, &GetRepoSyncRequest{Id: other}); err == nil {
+			t.Error("failed import is not rolled back")
+		}
+
+		resp, err := importTest(to, testExportKey, ImportRequest_SKIP, records)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(resp.ImportedIds, []string{other}) || !slices.Equal(resp.SkippedIds, []string{id}) {
+			t.Errorf("want %s skipped and %s imported, got %v", id, other, resp)
+		}
+		check(t, id)
+		check(t, other)
+
+		resp, err = importTest(to, testExportKey, ImportRequest_OVERWRITE, records)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.ImportedIds) != 2 || len(resp.SkippedIds) != 0 {
+			t.Errorf("want both repo syncs overwritten, got %v", resp)
+		}
+		check(t, id)
+		check(t, other)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := importTest(to, "000102030405060708090a0b0c0d0e0f", ImportRequest_OVERWRITE, records)
+		wantCode(t, err, codes.InvalidArgument)
+
+		stream, err := NewLocalClient(from).Export(ctx, &ExportRequest{Key: "00"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = stream.Recv()
+		wantCode(t, err, codes.InvalidArgument)
+	})
+}
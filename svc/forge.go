@@ -0,0 +1,433 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// forgeApiTimeout is the timeout of each request to the forge api.
+const forgeApiTimeout = 30 * time.Second
+
+// forge manages the repos and the webhooks through the api of the forge of a remote.
+type forge interface {
+	// createRepoIfNotExist creates the repo if it doesn't exist, and reports if the repo is created.
+	createRepoIfNotExist(ctx context.Context, owner string, repo string) (bool, error)
+	// setDefaultBranch sets the default branch of the repo, the branch must exist.
+	setDefaultBranch(ctx context.Context, owner string, repo string, branch string) error
+	// setPushWebhook registers the webhook for push events, or updates the secret of the webhook with the same url.
+	setPushWebhook(ctx context.Context, owner string, repo string, hookurl string, secret string) error
+}
+
+// newForge creates the forge for the remote, nil if the remote type has no forge api.
+func newForge(cfg *RemoteConfig) (forge, error) {
+	switch cfg.RemoteType {
+	case RemoteConfig_GITHUB:
+		api, err := newForgeApi(cfg, "Authorization", "Bearer ")
+		if err != nil {
+			return nil, err
+		}
+		api.header.Set("Accept", "application/vnd.github+json")
+		return &githubForge{api: api}, nil
+	case RemoteConfig_GITEA:
+		api, err := newForgeApi(cfg, "Authorization", "token ")
+		if err != nil {
+			return nil, err
+		}
+		return &giteaForge{api: api}, nil
+	case RemoteConfig_GITLAB:
+		api, err := newForgeApi(cfg, "PRIVATE-TOKEN", "")
+		if err != nil {
+			return nil, err
+		}
+		return &gitlabForge{api: api}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// apiUrl returns the base url of the forge api.
+func (c *RemoteConfig) apiUrl() string {
+	if c.ApiUrl != "" {
+		return strings.TrimSuffix(c.ApiUrl, "/")
+	}
+
+	remoteurl := strings.TrimSuffix(c.RemoteUrl, "/")
+	if remoteurl == "" {
+		return ""
+	}
+	switch c.RemoteType {
+	case RemoteConfig_GITHUB:
+		if remoteurl == "https://github.com" {
+			return "https://api.github.com"
+		}
+		return remoteurl + "/api/v3"
+	case RemoteConfig_GITEA:
+		return remoteurl + "/api/v1"
+	case RemoteConfig_GITLAB:
+		return remoteurl + "/api/v4"
+	default:
+		return ""
+	}
+}
+
+// apiToken returns the token for the forge api.
+func (c *RemoteConfig) apiToken() string {
+	switch {
+	case c.ApiToken != "":
+		return c.ApiToken
+	case c.BearerToken != "":
+		return c.BearerToken
+	default:
+		return c.Secret
+	}
+}
+
+var ErrEmptyApiUrl = errors.New("empty api url")
+
+// forgeApiError is returned when the forge api responds with a non-2xx status code.
+type forgeApiError struct {
+	Method     string
+	Url        string
+	StatusCode int
+	Body       string
+}
+
+func (e *forgeApiError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.Url, e.StatusCode, e.Body)
+}
+
+func isForgeNotFound(err error) bool {
+	var apierr *forgeApiError
+	return errors.As(err, &apierr) && apierr.StatusCode == http.StatusNotFound
+}
+
+// forgeApi sends json requests to the forge api.
+type forgeApi struct {
+	baseUrl string
+	header  http.Header
+	client  *http.Client
+}
+
+func newForgeApi(cfg *RemoteConfig, authheader string, authprefix string) (*forgeApi, error) {
+	baseurl := cfg.apiUrl()
+	if baseurl == "" {
+		return nil, fmt.Errorf("%w for remote %s", ErrEmptyApiUrl, cfg.RemoteName)
+	}
+
+	header := make(http.Header)
+	if token := cfg.apiToken(); token != "" {
+		header.Set(authheader, authprefix+token)
+	}
+
+	return &forgeApi{
+		baseUrl: baseurl,
+		header:  header,
+		client:  &http.Client{Timeout: forgeApiTimeout},
+	}, nil
+}
+
+// do sends the request with body encoded in json, and decodes the response into result if result is not nil.
+func (a *forgeApi) do(ctx context.Context, method string, path string, body any, result any) error {
+	var reqbody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqbody = bytes.NewReader(data)
+	}
+
+	fullurl := a.baseUrl + path
+	req, err := http.NewRequestWithContext(ctx, method, fullurl, reqbody)
+	if err != nil {
+		return err
+	}
+	for k, v := range a.header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s %s: %w", method, fullurl, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respbody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &forgeApiError{Method: method, Url: fullurl, StatusCode: resp.StatusCode, Body: string(respbody)}
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %w", method, fullurl, err)
+	}
+
+	return nil
+}
+
+// repoPath returns the path of the repo in the api.
+func repoPath(owner string, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+// githubForge uses the GitHub rest api.
+type githubForge struct {
+	api *forgeApi
+}
+
+var _ forge = (*githubForge)(nil)
+
+// createUserOrOrgRepo creates the repo under the authenticated user if owner is the user, or under the organization.
+// Both GitHub and Gitea use the same endpoints.
+func createUserOrOrgRepo(ctx context.Context, api *forgeApi, owner string, repo string) (bool, error) {
+	err := api.do(ctx, http.MethodGet, repoPath(owner, repo), nil, nil)
+	if err == nil {
+		return false, nil
+	}
+	if !isForgeNotFound(err) {
+		return false, err
+	}
+
+	user := struct {
+		Login string `json:"login"`
+	}{}
+	if err := api.do(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+		return false, err
+	}
+
+	createpath := "/orgs/" + url.PathEscape(owner) + "/repos"
+	if strings.EqualFold(user.Login, owner) {
+		createpath = "/user/repos"
+	}
+
+	logger.Info("creating repo", "owner", owner, "repo", repo)
+	if err := api.do(ctx, http.MethodPost, createpath, map[string]any{"name": repo, "private": true}, nil); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (f *githubForge) createRepoIfNotExist(ctx context.Context, owner string, repo string) (bool, error) {
+	return createUserOrOrgRepo(ctx, f.api, owner, repo)
+}
+
+func (f *githubForge) setDefaultBranch(ctx context.Context, owner string, repo string, branch string) error {
+	return f.api.do(ctx, http.MethodPatch, repoPath(owner, repo), map[string]any{"default_branch": branch}, nil)
+}
+
+// repoHook is a webhook of a repo on GitHub or Gitea.
+type repoHook struct {
+	Id     int64 `json:"id"`
+	Config struct {
+		Url string `json:"url"`
+	} `json:"config"`
+}
+
+// findRepoHook returns the id of the webhook with the url registered on the repo on GitHub or Gitea, zero if not
+// found.
+func findRepoHook(ctx context.Context, api *forgeApi, owner string, repo string, hookurl string) (int64, error) {
+	var hooks []repoHook
+	if err := api.do(ctx, http.MethodGet, repoPath(owner, repo)+"/hooks", nil, &hooks); err != nil {
+		return 0, err
+	}
+	for _, h := range hooks {
+		if h.Config.Url == hookurl {
+			return h.Id, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// setRepoHook registers the webhook on GitHub or Gitea, or updates the config of the existing one. The apis of
+// GitHub and Gitea take the same config for the hooks.
+func setRepoHook(ctx context.Context, api *forgeApi, owner string, repo string, hookurl string, secret string, newhook map[string]any) error {
+	config := map[string]string{
+		"url":          hookurl,
+		"content_type": "json",
+		"secret":       secret,
+	}
+
+	hookid, err := findRepoHook(ctx, api, owner, repo, hookurl)
+	if err != nil {
+		return err
+	}
+	if hookid != 0 {
+		return api.do(ctx, http.MethodPatch, fmt.Sprintf("%s/hooks/%d", repoPath(owner, repo), hookid), map[string]any{
+			"active": true,
+			"config": config,
+		}, nil)
+	}
+
+	newhook["active"] = true
+	newhook["events"] = []string{"push"}
+	newhook["config"] = config
+
+	return api.do(ctx, http.MethodPost, repoPath(owner, repo)+"/hooks", newhook, nil)
+}
+
+func (f *githubForge) setPushWebhook(ctx context.Context, owner string, repo string, hookurl string, secret string) error {
+	return setRepoHook(ctx, f.api, owner, repo, hookurl, secret, map[string]any{"name": "web"})
+}
+
+// giteaForge uses the Gitea api.
+type giteaForge struct {
+	api *forgeApi
+}
+
+var _ forge = (*giteaForge)(nil)
+
+func (f *giteaForge) createRepoIfNotExist(ctx context.Context, owner string, repo string) (bool, error) {
+	return createUserOrOrgRepo(ctx, f.api, owner, repo)
+}
+
+func (f *giteaForge) setDefaultBranch(ctx context.Context, owner string, repo string, branch string) error {
+	return f.api.do(ctx, http.MethodPatch, repoPath(owner, repo), map[string]any{"default_branch": branch}, nil)
+}
+
+func (f *giteaForge) setPushWebhook(ctx context.Context, owner string, repo string, hookurl string, secret string) error {
+	return setRepoHook(ctx, f.api, owner, repo, hookurl, secret, map[string]any{"type": "gitea"})
+}
+
+// gitlabForge uses the GitLab rest api, where the projects are identified by the url encoded full path.
+type gitlabForge struct {
+	api *forgeApi
+}
+
+var _ forge = (*gitlabForge)(nil)
+
+func gitlabProjectPath(owner string, repo string) string {
+	return "/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+func (f *gitlabForge) createRepoIfNotExist(ctx context.Context, owner string, repo string) (bool, error) {
+	err := f.api.do(ctx, http.MethodGet, gitlabProjectPath(owner, repo), nil, nil)
+	if err == nil {
+		return false, nil
+	}
+	if !isForgeNotFound(err) {
+		return false, err
+	}
+
+	namespace := struct {
+		Id int64 `json:"id"`
+	}{}
+	if err := f.api.do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(owner), nil, &namespace); err != nil {
+		return false, fmt.Errorf("failed to find namespace %s: %w", owner, err)
+	}
+
+	logger.Info("creating repo", "owner", owner, "repo", repo)
+	if err := f.api.do(ctx, http.MethodPost, "/projects", map[string]any{
+		"name":         repo,
+		"path":         repo,
+		"namespace_id": namespace.Id,
+		"visibility":   "private",
+	}, nil); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (f *gitlabForge) setDefaultBranch(ctx context.Context, owner string, repo string, branch string) error {
+	return f.api.do(ctx, http.MethodPut, gitlabProjectPath(owner, repo), map[string]any{"default_branch": branch}, nil)
+}
+
+func (f *gitlabForge) setPushWebhook(ctx context.Context, owner string, repo string, hookurl string, secret string) error {
+	var hooks []struct {
+		Id  int64  `json:"id"`
+		Url string `json:"url"`
+	}
+	if err := f.api.do(ctx, http.MethodGet, gitlabProjectPath(owner, repo)+"/hooks", nil, &hooks); err != nil {
+		return err
+	}
+
+	// GitLab sends the token as is in X-Gitlab-Token instead of signing the payload.
+	hook := map[string]any{
+		"url":         hookurl,
+		"push_events": true,
+		"token":       secret,
+	}
+	for _, h := range hooks {
+		if h.Url == hookurl {
+			return f.api.do(ctx, http.MethodPut, fmt.Sprintf("%s/hooks/%d", gitlabProjectPath(owner, repo), h.Id), hook, nil)
+		}
+	}
+
+	return f.api.do(ctx, http.MethodPost, gitlabProjectPath(owner, repo)+"/hooks", hook, nil)
+}
+
+// forgeForRepo returns the forge of the remote of the repo, nil if the remote has no forge api.
+func (s *Svc) forgeForRepo(repo *GitRepoIdentifier) (forge, error) {
+	cfg, found := s.config.Remotes[repo.RemoteName]
+	if !found {
+		return nil, fmt.Errorf("unknown remote: %s", repo.RemoteName)
+	}
+
+	return newForge(cfg)
+}
+
+// webhookUrl returns the url of the webhook for the repo sync, empty if the webhook is not served.
+func (s *Svc) webhookUrl(idhex string) string {
+	baseurl := strings.TrimSuffix(s.config.WebhookPublicUrl, "/")
+	if baseurl == "" && s.config.WebhookAddress != "" {
+		scheme := "http://"
+		if s.config.WebhookTls != nil {
+			scheme = "https://"
+		}
+		baseurl = scheme + s.config.WebhookAddress
+	}
+	if baseurl == "" {
+		return ""
+	}
+
+	return baseurl + webhookPath + idhex
+}
+
+// registerWebhooks registers the webhook of the repo sync on the from and to repos with forge apis, and returns the
+// repos the webhook is registered on.
+func (s *Svc) registerWebhooks(ctx context.Context, reposync *RepoSync, secret string) ([]*GitRepoIdentifier, error) {
+	hookurl := s.webhookUrl(reposync.Id)
+	if hookurl == "" {
+		return nil, nil
+	}
+
+	var registered []*GitRepoIdentifier
+	for _, repo := range []*GitRepoIdentifier{reposync.FromRepo, reposync.ToRepo} {
+		if len(registered) > 0 && sameGitRepo(registered[0], repo) {
+			continue
+		}
+		f, err := s.forgeForRepo(repo)
+		if err != nil {
+			return registered, err
+		}
+		if f == nil {
+			continue
+		}
+		if err := f.setPushWebhook(ctx, repo.Owner, repo.Repo, hookurl, secret); err != nil {
+			return registered, fmt.Errorf("failed to register webhook on %s/%s: %w", repo.Owner, repo.Repo, err)
+		}
+		logger.Info("registered webhook", "remote", repo.RemoteName, "owner", repo.Owner, "repo", repo.Repo, "url", hookurl)
+		registered = append(registered, repo)
+	}
+
+	return registered, nil
+}
+
+func sameGitRepo(a *GitRepoIdentifier, b *GitRepoIdentifier) bool {
+	return a.RemoteName == b.RemoteName && a.Owner == b.Owner && a.Repo == b.Repo
+}
@@ -0,0 +1,439 @@
+package svc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeForgeHook struct {
+	url    string
+	secret string
+}
+
+// fakeForge is a stand-in for the apis of GitHub, Gitea, and GitLab. The repos are created as bare repos in root.
+type fakeForge struct {
+	t    *testing.T
+	root string
+
+	authheader string
+	authvalue  string
+
+	mu              sync.Mutex
+	defaultBranches map[string]string
+	hooks           map[string][]fakeForgeHook
+}
+
+const (
+	fakeForgeUser        = "me"
+	fakeForgeNamespaceId = 7
+)
+
+func newFakeForge(t *testing.T, kind RemoteConfig_RemoteType, root string, authheader string, authvalue string) (*fakeForge, *httptest.Server) {
+	f := &fakeForge{
+		t:               t,
+		root:            root,
+		authheader:      authheader,
+		authvalue:       authvalue,
+		defaultBranches: make(map[string]string),
+		hooks:           make(map[string][]fakeForgeHook),
+	}
+
+	mux := http.NewServeMux()
+	switch kind {
+	case RemoteConfig_GITHUB:
+		f.handleRepos(mux, "")
+	case RemoteConfig_GITEA:
+		f.handleRepos(mux, "/api/v1")
+	case RemoteConfig_GITLAB:
+		f.handleProjects(mux, "/api/v4")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(f.authheader) != f.authvalue {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return f, server
+}
+
+func (f *fakeForge) exists(fullname string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, found := f.defaultBranches[fullname]
+	return found
+}
+
+func (f *fakeForge) create(fullname string) {
+	newLocalRepo(f.t, filepath.Join(f.root, fullname), true)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.defaultBranches[fullname] = "main"
+}
+
+func (f *fakeForge) setDefaultBranch(w http.ResponseWriter, r *http.Request, fullname string) {
+	if !f.exists(fullname) {
+		http.NotFound(w, r)
+		return
+	}
+	body := struct {
+		DefaultBranch string `json:"default_branch"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.defaultBranches[fullname] = body.DefaultBranch
+}
+
+func (f *fakeForge) getDefaultBranch(fullname string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.defaultBranches[fullname]
+}
+
+func (f *fakeForge) addHook(fullname string, hook fakeForgeHook) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hooks[fullname] = append(f.hooks[fullname], hook)
+}
+
+// updateHook updates the secret of the hook with the id, which is the index of the hook plus one.
+func (f *fakeForge) updateHook(w http.ResponseWriter, r *http.Request, fullname string, url string, secret string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	hookid, err := strconv.Atoi(r.PathValue("hookid"))
+	if err != nil || hookid < 1 || hookid > len(f.hooks[fullname]) || f.hooks[fullname][hookid-1].url != url {
+		http.NotFound(w, r)
+		return
+	}
+	f.hooks[fullname][hookid-1].secret = secret
+}
+
+func (f *fakeForge) getHooks(fullname string) []fakeForgeHook {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.hooks[fullname])
+}
+
+func writeJson(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// handleRepos serves the apis of GitHub and Gitea.
+func (f *fakeForge) handleRepos(mux *http.ServeMux, prefix string) {
+	fullname := func(r *http.Request) string {
+		return r.PathValue("owner") + "/" + r.PathValue("repo")
+	}
+	create := func(w http.ResponseWriter, r *http.Request, owner string) {
+		body := struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.create(owner + "/" + body.Name)
+		w.WriteHeader(http.StatusCreated)
+	}
+
+	mux.HandleFunc("GET "+prefix+"/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, map[string]string{"login": fakeForgeUser})
+	})
+	mux.HandleFunc("POST "+prefix+"/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		create(w, r, fakeForgeUser)
+	})
+	mux.HandleFunc("POST "+prefix+"/orgs/{org}/repos", func(w http.ResponseWriter, r *http.Request) {
+		create(w, r, r.PathValue("org"))
+	})
+	mux.HandleFunc("GET "+prefix+"/repos/{owner}/{repo}", func(w http.ResponseWriter, r *http.Request) {
+		if !f.exists(fullname(r)) {
+			http.NotFound(w, r)
+			return
+		}
+		writeJson(w, map[string]string{"full_name": fullname(r)})
+	})
+	mux.HandleFunc("PATCH "+prefix+"/repos/{owner}/{repo}", func(w http.ResponseWriter, r *http.Request) {
+		f.setDefaultBranch(w, r, fullname(r))
+	})
+	mux.HandleFunc("GET "+prefix+"/repos/{owner}/{repo}/hooks", func(w http.ResponseWriter, r *http.Request) {
+		var hooks []map[string]any
+		for i, h := range f.getHooks(fullname(r)) {
+			hooks = append(hooks, map[string]any{"id": i + 1, "config": map[string]string{"url": h.url}})
+		}
+		writeJson(w, hooks)
+	})
+	mux.HandleFunc("PATCH "+prefix+"/repos/{owner}/{repo}/hooks/{hookid}", func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Config struct {
+				Url    string `json:"url"`
+				Secret string `json:"secret"`
+			} `json:"config"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "invalid hook", http.StatusBadRequest)
+			return
+		}
+		f.updateHook(w, r, fullname(r), body.Config.Url, body.Config.Secret)
+	})
+	mux.HandleFunc("POST "+prefix+"/repos/{owner}/{repo}/hooks", func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Events []string `json:"events"`
+			Config struct {
+				Url    string `json:"url"`
+				Secret string `json:"secret"`
+			} `json:"config"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !slices.Contains(body.Events, "push") {
+			http.Error(w, "invalid hook", http.StatusBadRequest)
+			return
+		}
+		f.addHook(fullname(r), fakeForgeHook{url: body.Config.Url, secret: body.Config.Secret})
+		w.WriteHeader(http.StatusCreated)
+	})
+}
+
+// handleProjects serves the apis of GitLab.
+func (f *fakeForge) handleProjects(mux *http.ServeMux, prefix string) {
+	mux.HandleFunc("GET "+prefix+"/namespaces/{namespace}", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, map[string]any{"id": fakeForgeNamespaceId, "full_path": r.PathValue("namespace")})
+	})
+	mux.HandleFunc("POST "+prefix+"/projects", func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Path        string `json:"path"`
+			NamespaceId int64  `json:"namespace_id"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.NamespaceId != fakeForgeNamespaceId {
+			http.Error(w, "invalid project", http.StatusBadRequest)
+			return
+		}
+		f.create("org/" + body.Path)
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("GET "+prefix+"/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if !f.exists(r.PathValue("id")) {
+			http.NotFound(w, r)
+			return
+		}
+		writeJson(w, map[string]string{"path_with_namespace": r.PathValue("id")})
+	})
+	mux.HandleFunc("PUT "+prefix+"/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.setDefaultBranch(w, r, r.PathValue("id"))
+	})
+	mux.HandleFunc("GET "+prefix+"/projects/{id}/hooks", func(w http.ResponseWriter, r *http.Request) {
+		var hooks []map[string]any
+		for i, h := range f.getHooks(r.PathValue("id")) {
+			hooks = append(hooks, map[string]any{"id": i + 1, "url": h.url})
+		}
+		writeJson(w, hooks)
+	})
+	mux.HandleFunc("PUT "+prefix+"/projects/{id}/hooks/{hookid}", func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Url   string `json:"url"`
+			Token string `json:"token"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "invalid hook", http.StatusBadRequest)
+			return
+		}
+		f.updateHook(w, r, r.PathValue("id"), body.Url, body.Token)
+	})
+	mux.HandleFunc("POST "+prefix+"/projects/{id}/hooks", func(w http.ResponseWriter, r *http.Request) {
+		body := struct {
+			Url        string `json:"url"`
+			PushEvents bool   `json:"push_events"`
+			Token      string `json:"token"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || !body.PushEvents {
+			http.Error(w, "invalid hook", http.StatusBadRequest)
+			return
+		}
+		f.addHook(r.PathValue("id"), fakeForgeHook{url: body.Url, secret: body.Token})
+		w.WriteHeader(http.StatusCreated)
+	})
+}
+
+func TestSvc_InitRepoSync_forge(t *testing.T) {
+	tests := []struct {
+		kind       RemoteConfig_RemoteType
+		authheader string
+		authvalue  string
+		// useApiUrl sets api_url instead of deriving it from remote_url.
+		useApiUrl bool
+	}{
+		{kind: RemoteConfig_GITHUB, authheader: "Authorization", authvalue: "Bearer token", useApiUrl: true},
+		{kind: RemoteConfig_GITEA, authheader: "Authorization", authvalue: "token token"},
+		{kind: RemoteConfig_GITLAB, authheader: "PRIVATE-TOKEN", authvalue: "token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind.String(), func(t *testing.T) {
+			ctx := context.Background()
+
+			root := t.TempDir()
+			fromwork := newLocalWorkRepo(t, filepath.Join(root, "local", "org", "from"))
+			commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+			pushLocal(t, fromwork)
+
+			forgeroot := filepath.Join(root, "forge")
+			fake, server := newFakeForge(t, tt.kind, forgeroot, tt.authheader, tt.authvalue)
+
+			forgecfg := &RemoteConfig{
+				RemoteName:  "forge",
+				RemoteType:  tt.kind,
+				UrlTemplate: forgeroot + "/{owner}/{repo}",
+				ApiToken:    "token",
+			}
+			if tt.useApiUrl {
+				forgecfg.ApiUrl = server.URL
+			} else {
+				forgecfg.RemoteUrl = server.URL
+			}
+			badcfg := &RemoteConfig{
+				RemoteName:  "badtoken",
+				RemoteType:  tt.kind,
+				UrlTemplate: forgecfg.UrlTemplate,
+				ApiUrl:      forgecfg.apiUrl(),
+				ApiToken:    "bad",
+			}
+
+			s := newTestSvc(t, &GiTrimConfig{
+				WebhookPublicUrl: "https://gitrim.example.com/",
+				Remotes: map[string]*RemoteConfig{
+					"local":    {RemoteName: "local", RemoteType: RemoteConfig_LOCAL, RemoteUrl: filepath.Join(root, "local")},
+					"forge":    forgecfg,
+					"badtoken": badcfg,
+				},
+			})
+
+			fromrepo := &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"}
+			torepo := &GitRepoIdentifier{RemoteName: "forge", Owner: "org", Repo: "to"}
+
+			_, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+				FromRepo:   fromrepo,
+				FromBranch: "main",
+				ToRepo:     &GitRepoIdentifier{RemoteName: "badtoken", Owner: "org", Repo: "to"},
+				ToBranch:   "sub",
+				Filter:     "a/",
+			})
+			if status.Code(err) != codes.Internal {
+				t.Fatalf("want internal error for bad token, got %v", err)
+			}
+
+			resp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+				FromRepo:   fromrepo,
+				FromBranch: "main",
+				ToRepo:     torepo,
+				ToBranch:   "sub",
+				Filter:     "a/",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !resp.ToRepoCreated {
+				t.Errorf("to repo is not created")
+			}
+			if len(resp.WebhookRepos) != 1 || !sameGitRepo(resp.WebhookRepos[0], torepo) {
+				t.Errorf("want webhook on to repo, got %v", resp.WebhookRepos)
+			}
+
+			repo, err := git.PlainOpen(filepath.Join(forgeroot, "org", "to"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repo.Reference(plumbing.NewBranchReferenceName("sub"), true); err != nil {
+				t.Fatalf("to branch is not pushed: %v", err)
+			}
+			if got := fake.getDefaultBranch("org/to"); got != "sub" {
+				t.Errorf("want default branch sub, got %s", got)
+			}
+			wanthook := fakeForgeHook{url: "https://gitrim.example.com/webhook/" + resp.Id, secret: resp.Secret}
+			if got := fake.getHooks("org/to"); !slices.Equal(got, []fakeForgeHook{wanthook}) {
+				t.Errorf("want hook %v, got %v", wanthook, got)
+			}
+
+			// a second sync into the same repo doesn't create the repo.
+			second, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+				FromRepo:   fromrepo,
+				FromBranch: "main",
+				ToRepo:     torepo,
+				ToBranch:   "sub2",
+				Filter:     "a/",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if second.ToRepoCreated {
+				t.Errorf("existing repo is created again")
+			}
+			if got := fake.getDefaultBranch("org/to"); got != "sub" {
+				t.Errorf("default branch of existing repo is changed to %s", got)
+			}
+
+			// registering again doesn't add duplicated webhooks.
+			syncdata := &RepoSync{Id: resp.Id, FromRepo: fromrepo, ToRepo: torepo}
+			if _, err := s.registerWebhooks(ctx, syncdata, resp.Secret); err != nil {
+				t.Fatal(err)
+			}
+			if got := fake.getHooks("org/to"); len(got) != 2 {
+				t.Errorf("want 2 hooks, got %v", got)
+			}
+
+			// registering with a new secret updates the existing webhook.
+			if _, err := s.registerWebhooks(ctx, syncdata, "rotated"); err != nil {
+				t.Fatal(err)
+			}
+			wanthook.secret = "rotated"
+			if got := fake.getHooks("org/to"); len(got) != 2 || got[0] != wanthook {
+				t.Errorf("want hook %v updated, got %v", wanthook, got)
+			}
+
+			// moving the repo sync registers the webhook for the new id with the new secret.
+			updated, err := s.UpdateRepoSync(ctx, &UpdateRepoSyncRequest{Id: second.Id, ToBranch: "sub3"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(updated.WebhookRepos) != 1 || !sameGitRepo(updated.WebhookRepos[0], torepo) {
+				t.Errorf("want webhook on to repo, got %v", updated.WebhookRepos)
+			}
+			movedhook := fakeForgeHook{url: "https://gitrim.example.com/webhook/" + updated.RepoSync.Id, secret: updated.Secret}
+			if got := fake.getHooks("org/to"); !slices.Contains(got, movedhook) {
+				t.Errorf("want hook %v, got %v", movedhook, got)
+			}
+
+			filtered, err := s.UpdateRepoSyncFilter(ctx, &UpdateRepoSyncFilterRequest{Id: updated.RepoSync.Id, Filter: "a/\nb/\n", NewToBranch: "sub4", DoPush: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(filtered.WebhookRepos) != 1 || !sameGitRepo(filtered.WebhookRepos[0], torepo) {
+				t.Errorf("want webhook on to repo, got %v", filtered.WebhookRepos)
+			}
+			movedhook = fakeForgeHook{url: "https://gitrim.example.com/webhook/" + filtered.RepoSync.Id, secret: filtered.Secret}
+			if got := fake.getHooks("org/to"); !slices.Contains(got, movedhook) {
+				t.Errorf("want hook %v, got %v", movedhook, got)
+			}
+		})
+	}
+}
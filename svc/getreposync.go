@@ -52,10 +52,27 @@ func (svc *Svc) GetRepoSync(
 		return nil, err
 	}
 
+	pollstate, err := getPollStateFromDb(svc.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.WithStatMaps {
+		if err := svc.db.View(func(tx *bbolt.Tx) error {
+			return fillStatMaps(tx, id, rs)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
 	result := &GetRepoSyncResponse{
 		RepoSync: rs.SyncData,
 		Secret:   hex.EncodeToString(secret),
 		SyncStat: rs.Stat,
+
+		PreviousFilters:    rs.PreviousFilters,
+		PollState:          pollstate,
+		LastBundleToCommit: rs.LastBundleToCommit,
 	}
 
 	return result, nil
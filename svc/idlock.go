@@ -19,7 +19,10 @@
 
 package svc
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // emptyForChan is just that
 type emptyForChan struct{}
@@ -51,6 +54,8 @@ func (w *waitingChan) Done() <-chan emptyForChan {
 //  4. if the id doesn't have waitingChan, create a new waitingChan,
 //     set it to the id, unlock the map, and return the closer
 func (s *Svc) lockId(ctx context.Context, id string) (chan<- emptyForChan, error) {
+	start := time.Now()
+
 	var idmutex map[string]*waitingChan
 	select {
 	// locak idmutex
@@ -91,6 +96,8 @@ waitloop:
 
 	s.idmutex <- idmutex
 
+	idLockWait.Observe(time.Since(start).Seconds())
+
 	return result, nil
 }
 
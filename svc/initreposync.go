@@ -59,7 +59,7 @@ func (s *Svc) InitRepoSync(
 		return nil, fmt.Errorf("sync-ing between the two repos already exists")
 	}
 
-	secret, err := newSecret(s.encryptor, id[:])
+	secret, err := s.keyring.newSecret(id[:])
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to generate secret: %s", err.Error())
 	}
@@ -78,6 +78,18 @@ func (s *Svc) InitRepoSync(
 		return nil, ErrEmptyFilter
 	}
 
+	// the to repo is created before fetching.
+	toforge, err := s.forgeForRepo(req.ToRepo)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if toforge != nil {
+		resp.ToRepoCreated, err = toforge.createRepoIfNotExist(ctx, req.ToRepo.Owner, req.ToRepo.Repo)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create to repo: %s", err.Error())
+		}
+	}
+
 	reposync = &DbRepoSync{
 		SyncData: &RepoSync{
 			Id:         idstr,
@@ -90,21 +102,34 @@ func (s *Svc) InitRepoSync(
 		Stat: EmptySyncStat(),
 	}
 
-	ws, err := newSyncWorkspace(ctx, s.config.Remotes, reposync)
+	ws, err := newSyncWorkspace(ctx, s.config.Remotes, s.objectCache, reposync, nil, nil)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to obtain from repo: %s", err.Error())
 	}
+	defer ws.close()
+	s.observeSyncWorkspace(ws, AuditEvent_INIT_REPO_SYNC)
 
 	if _, err := ws.syncToTo(ctx, true); err != nil {
 		return nil, err
 	}
 
+	if resp.ToRepoCreated {
+		if err := toforge.setDefaultBranch(ctx, req.ToRepo.Owner, req.ToRepo.Repo, req.ToBranch); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set default branch of to repo: %s", err.Error())
+		}
+	}
+
+	resp.WebhookRepos, err = s.registerWebhooks(ctx, reposync.SyncData, resp.Secret)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to register webhooks: %s", err.Error())
+	}
+
 	if err := s.db.Update(func(tx *bbolt.Tx) error {
 		if err := putSecretFunc(id[:], secret)(tx); err != nil {
 			return err
 		}
 
-		return putRepoSyncFunc(id[:], reposync)(tx)
+		return ws.putFunc(id[:])(tx)
 	}); err != nil {
 		return nil, err
 	}
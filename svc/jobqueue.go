@@ -0,0 +1,490 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultJobWorkers      = 2
+	defaultJobMaxAttempts  = 5
+	defaultJobRetryDelay   = 10 * time.Second
+	defaultJobMaxRetry     = 10 * time.Minute
+	defaultJobRetention    = 7 * 24 * time.Hour
+	maxJobWorkerIdlePeriod = 30 * time.Second
+)
+
+var (
+	ErrStatusEmptyJobRequest = status.Error(codes.InvalidArgument, "empty job request")
+	ErrStatusJobOverride     = status.Error(codes.InvalidArgument, "jobs cannot override branches")
+	ErrStatusJobNotFound     = status.Error(codes.NotFound, "job not found for the provided id")
+)
+
+// jobKey is the key of the job in the db, the big endian form of the id so the jobs are in the order of creation.
+func jobKey(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, id)
+}
+
+func (c *GiTrimConfig) jobWorkers() int {
+	if c.JobWorkers <= 0 {
+		return defaultJobWorkers
+	}
+	return int(c.JobWorkers)
+}
+
+func (c *GiTrimConfig) jobMaxAttempts() int32 {
+	if c.JobMaxAttempts <= 0 {
+		return defaultJobMaxAttempts
+	}
+	return c.JobMaxAttempts
+}
+
+// jobRetryDelay returns the delay before the next attempt of a job that has run attempts times.
+func (c *GiTrimConfig) jobRetryDelay(attempts int32) time.Duration {
+	base := defaultJobRetryDelay
+	if c.JobRetrySecs > 0 {
+		base = time.Duration(c.JobRetrySecs) * time.Second
+	}
+	maxdelay := defaultJobMaxRetry
+	if c.JobMaxRetrySecs > 0 {
+		maxdelay = time.Duration(c.JobMaxRetrySecs) * time.Second
+	}
+
+	return exponentialBackoff(base, maxdelay, attempts-1)
+}
+
+func (c *GiTrimConfig) jobRetention() time.Duration {
+	if c.JobRetentionSecs <= 0 {
+		return defaultJobRetention
+	}
+	return time.Duration(c.JobRetentionSecs) * time.Second
+}
+
+// exponentialBackoff doubles base n times, up to maxdelay.
+func exponentialBackoff(base time.Duration, maxdelay time.Duration, n int32) time.Duration {
+	d := base
+	for i := int32(0); i < n && d < maxdelay; i++ {
+		d *= 2
+	}
+
+	return min(d, maxdelay)
+}
+
+// isFinished checks if the job will not run again.
+func (j *Job) isFinished() bool {
+	return j.State == Job_SUCCEEDED || j.State == Job_FAILED
+}
+
+// repoSyncIdOfRequest returns the id of the repo sync of the job request.
+func repoSyncIdOfRequest(req any) (string, error) {
+	var r RequestWithPossibleOverride
+	switch v := req.(type) {
+	case *Job_SyncToSubRepo:
+		r = v.SyncToSubRepo
+	case *Job_CommitsFromSubRepo:
+		r = v.CommitsFromSubRepo
+	}
+	if r == nil || r.GetId() == "" {
+		return "", ErrStatusEmptyJobRequest
+	}
+	if HasOverrides(r) {
+		return "", ErrStatusJobOverride
+	}
+
+	return r.GetId(), nil
+}
+
+func putJobFunc(job *Job) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(JOB_BUCKET))
+		if err != nil {
+			return err
+		}
+		data, err := proto.Marshal(job)
+		if err != nil {
+			return err
+		}
+		return b.Put(jobKey(job.Id), data)
+	}
+}
+
+func getJobFromDb(db *bbolt.DB, id uint64) (*Job, error) {
+	return getFromDb(db, []byte(JOB_BUCKET), jobKey(id), func(data []byte, v *Job) error {
+		return proto.Unmarshal(data, v)
+	})
+}
+
+// enqueueJob saves a new pending job for the request, or returns the pending job with the same request.
+func (s *Svc) enqueueJob(ctx context.Context, request isJob_Request) (*Job, error) {
+	idhex, err := repoSyncIdOfRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := getRepoSync(s.db, idhex, true); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	job := &Job{
+		RepoSyncId:    idhex,
+		State:         Job_PENDING,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		NextAttemptAt: now,
+		Request:       request,
+		TriggeredBy:   triggerFromContext(ctx).triggeredBy,
+	}
+
+	if err := s.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(JOB_BUCKET))
+		if err != nil {
+			return err
+		}
+
+		// the recent jobs are at the end.
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			existing := &Job{}
+			if err := proto.Unmarshal(v, existing); err != nil {
+				return err
+			}
+			if existing.State == Job_PENDING && existing.RepoSyncId == idhex &&
+				proto.Equal(&Job{Request: existing.Request}, &Job{Request: request}) {
+				job = existing
+				return nil
+			}
+		}
+
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		job.Id = id
+
+		return putJobFunc(job)(tx)
+	}); err != nil {
+		logger.Error("failed to enqueue job", "id", idhex, "err", err)
+		return nil, ErrStatusDBFailure
+	}
+
+	s.notifyJobWorkers()
+
+	return job, nil
+}
+
+// notifyJobWorkers wakes up an idle worker.
+func (s *Svc) notifyJobWorkers() {
+	select {
+	case s.jobNotify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Svc) EnqueueJob(ctx context.Context, req *EnqueueJobRequest) (*EnqueueJobResponse, error) {
+	var request isJob_Request
+	switch v := req.Request.(type) {
+	case *EnqueueJobRequest_SyncToSubRepo:
+		request = &Job_SyncToSubRepo{SyncToSubRepo: v.SyncToSubRepo}
+	case *EnqueueJobRequest_CommitsFromSubRepo:
+		request = &Job_CommitsFromSubRepo{CommitsFromSubRepo: v.CommitsFromSubRepo}
+	default:
+		return nil, ErrStatusEmptyJobRequest
+	}
+
+	job, err := s.enqueueJob(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EnqueueJobResponse{Job: job}, nil
+}
+
+func (s *Svc) GetJob(ctx context.Context, req *GetJobRequest) (*GetJobResponse, error) {
+	job, err := getJobFromDb(s.db, req.Id)
+	if err != nil {
+		return nil, ErrStatusDBFailure
+	}
+	if job == nil {
+		return nil, ErrStatusJobNotFound
+	}
+
+	return &GetJobResponse{Job: job}, nil
+}
+
+func (s *Svc) ListJobs(ctx context.Context, req *ListJobsRequest) (*ListJobsResponse, error) {
+	pagesize, err := getPageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// page token is the id of the last job in the previous page.
+	var before []byte
+	if req.PageToken != "" {
+		id, err := strconv.ParseUint(req.PageToken, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %s", err.Error())
+		}
+		before = jobKey(id)
+	}
+
+	canread := s.canReadFunc(ctx)
+	resp := &ListJobsResponse{}
+
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(JOB_BUCKET))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		k, v := c.Last()
+		if before != nil {
+			k, v = c.Seek(before)
+			if k == nil {
+				k, v = c.Last()
+			}
+			if k != nil && bytes.Compare(k, before) >= 0 {
+				k, v = c.Prev()
+			}
+		}
+
+		for ; k != nil; k, v = c.Prev() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			job := &Job{}
+			if err := proto.Unmarshal(v, job); err != nil {
+				return err
+			}
+			if req.RepoSyncId != "" && job.RepoSyncId != req.RepoSyncId {
+				continue
+			}
+			if !canread(job.RepoSyncId) {
+				continue
+			}
+			if len(req.States) > 0 && !slices.Contains(req.States, job.State) {
+				continue
+			}
+
+			if len(resp.Jobs) == pagesize {
+				resp.NextPageToken = strconv.FormatUint(resp.Jobs[len(resp.Jobs)-1].Id, 10)
+				return nil
+			}
+			resp.Jobs = append(resp.Jobs, job)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RunJobWorkers runs job_workers workers for the queued jobs until ctx is done, and waits for the running jobs
+// to finish.
+//
+// The jobs left running by a previous process are pending again once the workers start, so only one process
+// should run the workers for a db.
+func (s *Svc) RunJobWorkers(ctx context.Context) error {
+	if err := s.db.Update(resetRunningJobsFunc(time.Now())); err != nil {
+		return err
+	}
+
+	workers := s.config.jobWorkers()
+	logger.Info("running job workers", "workers", workers)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runJobWorker(ctx)
+		}()
+	}
+
+	wg.Wait()
+	logger.Info("stopped job workers")
+
+	return nil
+}
+
+// resetRunningJobsFunc moves the running jobs back to pending.
+func resetRunningJobsFunc(now time.Time) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(JOB_BUCKET))
+		if b == nil {
+			return nil
+		}
+
+		var jobs []*Job
+		if err := b.ForEach(func(_, v []byte) error {
+			job := &Job{}
+			if err := proto.Unmarshal(v, job); err != nil {
+				return err
+			}
+			if job.State == Job_RUNNING {
+				jobs = append(jobs, job)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			logger.Warn("job was interrupted, retrying", "job", job.Id, "id", job.RepoSyncId)
+			job.State = Job_PENDING
+			job.UpdatedAt = now.Unix()
+			job.NextAttemptAt = now.Unix()
+			if err := putJobFunc(job)(tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func (s *Svc) runJobWorker(ctx context.Context) {
+	for {
+		job, wait, err := s.claimJob(time.Now())
+		if err != nil {
+			logger.Error("failed to claim job", "err", err)
+			wait = maxJobWorkerIdlePeriod
+		}
+
+		if job != nil {
+			s.runJob(ctx, job)
+			continue
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.jobNotify:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// claimJob marks the oldest due pending job running and returns it. If no job is due, it returns the duration
+// until the next pending job is due. The finished jobs older than job_retention_secs are deleted.
+func (s *Svc) claimJob(now time.Time) (*Job, time.Duration, error) {
+	var claimed *Job
+	wait := maxJobWorkerIdlePeriod
+	expiredat := now.Add(-s.config.jobRetention()).Unix()
+
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(JOB_BUCKET))
+		if b == nil {
+			return nil
+		}
+
+		var expired [][]byte
+		if err := b.ForEach(func(k, v []byte) error {
+			job := &Job{}
+			if err := proto.Unmarshal(v, job); err != nil {
+				return err
+			}
+			switch {
+			case job.isFinished() && job.UpdatedAt < expiredat:
+				expired = append(expired, bytes.Clone(k))
+			case job.State != Job_PENDING:
+			case job.NextAttemptAt <= now.Unix():
+				if claimed == nil {
+					claimed = job
+				}
+			default:
+				wait = min(wait, time.Unix(job.NextAttemptAt, 0).Sub(now))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, k := range expired {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		if claimed == nil {
+			return nil
+		}
+
+		claimed.State = Job_RUNNING
+		claimed.Attempts++
+		claimed.UpdatedAt = now.Unix()
+		return putJobFunc(claimed)(tx)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return claimed, max(wait, 0), nil
+}
+
+// runJob runs the job and saves the outcome. Transient failures are retried with backoff until job_max_attempts,
+// and a job interrupted by ctx is pending again.
+func (s *Svc) runJob(ctx context.Context, job *Job) {
+	logger.Info("running job", "job", job.Id, "id", job.RepoSyncId, "attempt", job.Attempts)
+
+	jobctx := withJob(ctx, job)
+
+	var err error
+	switch r := job.Request.(type) {
+	case *Job_SyncToSubRepo:
+		var resp *SyncToSubRepoResponse
+		if resp, err = s.SyncToSubRepo(jobctx, r.SyncToSubRepo); err == nil {
+			job.Result = &Job_SyncToSubRepoResult{SyncToSubRepoResult: resp}
+		}
+	case *Job_CommitsFromSubRepo:
+		var resp *CommitsFromSubRepoResponse
+		if resp, err = s.CommitsFromSubRepo(jobctx, r.CommitsFromSubRepo); err == nil {
+			job.Result = &Job_CommitsFromSubRepoResult{CommitsFromSubRepoResult: resp}
+		}
+	default:
+		err = ErrStatusEmptyJobRequest
+	}
+
+	now := time.Now()
+	job.UpdatedAt = now.Unix()
+
+	switch {
+	case err == nil:
+		job.State = Job_SUCCEEDED
+		job.LastError = ""
+	case ctx.Err() != nil:
+		// interrupted by shutdown, the attempt doesn't count.
+		job.State = Job_PENDING
+		job.Attempts--
+		job.NextAttemptAt = now.Unix()
+	case isTransientError(err) && job.Attempts < s.config.jobMaxAttempts():
+		job.State = Job_PENDING
+		job.LastError = err.Error()
+		job.NextAttemptAt = now.Add(s.config.jobRetryDelay(job.Attempts)).Unix()
+	default:
+		job.State = Job_FAILED
+		job.LastError = err.Error()
+	}
+
+	logger.Info("job finished", "job", job.Id, "id", job.RepoSyncId, "state", job.State.String(), "err", err, "next-attempt-at", job.NextAttemptAt)
+
+	if err := s.db.Update(putJobFunc(job)); err != nil {
+		logger.Error("failed to save job", "job", job.Id, "err", err)
+	}
+}
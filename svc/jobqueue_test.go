@@ -0,0 +1,309 @@
+package svc
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// runTestJobWorkers runs the job workers of s until the test finishes.
+func runTestJobWorkers(t *testing.T, s *Svc) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.RunJobWorkers(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// waitTestJob waits for the job to finish.
+func waitTestJob(t *testing.T, s *Svc, id uint64) *Job {
+	t.Helper()
+
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		resp, err := s.GetJob(context.Background(), &GetJobRequest{Id: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Job.isFinished() {
+			return resp.Job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job is not finished: %v", resp.Job)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	cfg := &GiTrimConfig{JobRetrySecs: 10, JobMaxRetrySecs: 60}
+
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{attempts: 1, want: 10 * time.Second},
+		{attempts: 2, want: 20 * time.Second},
+		{attempts: 3, want: 40 * time.Second},
+		{attempts: 4, want: 60 * time.Second},
+		{attempts: 50, want: 60 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.jobRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("jobRetryDelay(%d): want %s, got %s", tt.attempts, tt.want, got)
+		}
+	}
+}
+
+func TestSvc_jobQueue(t *testing.T) {
+	ctx := context.Background()
+
+	fromroot := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(fromroot, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+
+	toroot := t.TempDir()
+	newLocalRepo(t, filepath.Join(toroot, "org", "to.git"), true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"from": {RemoteName: "from", RemoteType: RemoteConfig_LOCAL, RemoteUrl: fromroot},
+			"to":   {RemoteName: "to", RemoteType: RemoteConfig_LOCAL, RemoteUrl: toroot},
+		},
+		JobMaxAttempts: 2,
+	})
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "from", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "to", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	pushLocal(t, fromwork)
+
+	synctosub := &EnqueueJobRequest{
+		Request: &EnqueueJobRequest_SyncToSubRepo{SyncToSubRepo: &SyncToSubRepoRequest{Id: initresp.Id}},
+	}
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, req := range []*EnqueueJobRequest{
+			{},
+			{Request: &EnqueueJobRequest_SyncToSubRepo{SyncToSubRepo: &SyncToSubRepoRequest{}}},
+			{Request: &EnqueueJobRequest_SyncToSubRepo{SyncToSubRepo: &SyncToSubRepoRequest{Id: initresp.Id, OverrideToBranch: "dev"}}},
+		} {
+			if _, err := s.EnqueueJob(ctx, req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("want invalid argument for %v, got %v", req, err)
+			}
+		}
+
+		unknown := &EnqueueJobRequest{
+			Request: &EnqueueJobRequest_CommitsFromSubRepo{CommitsFromSubRepo: &CommitsFromSubRepoRequest{Id: "00"}},
+		}
+		if _, err := s.EnqueueJob(ctx, unknown); status.Code(err) != codes.NotFound {
+			t.Errorf("want not found, got %v", err)
+		}
+		if _, err := s.GetJob(ctx, &GetJobRequest{Id: 1000}); status.Code(err) != codes.NotFound {
+			t.Errorf("want not found, got %v", err)
+		}
+	})
+
+	var jobid uint64
+	t.Run("enqueue", func(t *testing.T) {
+		first, err := s.EnqueueJob(ctx, synctosub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first.Job.State != Job_PENDING || first.Job.RepoSyncId != initresp.Id {
+			t.Fatalf("unexpected job: %v", first.Job)
+		}
+		jobid = first.Job.Id
+
+		second, err := s.EnqueueJob(ctx, synctosub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if second.Job.Id != jobid {
+			t.Errorf("want pending job %d returned, got %d", jobid, second.Job.Id)
+		}
+	})
+
+	runTestJobWorkers(t, s)
+
+	t.Run("run", func(t *testing.T) {
+		job := waitTestJob(t, s, jobid)
+		if job.State != Job_SUCCEEDED || job.Attempts != 1 {
+			t.Fatalf("want succeeded in 1 attempt, got %v", job)
+		}
+		if job.GetSyncToSubRepoResult().GetNumberOfNewCommits() != 1 {
+			t.Errorf("want 1 new commit, got %v", job.GetSyncToSubRepoResult())
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		commitsfromsub := &EnqueueJobRequest{
+			Request: &EnqueueJobRequest_CommitsFromSubRepo{CommitsFromSubRepo: &CommitsFromSubRepoRequest{Id: initresp.Id}},
+		}
+		resp, err := s.EnqueueJob(ctx, commitsfromsub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Job.Id == jobid {
+			t.Fatal("different request is merged into the finished job")
+		}
+		waitTestJob(t, s, resp.Job.Id)
+
+		list, err := s.ListJobs(ctx, &ListJobsRequest{RepoSyncId: initresp.Id, PageSize: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.Jobs) != 1 || list.Jobs[0].Id != resp.Job.Id || list.NextPageToken == "" {
+			t.Fatalf("want the most recent job first, got %v", list)
+		}
+		list, err = s.ListJobs(ctx, &ListJobsRequest{RepoSyncId: initresp.Id, PageSize: 1, PageToken: list.NextPageToken})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.Jobs) != 1 || list.Jobs[0].Id != jobid || list.NextPageToken != "" {
+			t.Fatalf("want the first job on the second page, got %v", list)
+		}
+
+		list, err = s.ListJobs(ctx, &ListJobsRequest{States: []Job_State{Job_FAILED, Job_PENDING}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list.Jobs) != 0 {
+			t.Errorf("want no failed or pending jobs, got %v", list.Jobs)
+		}
+	})
+}
+
+func TestSvc_jobRetry(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(root, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n"}, "first")
+	pushLocal(t, fromwork)
+	newLocalRepo(t, filepath.Join(root, "org", "to"), true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"local": {RemoteName: "local", RemoteType: RemoteConfig_LOCAL, RemoteUrl: root},
+		},
+		JobMaxAttempts:   2,
+		JobRetrySecs:     60,
+		JobRetentionSecs: 3600,
+	})
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the remote is unreachable.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis.Close()
+	s.config.Remotes["local"] = &RemoteConfig{RemoteName: "local", RemoteType: RemoteConfig_GITEA, RemoteUrl: "http://" + lis.Addr().String()}
+
+	resp, err := s.EnqueueJob(ctx, &EnqueueJobRequest{
+		Request: &EnqueueJobRequest_SyncToSubRepo{SyncToSubRepo: &SyncToSubRepoRequest{Id: initresp.Id}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+
+	job, _, err := s.claimJob(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job == nil || job.Id != resp.Job.Id || job.State != Job_RUNNING {
+		t.Fatalf("want job %d claimed, got %v", resp.Job.Id, job)
+	}
+	s.runJob(ctx, job)
+
+	job, err = getJobFromDb(s.db, resp.Job.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.State != Job_PENDING || job.Attempts != 1 || job.LastError == "" {
+		t.Fatalf("want job pending for retry, got %v", job)
+	}
+	if job.NextAttemptAt < now.Add(time.Minute).Unix() {
+		t.Errorf("want retry after a minute, got %d", job.NextAttemptAt-now.Unix())
+	}
+
+	job, wait, err := s.claimJob(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job != nil {
+		t.Fatalf("job claimed before retry: %v", job)
+	}
+	if wait <= 0 || wait > time.Minute+time.Second {
+		t.Errorf("want wait for the retry, got %s", wait)
+	}
+
+	// the process restarts while the retry is running.
+	now = now.Add(2 * time.Minute)
+	if job, _, err = s.claimJob(now); err != nil || job == nil {
+		t.Fatalf("job is not claimed for retry: %v %v", job, err)
+	}
+	if err := s.db.Update(resetRunningJobsFunc(now)); err != nil {
+		t.Fatal(err)
+	}
+	if job, _, err = s.claimJob(now); err != nil || job == nil {
+		t.Fatalf("interrupted job is not claimed again: %v %v", job, err)
+	}
+	if job.Attempts != 3 {
+		t.Errorf("want 3 attempts, got %d", job.Attempts)
+	}
+
+	s.runJob(ctx, job)
+	job, err = getJobFromDb(s.db, resp.Job.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.State != Job_FAILED {
+		t.Fatalf("want job failed after max attempts, got %v", job)
+	}
+
+	// finished jobs are deleted after the retention.
+	if _, _, err := s.claimJob(time.Now().Add(2 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if job, err := getJobFromDb(s.db, resp.Job.Id); err != nil || job != nil {
+		t.Errorf("want job deleted, got %v %v", job, err)
+	}
+}
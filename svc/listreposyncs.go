@@ -0,0 +1,105 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultListPageSize = 100
+	maxListPageSize     = 1000
+)
+
+// getPageSize returns the proper page size for the requested one.
+func getPageSize(size int32) (int, error) {
+	switch {
+	case size < 0:
+		return 0, status.Errorf(codes.InvalidArgument, "negative page size: %d", size)
+	case size == 0:
+		return defaultListPageSize, nil
+	case size > maxListPageSize:
+		return maxListPageSize, nil
+	default:
+		return int(size), nil
+	}
+}
+
+// matchGitRepo checks if the non-empty fields of the request match the repo.
+func (req *ListRepoSyncsRequest) matchGitRepo(repo *GitRepoIdentifier) bool {
+	return (req.RemoteName == "" || req.RemoteName == repo.GetRemoteName()) &&
+		(req.Owner == "" || req.Owner == repo.GetOwner()) &&
+		(req.Repo == "" || req.Repo == repo.GetRepo())
+}
+
+func (req *ListRepoSyncsRequest) match(reposync *RepoSync) bool {
+	return req.matchGitRepo(reposync.FromRepo) || req.matchGitRepo(reposync.ToRepo)
+}
+
+func (s *Svc) ListRepoSyncs(ctx context.Context, req *ListRepoSyncsRequest) (*ListRepoSyncsResponse, error) {
+	pagesize, err := getPageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// page token is the id of the last repo sync in the previous page.
+	var after []byte
+	if req.PageToken != "" {
+		after, err = hex.DecodeString(req.PageToken)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %s", err.Error())
+		}
+	}
+
+	canread := s.canReadFunc(ctx)
+	resp := &ListRepoSyncsResponse{}
+
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(REPO_SYNC_BUCKET))
+		if b == nil {
+			return nil
+		}
+
+		var lastkey []byte
+		c := b.Cursor()
+		k, v := c.First()
+		if after != nil {
+			k, v = c.Seek(after)
+			if k != nil && bytes.Equal(k, after) {
+				k, v = c.Next()
+			}
+		}
+
+		for ; k != nil; k, v = c.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			reposync := &DbRepoSync{}
+			if err := proto.Unmarshal(v, reposync); err != nil {
+				return err
+			}
+			if !req.match(reposync.SyncData) || !canread(reposync.SyncData.Id) {
+				continue
+			}
+
+			if len(resp.RepoSyncs) == pagesize {
+				resp.NextPageToken = hex.EncodeToString(lastkey)
+				return nil
+			}
+			resp.RepoSyncs = append(resp.RepoSyncs, reposync.SyncData)
+			lastkey = k
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
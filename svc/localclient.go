@@ -0,0 +1,316 @@
+package svc
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// localClient implements [GiTrimClient] by calling the methods of [Svc] directly.
+type localClient struct {
+	s *Svc
+}
+
+var _ GiTrimClient = (*localClient)(nil)
+
+// NewLocalClient returns a [GiTrimClient] that calls s in process, so that the same code works against a
+// local database or a remote server. The call options are ignored.
+func NewLocalClient(s *Svc) GiTrimClient {
+	return &localClient{s: s}
+}
+
+func (c *localClient) InitRepoSync(ctx context.Context, in *InitRepoSyncRequest, _ ...grpc.CallOption) (*InitRepoSyncResponse, error) {
+	return c.s.InitRepoSync(ctx, in)
+}
+
+func (c *localClient) SyncToSubRepo(ctx context.Context, in *SyncToSubRepoRequest, _ ...grpc.CallOption) (*SyncToSubRepoResponse, error) {
+	return c.s.SyncToSubRepo(ctx, in)
+}
+
+func (c *localClient) CommitsFromSubRepo(ctx context.Context, in *CommitsFromSubRepoRequest, _ ...grpc.CallOption) (*CommitsFromSubRepoResponse, error) {
+	return c.s.CommitsFromSubRepo(ctx, in)
+}
+
+func (c *localClient) CheckRepoSyncUpToDate(ctx context.Context, in *CheckRepoSyncUpToDateRequest, _ ...grpc.CallOption) (*CheckRepoSyncUpToDateResponse, error) {
+	return c.s.CheckRepoSyncUpToDate(ctx, in)
+}
+
+func (c *localClient) CheckCommitsFromSubRepo(ctx context.Context, in *CheckCommitsFromSubRepoRequest, _ ...grpc.CallOption) (*CheckCommitsFromSubRepoResponse, error) {
+	return c.s.CheckCommitsFromSubRepo(ctx, in)
+}
+
+func (c *localClient) GetRepoSync(ctx context.Context, in *GetRepoSyncRequest, _ ...grpc.CallOption) (*GetRepoSyncResponse, error) {
+	return c.s.GetRepoSync(ctx, in)
+}
+
+func (c *localClient) CommitsFromPatches(ctx context.Context, in *CommitsFromPatchesRequest, _ ...grpc.CallOption) (*CommitsFromPatchesResponse, error) {
+	return c.s.CommitsFromPatches(ctx, in)
+}
+
+func (c *localClient) SyncToSubRepoBundle(ctx context.Context, in *SyncToSubRepoBundleRequest, _ ...grpc.CallOption) (GiTrim_SyncToSubRepoBundleClient, error) {
+	return newLocalServerStream(ctx, func(stream *localServerStreamServer[SyncToSubRepoBundleResponse]) error {
+		return c.s.SyncToSubRepoBundle(in, stream)
+	}), nil
+}
+
+func (c *localClient) ListRepoSyncs(ctx context.Context, in *ListRepoSyncsRequest, _ ...grpc.CallOption) (*ListRepoSyncsResponse, error) {
+	return c.s.ListRepoSyncs(ctx, in)
+}
+
+func (c *localClient) UpdateRepoSync(ctx context.Context, in *UpdateRepoSyncRequest, _ ...grpc.CallOption) (*UpdateRepoSyncResponse, error) {
+	return c.s.UpdateRepoSync(ctx, in)
+}
+
+func (c *localClient) DeleteRepoSync(ctx context.Context, in *DeleteRepoSyncRequest, _ ...grpc.CallOption) (*DeleteRepoSyncResponse, error) {
+	return c.s.DeleteRepoSync(ctx, in)
+}
+
+func (c *localClient) UpdateRepoSyncFilter(ctx context.Context, in *UpdateRepoSyncFilterRequest, _ ...grpc.CallOption) (*UpdateRepoSyncFilterResponse, error) {
+	return c.s.UpdateRepoSyncFilter(ctx, in)
+}
+
+func (c *localClient) EnqueueJob(ctx context.Context, in *EnqueueJobRequest, _ ...grpc.CallOption) (*EnqueueJobResponse, error) {
+	return c.s.EnqueueJob(ctx, in)
+}
+
+func (c *localClient) GetJob(ctx context.Context, in *GetJobRequest, _ ...grpc.CallOption) (*GetJobResponse, error) {
+	return c.s.GetJob(ctx, in)
+}
+
+func (c *localClient) ListJobs(ctx context.Context, in *ListJobsRequest, _ ...grpc.CallOption) (*ListJobsResponse, error) {
+	return c.s.ListJobs(ctx, in)
+}
+
+func (c *localClient) WatchSyncEvents(ctx context.Context, in *WatchSyncEventsRequest, _ ...grpc.CallOption) (GiTrim_WatchSyncEventsClient, error) {
+	return newLocalServerStream(ctx, func(stream *localServerStreamServer[SyncEvent]) error {
+		return c.s.WatchSyncEvents(in, stream)
+	}), nil
+}
+
+// newLocalServerStream runs the server side of an in process server streaming call with f, and returns the client
+// side.
+func newLocalServerStream[T any](ctx context.Context, f func(stream *localServerStreamServer[T]) error) *localServerStream[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &localServerStream[T]{
+		ctx:  ctx,
+		msgs: make(chan *T),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer cancel()
+		stream.err = f(&localServerStreamServer[T]{stream})
+		if stream.err == nil {
+			stream.err = io.EOF
+		}
+		close(stream.done)
+	}()
+
+	return stream
+}
+
+// localServerStream is the client side of an in process server streaming call.
+type localServerStream[T any] struct {
+	ctx context.Context
+
+	// msgs is unbuffered, so all the messages are received once done is closed.
+	msgs chan *T
+	// done is closed when the server side returns with err.
+	done chan struct{}
+	err  error
+}
+
+var (
+	_ GiTrim_WatchSyncEventsClient     = (*localServerStream[SyncEvent])(nil)
+	_ GiTrim_SyncToSubRepoBundleClient = (*localServerStream[SyncToSubRepoBundleResponse])(nil)
+	_ GiTrim_BackupClient              = (*localServerStream[BackupResponse])(nil)
+	_ GiTrim_ExportClient              = (*localServerStream[ExportedRecord])(nil)
+)
+
+func (s *localServerStream[T]) Recv() (*T, error) {
+	select {
+	case m := <-s.msgs:
+		return m, nil
+	case <-s.done:
+		return nil, s.err
+	}
+}
+
+func (s *localServerStream[T]) Header() (metadata.MD, error) { return nil, nil }
+func (s *localServerStream[T]) Trailer() metadata.MD         { return nil }
+func (s *localServerStream[T]) CloseSend() error             { return nil }
+func (s *localServerStream[T]) Context() context.Context     { return s.ctx }
+func (s *localServerStream[T]) SendMsg(any) error            { return nil }
+
+func (s *localServerStream[T]) RecvMsg(m any) error {
+	v, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	proto.Merge(m.(proto.Message), any(v).(proto.Message))
+	return nil
+}
+
+// localServerStreamServer is the server side of an in process server streaming call.
+type localServerStreamServer[T any] struct {
+	s *localServerStream[T]
+}
+
+var (
+	_ GiTrim_WatchSyncEventsServer     = (*localServerStreamServer[SyncEvent])(nil)
+	_ GiTrim_SyncToSubRepoBundleServer = (*localServerStreamServer[SyncToSubRepoBundleResponse])(nil)
+	_ GiTrim_BackupServer              = (*localServerStreamServer[BackupResponse])(nil)
+	_ GiTrim_ExportServer              = (*localServerStreamServer[ExportedRecord])(nil)
+)
+
+func (s *localServerStreamServer[T]) Send(m *T) error {
+	select {
+	case s.s.msgs <- m:
+		return nil
+	case <-s.s.ctx.Done():
+		return s.s.ctx.Err()
+	}
+}
+
+func (s *localServerStreamServer[T]) SetHeader(metadata.MD) error  { return nil }
+func (s *localServerStreamServer[T]) SendHeader(metadata.MD) error { return nil }
+func (s *localServerStreamServer[T]) SetTrailer(metadata.MD)       {}
+func (s *localServerStreamServer[T]) Context() context.Context     { return s.s.ctx }
+func (s *localServerStreamServer[T]) SendMsg(m any) error          { return s.Send(m.(*T)) }
+func (s *localServerStreamServer[T]) RecvMsg(any) error            { return io.EOF }
+
+func (c *localClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, _ ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	return c.s.ListAuditEvents(ctx, in)
+}
+
+func (c *localClient) RotateSecret(ctx context.Context, in *RotateSecretRequest, _ ...grpc.CallOption) (*RotateSecretResponse, error) {
+	return c.s.RotateSecret(ctx, in)
+}
+
+func (c *localClient) Backup(ctx context.Context, in *BackupRequest, _ ...grpc.CallOption) (GiTrim_BackupClient, error) {
+	return newLocalServerStream(ctx, func(stream *localServerStreamServer[BackupResponse]) error {
+		return c.s.Backup(in, stream)
+	}), nil
+}
+
+func (c *localClient) Export(ctx context.Context, in *ExportRequest, _ ...grpc.CallOption) (GiTrim_ExportClient, error) {
+	return newLocalServerStream(ctx, func(stream *localServerStreamServer[ExportedRecord]) error {
+		return c.s.Export(in, stream)
+	}), nil
+}
+
+func (c *localClient) Import(ctx context.Context, _ ...grpc.CallOption) (GiTrim_ImportClient, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream := &localImportStream{
+		ctx:    ctx,
+		reqs:   make(chan *ImportRequest),
+		closed: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer cancel()
+		stream.err = c.s.Import(&localImportServerStream{stream})
+		if stream.err == nil && stream.resp == nil {
+			stream.err = status.Error(codes.Internal, "import returned without a response")
+		}
+		close(stream.done)
+	}()
+
+	return stream, nil
+}
+
+// localImportStream is the client side of an in process [Svc.Import].
+type localImportStream struct {
+	ctx context.Context
+
+	// reqs is unbuffered, closed is closed by CloseSend after the last request.
+	reqs      chan *ImportRequest
+	closed    chan struct{}
+	closeOnce sync.Once
+	// done is closed when the server side returns with resp or err.
+	done chan struct{}
+	resp *ImportResponse
+	err  error
+}
+
+var _ GiTrim_ImportClient = (*localImportStream)(nil)
+
+func (s *localImportStream) Send(req *ImportRequest) error {
+	select {
+	case s.reqs <- req:
+		return nil
+	case <-s.done:
+		// the error is returned by CloseAndRecv, same as grpc.
+		return io.EOF
+	}
+}
+
+func (s *localImportStream) CloseSend() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+func (s *localImportStream) CloseAndRecv() (*ImportResponse, error) {
+	s.CloseSend()
+	<-s.done
+	return s.resp, s.err
+}
+
+func (s *localImportStream) Header() (metadata.MD, error) { return nil, nil }
+func (s *localImportStream) Trailer() metadata.MD         { return nil }
+func (s *localImportStream) Context() context.Context     { return s.ctx }
+func (s *localImportStream) SendMsg(m any) error          { return s.Send(m.(*ImportRequest)) }
+
+func (s *localImportStream) RecvMsg(m any) error {
+	resp, err := s.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	proto.Merge(m.(*ImportResponse), resp)
+	return nil
+}
+
+// localImportServerStream is the server side of an in process [Svc.Import].
+type localImportServerStream struct {
+	s *localImportStream
+}
+
+var _ GiTrim_ImportServer = (*localImportServerStream)(nil)
+
+func (s *localImportServerStream) Recv() (*ImportRequest, error) {
+	select {
+	case req := <-s.s.reqs:
+		return req, nil
+	case <-s.s.closed:
+		return nil, io.EOF
+	case <-s.s.ctx.Done():
+		return nil, s.s.ctx.Err()
+	}
+}
+
+func (s *localImportServerStream) SendAndClose(resp *ImportResponse) error {
+	s.s.resp = resp
+	return nil
+}
+
+func (s *localImportServerStream) SetHeader(metadata.MD) error  { return nil }
+func (s *localImportServerStream) SendHeader(metadata.MD) error { return nil }
+func (s *localImportServerStream) SetTrailer(metadata.MD)       {}
+func (s *localImportServerStream) Context() context.Context     { return s.s.ctx }
+func (s *localImportServerStream) SendMsg(m any) error          { return s.SendAndClose(m.(*ImportResponse)) }
+
+func (s *localImportServerStream) RecvMsg(m any) error {
+	req, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	proto.Merge(m.(*ImportRequest), req)
+	return nil
+}
@@ -0,0 +1,77 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewLocalClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	root := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(root, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+	newLocalRepo(t, filepath.Join(root, "org", "to"), true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"local": {RemoteName: "local", RemoteUrl: root},
+		},
+	})
+	client := NewLocalClient(s)
+
+	t.Run("unary", func(t *testing.T) {
+		initresp, err := client.InitRepoSync(ctx, &InitRepoSyncRequest{
+			FromRepo:   &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+			FromBranch: "main",
+			ToRepo:     &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+			ToBranch:   "main",
+			Filter:     "a/",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: initresp.Id})
+		if err != nil {
+			t.Fatalf("repo sync is not created by the local client: %v", err)
+		}
+		got, err := client.GetRepoSync(ctx, &GetRepoSyncRequest{Id: initresp.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(want, got) {
+			t.Errorf("want %v, got %v", want, got)
+		}
+
+		_, err = client.GetRepoSync(ctx, &GetRepoSyncRequest{Id: "00"})
+		wantCode(t, err, codes.NotFound)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		streamctx, streamcancel := context.WithCancel(ctx)
+		stream, err := client.WatchSyncEvents(streamctx, &WatchSyncEventsRequest{Resume: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the events of the init in the unary test are resumed.
+		filtered := recvSyncEvent(t, stream, SyncEvent_COMMITS_FILTERED)
+		pushed := recvSyncEvent(t, stream, SyncEvent_PUSHED)
+		if pushed.RepoSyncId != filtered.RepoSyncId || pushed.Sequence != filtered.Sequence+1 {
+			t.Errorf("unexpected events %v and %v", filtered, pushed)
+		}
+
+		streamcancel()
+		if _, err := stream.Recv(); !errors.Is(err, context.Canceled) {
+			t.Errorf("want canceled after the context is done, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func TestLocalRepoPath(t *testing.T) {
+	root := t.TempDir()
+	newLocalRepo(t, filepath.Join(root, "org", "plain"), true)
+	newLocalRepo(t, filepath.Join(root, "org", "suffixed.git"), true)
+	newLocalRepo(t, filepath.Join(root, "org", "nonbare"), false)
+
+	tests := []struct {
+		name    string
+		cfg     *RemoteConfig
+		repo    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "dir",
+			cfg:  &RemoteConfig{RemoteType: RemoteConfig_LOCAL, RemoteUrl: root},
+			repo: "plain",
+			want: filepath.Join(root, "org", "plain"),
+		},
+		{
+			name: "file url with suffix",
+			cfg:  &RemoteConfig{RemoteType: RemoteConfig_LOCAL, RemoteUrl: "file://" + root},
+			repo: "suffixed",
+			want: filepath.Join(root, "org", "suffixed.git"),
+		},
+		{
+			name: "template",
+			cfg:  &RemoteConfig{RemoteType: RemoteConfig_LOCAL, UrlTemplate: "file://" + root + "/{owner}/{repo}.git"},
+			repo: "suffixed",
+			want: filepath.Join(root, "org", "suffixed.git"),
+		},
+		{
+			name:    "non bare",
+			cfg:     &RemoteConfig{RemoteType: RemoteConfig_LOCAL, RemoteUrl: root},
+			repo:    "nonbare",
+			wantErr: true,
+		},
+		{
+			name:    "missing",
+			cfg:     &RemoteConfig{RemoteType: RemoteConfig_LOCAL, RemoteUrl: root},
+			repo:    "missing",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := constructPullUrl(tt.cfg, "org", tt.repo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	// a repo outside of the root.
+	newLocalRepo(t, filepath.Join(filepath.Dir(root), "other"), true)
+	for _, cfg := range []*RemoteConfig{
+		{RemoteType: RemoteConfig_LOCAL, RemoteUrl: root},
+		{RemoteType: RemoteConfig_LOCAL, UrlTemplate: "file://" + root + "/{owner}/{repo}"},
+	} {
+		for _, names := range [][2]string{{"..", "other"}, {"../..", "other"}, {"org", ".."}, {".", "plain"}, {`..\org`, "plain"}} {
+			if got, err := constructPullUrl(cfg, names[0], names[1]); err == nil {
+				t.Errorf("want %s/%s rejected for %v, got %s", names[0], names[1], cfg, got)
+			}
+		}
+	}
+	if !isUnderRoot(root, filepath.Join(root, "org", "plain")) || isUnderRoot(root, filepath.Join(root, "..", "other")) {
+		t.Error("unexpected check of the root")
+	}
+
+	if _, err := (&RemoteConfig{RemoteType: RemoteConfig_LOCAL, Username: "user"}).auth(); err != ErrAuthForLocalRemote {
+		t.Errorf("want auth error for local remote, got %v", err)
+	}
+}
+
+func TestSvc_localRemote(t *testing.T) {
+	ctx := context.Background()
+
+	fromroot := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(fromroot, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+
+	toroot := t.TempDir()
+	todir := filepath.Join(toroot, "org", "to.git")
+	newLocalRepo(t, todir, true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"from": {RemoteName: "from", RemoteType: RemoteConfig_LOCAL, RemoteUrl: fromroot},
+			"to":   {RemoteName: "to", RemoteType: RemoteConfig_LOCAL, RemoteUrl: "file://" + toroot},
+		},
+	})
+
+	// the to repo of a sync cannot be outside of the root of the remote.
+	_, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "from", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "from", Owner: "..", Repo: filepath.Base(toroot)},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if !errors.Is(err, ErrInvalidOwnerName) {
+		t.Errorf("want owner .. rejected, got %v", err)
+	}
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "from", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "to", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	pushLocal(t, fromwork)
+
+	syncresp, err := s.SyncToSubRepo(ctx, &SyncToSubRepoRequest{Id: initresp.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if syncresp.NumberOfNewCommits != 1 {
+		t.Errorf("want 1 new commit, got %d", syncresp.NumberOfNewCommits)
+	}
+
+	towork, err := git.PlainClone(filepath.Join(t.TempDir(), "towork"), false, &git.CloneOptions{URL: todir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitLocalFiles(t, towork, map[string]string{"a/z.txt": "z\n"}, "from sub repo")
+	pushLocal(t, towork)
+
+	commitsresp, err := s.CommitsFromSubRepo(ctx, &CommitsFromSubRepoRequest{Id: initresp.Id, DoPush: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commitsresp.Result != SubRepoCommitsCheck_CHECK_PASSED || len(commitsresp.NewCommits) != 1 {
+		t.Fatalf("unexpected response: %v", commitsresp)
+	}
+
+	if err := fromwork.Fetch(&git.FetchOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	ref, err := fromwork.Reference(plumbing.NewRemoteReferenceName(remotename, "main"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Hash().String() != commitsresp.NewCommits[0] {
+		t.Errorf("want from repo at %s, got %s", commitsresp.NewCommits[0], ref.Hash())
+	}
+	c, err := fromwork.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.File("a/z.txt"); err != nil {
+		t.Errorf("commit from sub repo is not in from repo: %v", err)
+	}
+	if _, err := c.File("b/y.txt"); err != nil {
+		t.Errorf("files outside of filter are lost: %v", err)
+	}
+
+	uptodate, err := s.CheckRepoSyncUpToDate(ctx, &CheckRepoSyncUpToDateRequest{Id: initresp.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uptodate.FromRepoStatus != LastSyncCommitStatus_INSYNC || uptodate.ToRepoStatus != LastSyncCommitStatus_INSYNC {
+		t.Errorf("repos are not in sync: %v", uptodate)
+	}
+}
@@ -0,0 +1,255 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.etcd.io/bbolt"
+
+	"github.com/fardream/gitrim"
+)
+
+const metricsNamespace = "gitrim"
+
+var ErrEmptyMetricsAddress = errors.New("empty metrics address")
+
+// operations and outcomes of the syncs in gitrim_syncs_total.
+const (
+	syncOperationToSubRepo       = "to_sub_repo"
+	syncOperationToSubRepoBundle = "to_sub_repo_bundle"
+	syncOperationFromSubRepo     = "from_sub_repo"
+	syncOperationFromPatches     = "from_patches"
+
+	syncOutcomeSynced    = "synced"
+	syncOutcomeUpToDate  = "up_to_date"
+	syncOutcomeChecked   = "checked"
+	syncOutcomeRejected  = "rejected"
+	syncOutcomeNotInSync = "not_in_sync"
+	syncOutcomeFailed    = "failed"
+)
+
+// the metrics are shared by all the [Svc] in the process, and each [Svc] registers them in its own registry
+// together with the stats of its db.
+var (
+	syncsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "syncs_total",
+		Help:      "Number of syncs by repo sync id, operation, and outcome.",
+	}, []string{"repo_sync_id", "operation", "outcome"})
+
+	commitsFilteredTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "commits_filtered_total",
+		Help:      "Number of commits of from repo filtered into to repo by repo sync id.",
+	}, []string{"repo_sync_id"})
+
+	fetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "fetch_duration_seconds",
+		Help:      "Duration of the fetches from the remotes.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"remote"})
+
+	pushDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "push_duration_seconds",
+		Help:      "Duration of the pushes to the remotes.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"remote"})
+
+	filterCacheLookupsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "filter_cache_lookups_total",
+		Help:      "Number of paths looked up in the filter cache by result, hit or miss.",
+	}, []string{"result"})
+
+	idLockWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "id_lock_wait_seconds",
+		Help:      "Time waited to lock a repo sync id.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+	})
+)
+
+// observeSync counts a sync of the repo sync.
+func observeSync(id string, operation string, outcome string) {
+	syncsTotal.WithLabelValues(id, operation, outcome).Inc()
+}
+
+// observeFilterCache adds the stats of the filter if it is a [gitrim.CachedFilter].
+func observeFilterCache(f gitrim.Filter) {
+	cached, ok := f.(*gitrim.CachedFilter)
+	if !ok {
+		return
+	}
+	hits, misses := cached.Stats()
+	filterCacheLookupsTotal.WithLabelValues("hit").Add(float64(hits))
+	filterCacheLookupsTotal.WithLabelValues("miss").Add(float64(misses))
+}
+
+// dbCollector collects the stats of the bbolt db.
+type dbCollector struct {
+	db *bbolt.DB
+
+	readTx        *prometheus.Desc
+	openReadTx    *prometheus.Desc
+	freePages     *prometheus.Desc
+	pendingPages  *prometheus.Desc
+	freeAlloc     *prometheus.Desc
+	freelistInuse *prometheus.Desc
+	pageCount     *prometheus.Desc
+	pageAlloc     *prometheus.Desc
+	cursors       *prometheus.Desc
+	nodes         *prometheus.Desc
+	rebalances    *prometheus.Desc
+	rebalanceTime *prometheus.Desc
+	splits        *prometheus.Desc
+	spills        *prometheus.Desc
+	spillTime     *prometheus.Desc
+	writes        *prometheus.Desc
+	writeTime     *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*dbCollector)(nil)
+
+func newDbCollector(db *bbolt.DB) *dbCollector {
+	desc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "bbolt", name), help, nil, nil)
+	}
+
+	return &dbCollector{
+		db: db,
+
+		readTx:        desc("read_tx_total", "Number of read transactions started."),
+		openReadTx:    desc("open_read_tx", "Number of open read transactions."),
+		freePages:     desc("free_pages", "Number of free pages on the freelist."),
+		pendingPages:  desc("pending_pages", "Number of pending pages on the freelist."),
+		freeAlloc:     desc("free_alloc_bytes", "Bytes allocated in free pages."),
+		freelistInuse: desc("freelist_inuse_bytes", "Bytes used by the freelist."),
+		pageCount:     desc("tx_page_allocations_total", "Number of page allocations of the transactions."),
+		pageAlloc:     desc("tx_page_alloc_bytes_total", "Bytes allocated for pages by the transactions."),
+		cursors:       desc("tx_cursors_total", "Number of cursors created by the transactions."),
+		nodes:         desc("tx_nodes_total", "Number of node allocations of the transactions."),
+		rebalances:    desc("tx_rebalances_total", "Number of node rebalances of the transactions."),
+		rebalanceTime: desc("tx_rebalance_seconds_total", "Time spent rebalancing nodes."),
+		splits:        desc("tx_splits_total", "Number of node splits of the transactions."),
+		spills:        desc("tx_spills_total", "Number of nodes spilled by the transactions."),
+		spillTime:     desc("tx_spill_seconds_total", "Time spent spilling nodes."),
+		writes:        desc("tx_writes_total", "Number of writes to disk by the transactions."),
+		writeTime:     desc("tx_write_seconds_total", "Time spent writing to disk."),
+	}
+}
+
+func (c *dbCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *dbCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.db.Stats()
+	tx := &stats.TxStats
+
+	counter := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v)
+	}
+	gauge := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v)
+	}
+
+	counter(c.readTx, float64(stats.TxN))
+	gauge(c.openReadTx, float64(stats.OpenTxN))
+	gauge(c.freePages, float64(stats.FreePageN))
+	gauge(c.pendingPages, float64(stats.PendingPageN))
+	gauge(c.freeAlloc, float64(stats.FreeAlloc))
+	gauge(c.freelistInuse, float64(stats.FreelistInuse))
+	counter(c.pageCount, float64(tx.GetPageCount()))
+	counter(c.pageAlloc, float64(tx.GetPageAlloc()))
+	counter(c.cursors, float64(tx.GetCursorCount()))
+	counter(c.nodes, float64(tx.GetNodeCount()))
+	counter(c.rebalances, float64(tx.GetRebalance()))
+	counter(c.rebalanceTime, tx.GetRebalanceTime().Seconds())
+	counter(c.splits, float64(tx.GetSplit()))
+	counter(c.spills, float64(tx.GetSpill()))
+	counter(c.spillTime, tx.GetSpillTime().Seconds())
+	counter(c.writes, float64(tx.GetWrite()))
+	counter(c.writeTime, tx.GetWriteTime().Seconds())
+}
+
+// setupMetrics creates the registry of the metrics served by [Svc.ServeMetrics].
+func (s *Svc) setupMetrics() {
+	s.metrics = prometheus.NewRegistry()
+	s.metrics.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		syncsTotal,
+		commitsFilteredTotal,
+		fetchDuration,
+		pushDuration,
+		filterCacheLookupsTotal,
+		idLockWait,
+		newDbCollector(s.db),
+	)
+}
+
+// MetricsHandler returns the handler for the prometheus metrics.
+func (s *Svc) MetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(s.metrics, promhttp.HandlerOpts{}))
+
+	return mux
+}
+
+// ServeMetrics listens on metrics_address and serves the prometheus metrics until ctx is done.
+// See [Svc.ServeMetricsListener].
+func (s *Svc) ServeMetrics(ctx context.Context) error {
+	if s.config.MetricsAddress == "" {
+		return ErrEmptyMetricsAddress
+	}
+
+	lis, err := net.Listen("tcp", s.config.MetricsAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.MetricsAddress, err)
+	}
+
+	return s.ServeMetricsListener(ctx, lis)
+}
+
+// ServeMetricsListener serves the prometheus metrics at /metrics on lis until ctx is done.
+//
+// The metrics include the syncs of each repo sync by outcome, the commits filtered, the durations of the fetches
+// and the pushes, the lookups of the filter cache, the time waited for the lock of the repo sync ids, and the stats of
+// the db.
+func (s *Svc) ServeMetricsListener(ctx context.Context, lis net.Listener) error {
+	server := &http.Server{
+		Handler:           s.MetricsHandler(),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	serveerr := make(chan error, 1)
+	go func() {
+		logger.Info("serving metrics", "address", lis.Addr().String())
+		serveerr <- server.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveerr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down metrics server")
+	// scrapes are short, no need to wait for them.
+	server.Close()
+
+	if err := <-serveerr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package svc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+)
+
+// scrapeTestMetrics gets the metrics from the url, and parses them.
+func scrapeTestMetrics(t *testing.T, url string) map[string]*dto.MetricFamily {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("want 200, got %d", resp.StatusCode)
+	}
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return families
+}
+
+// findTestMetric returns the metric of the family with the labels, or nil if it is not found.
+func findTestMetric(families map[string]*dto.MetricFamily, name string, labels map[string]string) *dto.Metric {
+	family, ok := families[name]
+	if !ok {
+		return nil
+	}
+
+metricloop:
+	for _, m := range family.Metric {
+		for _, l := range m.Label {
+			if v, ok := labels[l.GetName()]; ok && v != l.GetValue() {
+				continue metricloop
+			}
+		}
+		return m
+	}
+
+	return nil
+}
+
+// getTestCounter returns the value of the counter.
+func getTestCounter(t *testing.T, c prometheus.Counter) float64 {
+	t.Helper()
+
+	m := &dto.Metric{}
+	if err := c.Write(m); err != nil {
+		t.Fatal(err)
+	}
+
+	return m.GetCounter().GetValue()
+}
+
+func TestSvc_ServeMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	fromroot := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(fromroot, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+
+	toroot := t.TempDir()
+	newLocalRepo(t, filepath.Join(toroot, "org", "to.git"), true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"metrics-from": {RemoteName: "metrics-from", RemoteType: RemoteConfig_LOCAL, RemoteUrl: fromroot},
+			"metrics-to":   {RemoteName: "metrics-to", RemoteType: RemoteConfig_LOCAL, RemoteUrl: toroot},
+		},
+	})
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "metrics-from", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "metrics-to", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	pushLocal(t, fromwork)
+	for range 2 {
+		if _, err := s.SyncToSubRepo(ctx, &SyncToSubRepoRequest{Id: initresp.Id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.SyncToSubRepo(ctx, &SyncToSubRepoRequest{Id: "00"}); err == nil {
+		t.Fatal("want error for unknown repo sync")
+	}
+
+	events, err := s.events.stored(0, []string{initresp.Id}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var filtered int32
+	for _, e := range events {
+		filtered += e.NumberOfFromCommits
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	servectx, cancel := context.WithCancel(ctx)
+	served := make(chan error, 1)
+	go func() {
+		served <- s.ServeMetricsListener(servectx, lis)
+	}()
+
+	families := scrapeTestMetrics(t, "http://"+lis.Addr().String()+"/metrics")
+
+	counters := []struct {
+		name   string
+		labels map[string]string
+		want   float64
+	}{
+		{"gitrim_syncs_total", map[string]string{"repo_sync_id": initresp.Id, "operation": syncOperationToSubRepo, "outcome": syncOutcomeSynced}, 1},
+		{"gitrim_syncs_total", map[string]string{"repo_sync_id": initresp.Id, "operation": syncOperationToSubRepo, "outcome": syncOutcomeUpToDate}, 1},
+		{"gitrim_syncs_total", map[string]string{"repo_sync_id": "00", "operation": syncOperationToSubRepo, "outcome": syncOutcomeFailed}, 1},
+		{"gitrim_commits_filtered_total", map[string]string{"repo_sync_id": initresp.Id}, float64(filtered)},
+	}
+	for _, c := range counters {
+		m := findTestMetric(families, c.name, c.labels)
+		if m == nil {
+			t.Errorf("%s%v not found", c.name, c.labels)
+			continue
+		}
+		if got := m.GetCounter().GetValue(); got != c.want {
+			t.Errorf("%s%v: want %v, got %v", c.name, c.labels, c.want, got)
+		}
+	}
+
+	histograms := []struct {
+		name   string
+		labels map[string]string
+		want   uint64
+	}{
+		// from and to are fetched by InitRepoSync and each SyncToSubRepo.
+		{"gitrim_fetch_duration_seconds", map[string]string{"remote": "metrics-from"}, 3},
+		{"gitrim_fetch_duration_seconds", map[string]string{"remote": "metrics-to"}, 3},
+		{"gitrim_push_duration_seconds", map[string]string{"remote": "metrics-to"}, 2},
+	}
+	for _, h := range histograms {
+		m := findTestMetric(families, h.name, h.labels)
+		if m == nil {
+			t.Errorf("%s%v not found", h.name, h.labels)
+			continue
+		}
+		if got := m.GetHistogram().GetSampleCount(); got != h.want {
+			t.Errorf("%s%v: want %d samples, got %d", h.name, h.labels, h.want, got)
+		}
+	}
+
+	if m := findTestMetric(families, "gitrim_id_lock_wait_seconds", nil); m == nil || m.GetHistogram().GetSampleCount() == 0 {
+		t.Errorf("want samples of id lock wait, got %v", m)
+	}
+	if m := findTestMetric(families, "gitrim_filter_cache_lookups_total", map[string]string{"result": "miss"}); m == nil || m.GetCounter().GetValue() == 0 {
+		t.Errorf("want filter cache misses, got %v", m)
+	}
+	if m := findTestMetric(families, "gitrim_filter_cache_lookups_total", map[string]string{"result": "hit"}); m == nil {
+		t.Error("filter cache hits not found")
+	}
+	for _, name := range []string{"gitrim_bbolt_tx_writes_total", "gitrim_bbolt_read_tx_total"} {
+		if m := findTestMetric(families, name, nil); m == nil || m.GetCounter().GetValue() == 0 {
+			t.Errorf("want %s, got %v", name, m)
+		}
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Fatal(err)
+	}
+}
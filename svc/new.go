@@ -2,8 +2,9 @@ package svc
 
 func New(cfg *GiTrimConfig) (*Svc, error) {
 	svc := &Svc{
-		config:  cfg,
-		idmutex: make(chan map[string]*waitingChan, 1),
+		config:    cfg,
+		idmutex:   make(chan map[string]*waitingChan, 1),
+		jobNotify: make(chan struct{}, 1),
 	}
 
 	svc.idmutex <- make(map[string]*waitingChan)
@@ -11,13 +12,35 @@ func New(cfg *GiTrimConfig) (*Svc, error) {
 	if svc.config.Remotes == nil {
 		svc.config.Remotes = make(map[string]*RemoteConfig)
 	}
+	if err := svc.setupAuth(); err != nil {
+		return nil, err
+	}
+	if err := svc.setupTLS(); err != nil {
+		return nil, err
+	}
 	if err := svc.setupDb(); err != nil {
 		return nil, err
 	}
+	if err := svc.migrateDb(); err != nil {
+		return nil, err
+	}
+
+	svc.events = newEventBus(svc.db, cfg.MaxSyncEvents)
+	svc.audit = newAuditLog(svc.db)
+	svc.setupMetrics()
 
 	if err := svc.setupCipher(); err != nil {
 		return nil, err
 	}
+	if err := svc.checkSecretKeys(); err != nil {
+		return nil, err
+	}
+
+	if err := svc.setupObjectCache(); err != nil {
+		return nil, err
+	}
+
+	svc.setupWebhook()
 
 	return svc, nil
 }
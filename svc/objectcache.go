@@ -0,0 +1,315 @@
+package svc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// maxCachePacks is the number of packfiles in a cached repo, above which the packfiles are repacked into one.
+// Each incremental fetch adds a packfile.
+const maxCachePacks = 32
+
+// cacheRepoSuffix is the suffix of the directories of cached repos.
+const cacheRepoSuffix = ".git"
+
+// objectCache keeps a bare repo on disk for each remote repo, so the objects are fetched incrementally instead of
+// cloning the whole branch for each operation.
+//
+// The cached repos are only written to when fetching, and a fetch holds the lock of the repo. Workspaces read the
+// objects of the cached repo through their own storage, and keep the new objects and the references in memory,
+// see [overlayStorage].
+//
+// A cached repo is in use from the fetch until the workspace is closed. When the total size of the cache is over
+// the limit, the least recently fetched repos that are not in use are deleted.
+type objectCache struct {
+	dir      string
+	maxBytes int64
+
+	// mu guards repos, and the users of each repo.
+	mu    sync.Mutex
+	repos map[string]*cachedRepo
+}
+
+// cachedRepo is a bare repo in the cache.
+type cachedRepo struct {
+	path string
+
+	// fetchmu serializes the fetches into the repo.
+	fetchmu sync.Mutex
+	// number of workspaces using the repo.
+	users int
+}
+
+// newObjectCache creates the cache in dir. maxBytes <= 0 means there is no limit on the size of the cache.
+func newObjectCache(dir string, maxBytes int64) (*objectCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir %s: %w", dir, err)
+	}
+
+	c := &objectCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		repos:    make(map[string]*cachedRepo),
+	}
+
+	if err := c.gc(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (s *Svc) setupObjectCache() error {
+	if s.config.CacheDir == "" {
+		return nil
+	}
+
+	c, err := newObjectCache(s.config.CacheDir, s.config.CacheMaxBytes)
+	if err != nil {
+		return err
+	}
+	s.objectCache = c
+
+	return nil
+}
+
+// repoPath returns the path of the cached repo for url.
+func (c *objectCache) repoPath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+cacheRepoSuffix)
+}
+
+// acquire marks the repo for url in use, the returned function releases it.
+func (c *objectCache) acquire(url string) (*cachedRepo, func()) {
+	path := c.repoPath(url)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	r, found := c.repos[path]
+	if !found {
+		r = &cachedRepo{path: path}
+		c.repos[path] = r
+	}
+	r.users++
+
+	var once sync.Once
+	return r, func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			r.users--
+		})
+	}
+}
+
+// isOnlyUser checks if the caller is the only user of the repo.
+func (c *objectCache) isOnlyUser(r *cachedRepo) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return r.users == 1
+}
+
+// fetch updates the branch of the cached repo for the url, and returns a storage to read the objects of the cached
+// repo and the head of the branch. The head is zero if the remote repo or the branch is empty.
+//
+// The returned function must be called once the storage is no longer used.
+func (c *objectCache) fetch(
+	ctx context.Context,
+	url string,
+	branch string,
+	auth transport.AuthMethod,
+) (*filesystem.Storage, plumbing.Hash, func(), error) {
+	r, release := c.acquire(url)
+
+	head, err := c.fetchLocked(ctx, r, url, branch, auth)
+	if err != nil {
+		release()
+		return nil, plumbing.ZeroHash, nil, err
+	}
+
+	if err := c.gc(); err != nil {
+		logger.Warn("failed to gc object cache", "err", err)
+	}
+
+	return filesystem.NewStorage(osfs.New(r.path), cache.NewObjectLRUDefault()), head, release, nil
+}
+
+func (c *objectCache) fetchLocked(
+	ctx context.Context,
+	r *cachedRepo,
+	url string,
+	branch string,
+	auth transport.AuthMethod,
+) (plumbing.Hash, error) {
+	r.fetchmu.Lock()
+	defer r.fetchmu.Unlock()
+
+	repo, err := git.PlainOpen(r.path)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		logger.Info("creating cached repo", "remote", url, "path", r.path)
+		repo, err = git.PlainInit(r.path, true)
+	}
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to open cached repo %s: %w", r.path, err)
+	}
+
+	remote, err := repo.Remote(remotename)
+	if err == nil && !slices.Equal(remote.Config().URLs, []string{url}) {
+		if err := repo.DeleteRemote(remotename); err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to reset remote of cached repo: %w", err)
+		}
+		err = git.ErrRemoteNotFound
+	}
+	if errors.Is(err, git.ErrRemoteNotFound) {
+		_, err = repo.CreateRemote(&config.RemoteConfig{Name: remotename, URLs: []string{url}})
+	}
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to set remote of cached repo: %w", err)
+	}
+
+	remoteref := plumbing.NewRemoteReferenceName(remotename, branch)
+
+	logger.Info("fetching into cached repo", "remote", url, "branch", branch, "path", r.path)
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: remotename,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf(refSpecSingleBranchRemote, branch, remotename))},
+		Auth:       auth,
+		Tags:       git.NoTags,
+	})
+	switch {
+	case errors.Is(err, transport.ErrEmptyRemoteRepository) || isNoMatchingRef(err):
+		logger.Warn("empty remote or branch doesn't exist", "remote", url, "branch", branch)
+		if err := repo.Storer.RemoveReference(remoteref); err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to remove stale branch from cached repo: %w", err)
+		}
+		return plumbing.ZeroHash, nil
+	case err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate):
+		return plumbing.ZeroHash, fmt.Errorf("failed to fetch into cached repo: %w", remoteError(err))
+	}
+
+	ref, err := repo.Reference(remoteref, true)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to get branch %s from cached repo: %w", branch, err)
+	}
+
+	// the modification time of the repo decides the order of eviction.
+	now := time.Now()
+	if err := os.Chtimes(r.path, now, now); err != nil {
+		logger.Warn("failed to touch cached repo", "path", r.path, "err", err)
+	}
+
+	// readers hold their own view of the packfiles, so repacking is only safe without other users.
+	// new users wait on the fetch lock before reading.
+	if packs, err := filepath.Glob(filepath.Join(r.path, "objects", "pack", "*.pack")); err == nil && len(packs) > maxCachePacks && c.isOnlyUser(r) {
+		logger.Info("repacking cached repo", "path", r.path, "packs", len(packs))
+		if err := repo.RepackObjects(&git.RepackConfig{}); err != nil {
+			logger.Warn("failed to repack cached repo", "path", r.path, "err", err)
+		}
+	}
+
+	return ref.Hash(), nil
+}
+
+// dirSize returns the total size of the files in dir.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+
+	return size, err
+}
+
+// gc deletes the least recently fetched repos that are not in use until the size of the cache is within the limit.
+func (c *objectCache) gc() error {
+	if c.maxBytes <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list cache dir: %w", err)
+	}
+
+	type cacheEntry struct {
+		path    string
+		size    int64
+		modtime time.Time
+	}
+
+	var total int64
+	var candidates []cacheEntry
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasSuffix(e.Name(), cacheRepoSuffix) {
+			continue
+		}
+		path := filepath.Join(c.dir, e.Name())
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		size, err := dirSize(path)
+		if err != nil {
+			return err
+		}
+		total += size
+		if r, found := c.repos[path]; found && r.users > 0 {
+			continue
+		}
+		candidates = append(candidates, cacheEntry{path: path, size: size, modtime: info.ModTime()})
+	}
+
+	slices.SortFunc(candidates, func(a, b cacheEntry) int { return a.modtime.Compare(b.modtime) })
+
+	for _, e := range candidates {
+		if total <= c.maxBytes {
+			break
+		}
+		logger.Info("evicting cached repo", "path", e.path, "size", e.size)
+		if err := os.RemoveAll(e.path); err != nil {
+			return fmt.Errorf("failed to evict cached repo %s: %w", e.path, err)
+		}
+		delete(c.repos, e.path)
+		total -= e.size
+	}
+
+	if total > c.maxBytes {
+		logger.Warn("object cache is over the size limit", "size", total, "limit", c.maxBytes)
+	}
+
+	return nil
+}
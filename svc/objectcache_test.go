@@ -0,0 +1,202 @@
+package svc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestSvc_objectCache(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(root, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+	torepo := newLocalRepo(t, filepath.Join(root, "org", "to"), true)
+
+	cachedir := filepath.Join(t.TempDir(), "cache")
+	s := newTestSvc(t, &GiTrimConfig{
+		CacheDir: cachedir,
+		Remotes: map[string]*RemoteConfig{
+			"local": {RemoteName: "local", RemoteUrl: root},
+		},
+	})
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := filepath.Glob(filepath.Join(cachedir, "*"+cacheRepoSuffix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("want 2 cached repos, got %v", repos)
+	}
+
+	fromhead := commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	pushLocal(t, fromwork)
+
+	resp, err := s.SyncToSubRepo(ctx, &SyncToSubRepoRequest{Id: initresp.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.NumberOfNewCommits != 1 {
+		t.Errorf("want 1 new commit, got %d", resp.NumberOfNewCommits)
+	}
+
+	ref, err := torepo.Reference(plumbing.NewBranchReferenceName("main"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Hash().String() != resp.NewHead {
+		t.Errorf("want to repo at %s, got %s", resp.NewHead, ref.Hash())
+	}
+
+	// the new commit is fetched into the cached repo, and the pushed commits are not written to it.
+	var cachedfromhead plumbing.Hash
+	for _, path := range repos {
+		cached, err := git.PlainOpen(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref, err := cached.Reference(plumbing.NewRemoteReferenceName(remotename, "main"), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cached.CommitObject(plumbing.NewHash(resp.NewHead)); err == nil {
+			t.Errorf("pushed commit is written to cached repo %s", path)
+		}
+		if ref.Hash() == fromhead {
+			cachedfromhead = ref.Hash()
+		}
+	}
+	if cachedfromhead.IsZero() {
+		t.Errorf("cached repo of from repo is not updated to %s", fromhead)
+	}
+
+	if s.objectCache.repos[repos[0]].users != 0 || s.objectCache.repos[repos[1]].users != 0 {
+		t.Errorf("cached repos are not released")
+	}
+}
+
+func TestObjectCache_gc(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	var urls []string
+	for _, name := range []string{"one", "two"} {
+		dir := filepath.Join(root, name)
+		work := newLocalWorkRepo(t, dir)
+		commitLocalFiles(t, work, map[string]string{"a.txt": name + "\n"}, name)
+		pushLocal(t, work)
+		urls = append(urls, dir)
+	}
+
+	c, err := newObjectCache(filepath.Join(t.TempDir(), "cache"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	onestorage, onehead, releaseone, err := c.fetch(ctx, urls[0], "main", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := object.GetCommit(onestorage, onehead); err != nil {
+		t.Fatalf("failed to read head from cache: %v", err)
+	}
+	releaseone()
+	onerepo := c.repoPath(urls[0])
+
+	_, _, releasetwo, err := c.fetch(ctx, urls[1], "main", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer releasetwo()
+
+	if _, err := os.Stat(onerepo); !os.IsNotExist(err) {
+		t.Errorf("least recently used repo is not evicted: %v", err)
+	}
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("repo in use is evicted, %d repos left", len(entries))
+	}
+
+	_, head, releaseempty, err := c.fetch(ctx, urls[0], "nonexist", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	releaseempty()
+	if !head.IsZero() {
+		t.Errorf("want zero head for non-existent branch, got %s", head)
+	}
+}
+
+func TestOverlayStorage(t *testing.T) {
+	root := t.TempDir()
+	work := newLocalWorkRepo(t, filepath.Join(root, "repo"))
+	head := commitLocalFiles(t, work, map[string]string{"a.txt": "a\n"}, "first")
+
+	s := newOverlayStorage(work.Storer)
+
+	c, err := object.GetCommit(s, head)
+	if err != nil {
+		t.Fatalf("failed to read from base: %v", err)
+	}
+
+	// objects in the base are not copied.
+	obj := s.NewEncodedObject()
+	if err := c.Encode(obj); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetEncodedObject(obj); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Storage.ObjectStorage.Commits) != 0 {
+		t.Errorf("object in base is copied to memory")
+	}
+
+	c.Message = "changed"
+	obj = s.NewEncodedObject()
+	if err := c.Encode(obj); err != nil {
+		t.Fatal(err)
+	}
+	h, err := s.SetEncodedObject(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := work.Storer.HasEncodedObject(h); err == nil {
+		t.Errorf("new object is written to base")
+	}
+	if err := s.HasEncodedObject(h); err != nil {
+		t.Errorf("new object is not found: %v", err)
+	}
+
+	iter, err := s.IterEncodedObjects(plumbing.CommitObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	if err := iter.ForEach(func(plumbing.EncodedObject) error { n++; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("want 2 commits, got %d", n)
+	}
+}
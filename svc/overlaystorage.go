@@ -0,0 +1,76 @@
+package svc
+
+import (
+	"errors"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// overlayStorage keeps the references and the new objects in memory, and reads the objects from both the memory and
+// the base storage. The base storage is never written to, so it can be shared by workspaces of the same repo.
+type overlayStorage struct {
+	*memory.Storage
+
+	base storer.EncodedObjectStorer
+}
+
+var _ storage.Storer = (*overlayStorage)(nil)
+
+func newOverlayStorage(base storer.EncodedObjectStorer) *overlayStorage {
+	return &overlayStorage{
+		Storage: memory.NewStorage(),
+		base:    base,
+	}
+}
+
+// SetEncodedObject saves the object in memory, unless the base already contains it.
+func (s *overlayStorage) SetEncodedObject(obj plumbing.EncodedObject) (plumbing.Hash, error) {
+	if s.base.HasEncodedObject(obj.Hash()) == nil {
+		return obj.Hash(), nil
+	}
+
+	return s.Storage.SetEncodedObject(obj)
+}
+
+func (s *overlayStorage) EncodedObject(t plumbing.ObjectType, h plumbing.Hash) (plumbing.EncodedObject, error) {
+	obj, err := s.Storage.EncodedObject(t, h)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return s.base.EncodedObject(t, h)
+	}
+
+	return obj, err
+}
+
+func (s *overlayStorage) HasEncodedObject(h plumbing.Hash) error {
+	if err := s.Storage.HasEncodedObject(h); err == nil {
+		return nil
+	}
+
+	return s.base.HasEncodedObject(h)
+}
+
+func (s *overlayStorage) EncodedObjectSize(h plumbing.Hash) (int64, error) {
+	size, err := s.Storage.EncodedObjectSize(h)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return s.base.EncodedObjectSize(h)
+	}
+
+	return size, err
+}
+
+func (s *overlayStorage) IterEncodedObjects(t plumbing.ObjectType) (storer.EncodedObjectIter, error) {
+	memiter, err := s.Storage.IterEncodedObjects(t)
+	if err != nil {
+		return nil, err
+	}
+	baseiter, err := s.base.IterEncodedObjects(t)
+	if err != nil {
+		memiter.Close()
+		return nil, err
+	}
+
+	return storer.NewMultiEncodedObjectIter([]storer.EncodedObjectIter{memiter, baseiter}), nil
+}
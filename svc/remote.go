@@ -0,0 +1,241 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultSshUser is the user for ssh if username is not set.
+const defaultSshUser = "git"
+
+var (
+	ErrMultipleAuthMethods = errors.New("only one of ssh_key_path, bearer_token, and username can be set")
+	ErrAuthForLocalRemote  = errors.New("auth cannot be set for local remotes")
+	ErrEmptyLocalRemoteUrl = errors.New("empty remote url for local remote")
+)
+
+// isLocal checks if the remote is a [RemoteConfig_LOCAL] remote.
+func (c *RemoteConfig) isLocal() bool {
+	return c.RemoteType == RemoteConfig_LOCAL
+}
+
+// auth returns the auth method for the remote, nil if no auth is configured.
+//
+// The private key of ssh is loaded each time, so it can be rotated without restarting.
+func (c *RemoteConfig) auth() (transport.AuthMethod, error) {
+	switch {
+	case c.isLocal() && (c.SshKeyPath != "" || c.BearerToken != "" || c.Username != ""):
+		return nil, ErrAuthForLocalRemote
+	case c.isLocal():
+		return nil, nil
+	case c.SshKeyPath != "" && c.BearerToken != "":
+		return nil, ErrMultipleAuthMethods
+	case c.SshKeyPath != "":
+		user := c.Username
+		if user == "" {
+			user = defaultSshUser
+		}
+		keys, err := ssh.NewPublicKeysFromFile(user, c.SshKeyPath, c.SshKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ssh key for remote %s: %w", c.RemoteName, err)
+		}
+		var knownhosts []string
+		if c.SshKnownHostsPath != "" {
+			knownhosts = append(knownhosts, c.SshKnownHostsPath)
+		}
+		keys.HostKeyCallback, err = ssh.NewKnownHostsCallback(knownhosts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load known hosts for remote %s: %w", c.RemoteName, err)
+		}
+		return keys, nil
+	case c.BearerToken != "" && c.Username != "":
+		return nil, ErrMultipleAuthMethods
+	case c.BearerToken != "":
+		return &http.TokenAuth{Token: c.BearerToken}, nil
+	case c.Username != "":
+		return &http.BasicAuth{
+			Username: c.Username,
+			Password: c.Secret,
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// verifyPathElements checks the owner and the repo are single path elements before they are put into a path or an
+// url of the remote.
+func verifyPathElements(cfg *RemoteConfig, owner string, repo string) error {
+	if !isPathElement(owner) {
+		return fmt.Errorf("invalid owner %q for remote %s: %w", owner, cfg.RemoteName, ErrInvalidOwnerName)
+	}
+	if !isPathElement(repo) {
+		return fmt.Errorf("invalid repo %q for remote %s: %w", repo, cfg.RemoteName, ErrInvalidRepoName)
+	}
+
+	return nil
+}
+
+// isUnderRoot checks if the cleaned path is under the cleaned root.
+func isUnderRoot(root string, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(root), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// constructPullUrl constructs the url of the repo from the url template of the remote.
+func constructPullUrl(cfg *RemoteConfig, owner string, repo string) (string, error) {
+	if err := verifyPathElements(cfg, owner, repo); err != nil {
+		return "", err
+	}
+	if cfg.isLocal() {
+		return localRepoPath(cfg, owner, repo)
+	}
+	if cfg.UrlTemplate == "" {
+		return fmt.Sprintf("%s/%s/%s", cfg.RemoteUrl, owner, repo), nil
+	}
+
+	return expandUrlTemplate(cfg, owner, repo)
+}
+
+func expandUrlTemplate(cfg *RemoteConfig, owner string, repo string) (string, error) {
+	if err := verifyPathElements(cfg, owner, repo); err != nil {
+		return "", err
+	}
+	if !strings.Contains(cfg.UrlTemplate, "{repo}") {
+		return "", fmt.Errorf("url template of remote %s doesn't contain {repo}: %s", cfg.RemoteName, cfg.UrlTemplate)
+	}
+
+	return strings.NewReplacer(
+		"{remote_url}", cfg.RemoteUrl,
+		"{owner}", owner,
+		"{repo}", repo,
+	).Replace(cfg.UrlTemplate), nil
+}
+
+// localPath converts a file:// url to a path.
+func localPath(s string) (string, error) {
+	if !strings.HasPrefix(s, "file://") {
+		return s, nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid file url %s: %w", s, err)
+	}
+
+	return u.Path, nil
+}
+
+// localRepoPath returns the path of the bare repo on a local remote.
+func localRepoPath(cfg *RemoteConfig, owner string, repo string) (string, error) {
+	var root string
+	var candidates []string
+	if cfg.UrlTemplate != "" {
+		expanded, err := expandUrlTemplate(cfg, owner, repo)
+		if err != nil {
+			return "", err
+		}
+		path, err := localPath(expanded)
+		if err != nil {
+			return "", err
+		}
+		// the root is the directory of the template before the owner or the repo.
+		prefix := cfg.UrlTemplate
+		if i := strings.Index(prefix, "{owner}"); i >= 0 {
+			prefix = prefix[:i]
+		}
+		if i := strings.Index(prefix, "{repo}"); i >= 0 {
+			prefix = prefix[:i]
+		}
+		prefix, err = localPath(strings.ReplaceAll(prefix, "{remote_url}", cfg.RemoteUrl))
+		if err != nil {
+			return "", err
+		}
+		root = filepath.Dir(prefix + "x")
+		candidates = append(candidates, path)
+	} else {
+		if cfg.RemoteUrl == "" {
+			return "", ErrEmptyLocalRemoteUrl
+		}
+		var err error
+		root, err = localPath(cfg.RemoteUrl)
+		if err != nil {
+			return "", err
+		}
+		path := filepath.Join(root, owner, repo)
+		candidates = append(candidates, path, path+".git")
+	}
+
+	for _, path := range candidates {
+		if !isUnderRoot(root, path) {
+			return "", fmt.Errorf("repo %s/%s escapes the root %s of local remote %s", owner, repo, root, cfg.RemoteName)
+		}
+	}
+
+	for _, path := range candidates {
+		// non-bare repos are rejected, since the checked out branch cannot be pushed to.
+		if _, err := os.Stat(filepath.Join(path, "config")); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("bare repo %s/%s doesn't exist on local remote %s, looked at %s", owner, repo, cfg.RemoteName, strings.Join(candidates, ", "))
+}
+
+// transientError is a failure to fetch from or push to a remote that may succeed if retried.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
+
+// remoteError marks the error of a fetch or a push transient, unless the remote rejects the request,
+// or the operation is cancelled.
+func remoteError(err error) error {
+	switch {
+	case err == nil,
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, transport.ErrAuthenticationRequired),
+		errors.Is(err, transport.ErrAuthorizationFailed),
+		errors.Is(err, transport.ErrInvalidAuthMethod),
+		errors.Is(err, transport.ErrRepositoryNotFound),
+		errors.Is(err, git.ErrNonFastForwardUpdate),
+		errors.Is(err, git.ErrForceNeeded):
+		return err
+	default:
+		return &transientError{err: err}
+	}
+}
+
+// isTransientError checks if err is a transient failure of a remote, either as an error or as an
+// [codes.Unavailable] status.
+func isTransientError(err error) bool {
+	var v *transientError
+	return errors.As(err, &v) || status.Code(err) == codes.Unavailable
+}
+
+// statusCodeForRemoteError returns [codes.Unavailable] for the transient errors, and [codes.Internal] otherwise.
+func statusCodeForRemoteError(err error) codes.Code {
+	if isTransientError(err) {
+		return codes.Unavailable
+	}
+
+	return codes.Internal
+}
@@ -0,0 +1,381 @@
+package svc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConstructPullUrl(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *RemoteConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default",
+			cfg:  &RemoteConfig{RemoteUrl: "https://example.com"},
+			want: "https://example.com/org/repo",
+		},
+		{
+			name: "ssh",
+			cfg:  &RemoteConfig{UrlTemplate: "ssh://git@example.com:2222/{owner}/{repo}.git"},
+			want: "ssh://git@example.com:2222/org/repo.git",
+		},
+		{
+			name: "remote url",
+			cfg:  &RemoteConfig{RemoteUrl: "https://example.com", UrlTemplate: "{remote_url}/scm/{owner}/{repo}.git"},
+			want: "https://example.com/scm/org/repo.git",
+		},
+		{
+			name:    "no repo",
+			cfg:     &RemoteConfig{UrlTemplate: "https://example.com/{owner}"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := constructPullUrl(tt.cfg, "org", "repo")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// serveGitSsh serves the git repos in root over ssh for the client key, and returns the address and the host key.
+func serveGitSsh(t *testing.T, root string, clientkey ssh.PublicKey) (string, ssh.PublicKey) {
+	t.Helper()
+
+	_, hostpriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostsigner, err := ssh.NewSignerFromKey(hostpriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientkey.Marshal()) {
+				return nil, errors.New("unknown key")
+			}
+			return nil, nil
+		},
+	}
+	cfg.AddHostKey(hostsigner)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go serveGitSshConn(root, conn, cfg)
+		}
+	}()
+
+	return lis.Addr().String(), hostsigner.PublicKey()
+}
+
+func serveGitSshConn(root string, conn net.Conn, cfg *ssh.ServerConfig) {
+	defer conn.Close()
+
+	_, chans, reqs, err := ssh.NewServerConn(conn, cfg)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newch := range chans {
+		if newch.ChannelType() != "session" {
+			newch.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		ch, chreqs, err := newch.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range chreqs {
+				if req.Type != "exec" || len(req.Payload) < 4 {
+					req.Reply(false, nil)
+					continue
+				}
+				req.Reply(true, nil)
+				// the payload is the command as an ssh string, like git-upload-pack '/org/repo'.
+				command := string(req.Payload[4:])
+				service, path, _ := strings.Cut(command, " ")
+				path = strings.Trim(path, "'")
+				go runGitSshCommand(ch, strings.TrimPrefix(service, "git-"), filepath.Join(root, path))
+			}
+		}()
+	}
+}
+
+func runGitSshCommand(ch ssh.Channel, service string, dir string) {
+	defer ch.Close()
+
+	cmd := exec.Command("git", service, dir)
+	cmd.Stdout = ch
+	cmd.Stderr = ch.Stderr()
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return
+	}
+	go func() {
+		io.Copy(stdin, ch)
+		stdin.Close()
+	}()
+
+	exitcode := uint32(0)
+	if err := cmd.Run(); err != nil {
+		exitcode = 1
+	}
+	ch.SendRequest("exit-status", false, binary.BigEndian.AppendUint32(nil, exitcode))
+}
+
+// writeSshKey generates a key, and writes the private key encrypted with passphrase to path.
+// The private key is not encrypted if passphrase is empty.
+func writeSshKey(t *testing.T, path string, passphrase string) ssh.PublicKey {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var block *pem.Block
+	if passphrase == "" {
+		block, err = ssh.MarshalPrivateKey(priv, "")
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte(passphrase))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sshpub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sshpub
+}
+
+func TestSvc_sshRemote(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(root, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+	torepo := newLocalRepo(t, filepath.Join(root, "org", "to"), true)
+
+	keydir := t.TempDir()
+	keypath := filepath.Join(keydir, "id_ed25519")
+	clientkey := writeSshKey(t, keypath, "passphrase")
+
+	addr, hostkey := serveGitSsh(t, root, clientkey)
+
+	knownhostspath := filepath.Join(keydir, "known_hosts")
+	if err := os.WriteFile(knownhostspath, []byte(knownhosts.Line([]string{knownhosts.Normalize(addr)}, hostkey)+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	otherkey := writeSshKey(t, filepath.Join(keydir, "other"), "")
+	otherknownhostspath := filepath.Join(keydir, "other_known_hosts")
+	if err := os.WriteFile(otherknownhostspath, []byte(knownhosts.Line([]string{knownhosts.Normalize(addr)}, otherkey)+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"ssh": {
+				RemoteName:        "ssh",
+				UrlTemplate:       "ssh://" + addr + "/{owner}/{repo}",
+				SshKeyPath:        keypath,
+				SshKeyPassphrase:  "passphrase",
+				SshKnownHostsPath: knownhostspath,
+			},
+			"unknownhost": {
+				RemoteName:        "unknownhost",
+				UrlTemplate:       "ssh://" + addr + "/{owner}/{repo}",
+				SshKeyPath:        keypath,
+				SshKeyPassphrase:  "passphrase",
+				SshKnownHostsPath: otherknownhostspath,
+			},
+		},
+	})
+
+	if _, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "unknownhost", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "unknownhost", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	}); err == nil {
+		t.Fatal("want error for unknown host key")
+	}
+
+	if _, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "ssh", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "ssh", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := torepo.Reference(plumbing.NewBranchReferenceName("main"), true); err != nil {
+		t.Fatalf("sub repo is not pushed over ssh: %v", err)
+	}
+}
+
+// serveGitHttp serves the git repos in root over http, and requires the authorization header.
+func serveGitHttp(t *testing.T, root string, authorization string) string {
+	t.Helper()
+
+	gitpath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not found")
+	}
+	backend := &cgi.Handler{
+		Path: gitpath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1", "REMOTE_USER=gitrim"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != authorization {
+			w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestSvc_bearerTokenRemote(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(root, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+	torepo := newLocalRepo(t, filepath.Join(root, "org", "to"), true)
+
+	url := serveGitHttp(t, root, "Bearer token")
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"http":     {RemoteName: "http", RemoteUrl: url, BearerToken: "token"},
+			"badtoken": {RemoteName: "badtoken", RemoteUrl: url, BearerToken: "bad"},
+		},
+	})
+
+	if _, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "badtoken", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "badtoken", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	}); err == nil {
+		t.Fatal("want error for bad token")
+	}
+
+	if _, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "http", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "http", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := torepo.Reference(plumbing.NewBranchReferenceName("main"), true); err != nil {
+		t.Fatalf("sub repo is not pushed over http: %v", err)
+	}
+}
+
+func TestRemoteConfig_auth(t *testing.T) {
+	if _, err := (&RemoteConfig{SshKeyPath: "key", BearerToken: "token"}).auth(); !errors.Is(err, ErrMultipleAuthMethods) {
+		t.Errorf("want multiple auth methods error, got %v", err)
+	}
+	if _, err := (&RemoteConfig{Username: "user", BearerToken: "token"}).auth(); !errors.Is(err, ErrMultipleAuthMethods) {
+		t.Errorf("want multiple auth methods error, got %v", err)
+	}
+	if auth, err := (&RemoteConfig{}).auth(); auth != nil || err != nil {
+		t.Errorf("want no auth, got %v %v", auth, err)
+	}
+	if _, err := (&RemoteConfig{SshKeyPath: filepath.Join(t.TempDir(), "nonexist")}).auth(); err == nil {
+		t.Errorf("want error for missing key")
+	}
+}
+
+func TestRemoteError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "network", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: true},
+		{name: "auth", err: transport.ErrAuthenticationRequired},
+		{name: "not found", err: fmt.Errorf("wrapped: %w", transport.ErrRepositoryNotFound)},
+		{name: "cancelled", err: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("failed to clone: %w", remoteError(tt.err))
+			if got := isTransientError(err); got != tt.want {
+				t.Errorf("want transient %v, got %v", tt.want, got)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("original error is lost: %v", err)
+			}
+		})
+	}
+
+	if !isTransientError(status.Error(codes.Unavailable, "unavailable")) {
+		t.Error("unavailable status is not transient")
+	}
+}
@@ -0,0 +1,243 @@
+package svc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// putTestRepoSync puts a repo sync directly into the db of s without touching any remotes.
+func putTestRepoSync(t *testing.T, s *Svc, from *GitRepoIdentifier, to *GitRepoIdentifier, filter string) string {
+	t.Helper()
+
+	id := NewRepoSyncId(from, "main", to, "main")
+	canonicalfilter, err := NewCanonicalFilter(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := s.keyring.newSecret(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reposync := &DbRepoSync{
+		SyncData: &RepoSync{
+			Id:         hex.EncodeToString(id),
+			FromRepo:   from,
+			FromBranch: "main",
+			ToRepo:     to,
+			ToBranch:   "main",
+			Filter:     canonicalfilter,
+		},
+		Stat: EmptySyncStat(),
+	}
+	if err := s.db.Update(func(tx *bbolt.Tx) error {
+		if err := putSecretFunc(id, secret)(tx); err != nil {
+			return err
+		}
+		return putRepoSyncFunc(id, reposync)(tx)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return hex.EncodeToString(id)
+}
+
+// countBucket returns the number of keys in the bucket.
+func countBucket(t *testing.T, s *Svc, bucket string) int {
+	t.Helper()
+
+	n := 0
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		if b := tx.Bucket([]byte(bucket)); b != nil {
+			n = b.Stats().KeyN
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return n
+}
+
+func TestSvc_ListRepoSyncs(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSvc(t, nil)
+
+	for i := range 5 {
+		putTestRepoSync(t, s,
+			&GitRepoIdentifier{RemoteName: "github", Owner: "org", Repo: fmt.Sprintf("repo-%d", i)},
+			&GitRepoIdentifier{RemoteName: "gitea", Owner: fmt.Sprintf("team-%d", i%2), Repo: "sub"},
+			"a/")
+	}
+
+	var all []*RepoSync
+	req := &ListRepoSyncsRequest{PageSize: 2}
+	for {
+		resp, err := s.ListRepoSyncs(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.RepoSyncs) > 2 {
+			t.Fatalf("page size is 2, got %d", len(resp.RepoSyncs))
+		}
+		all = append(all, resp.RepoSyncs...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if len(all) != 5 {
+		t.Fatalf("want 5 repo syncs, got %d", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Id >= all[i].Id {
+			t.Errorf("repo syncs are not ordered by id: %s, %s", all[i-1].Id, all[i].Id)
+		}
+	}
+
+	for _, tc := range []struct {
+		req  *ListRepoSyncsRequest
+		want int
+	}{
+		{req: &ListRepoSyncsRequest{RemoteName: "gitea"}, want: 5},
+		{req: &ListRepoSyncsRequest{Owner: "team-0"}, want: 3},
+		{req: &ListRepoSyncsRequest{RemoteName: "github", Repo: "repo-3"}, want: 1},
+		{req: &ListRepoSyncsRequest{RemoteName: "github", Owner: "team-0"}, want: 0},
+	} {
+		resp, err := s.ListRepoSyncs(ctx, tc.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.RepoSyncs) != tc.want || resp.NextPageToken != "" {
+			t.Errorf("%v: want %d, got %d, next page %q", tc.req, tc.want, len(resp.RepoSyncs), resp.NextPageToken)
+		}
+	}
+
+	if _, err := s.ListRepoSyncs(ctx, &ListRepoSyncsRequest{PageToken: "zz"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("want invalid argument for bad token, got %v", err)
+	}
+}
+
+func TestSvc_UpdateRepoSync(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSvc(t, nil)
+
+	from := &GitRepoIdentifier{RemoteName: "github", Owner: "org", Repo: "repo"}
+	to := &GitRepoIdentifier{RemoteName: "gitea", Owner: "org", Repo: "sub"}
+	id := putTestRepoSync(t, s, from, to, "a/\n")
+	otherid := putTestRepoSync(t, s, from, &GitRepoIdentifier{RemoteName: "gitea", Owner: "org", Repo: "other"}, "a/\n")
+
+	// reformatting the filter keeps the id.
+	resp, err := s.UpdateRepoSync(ctx, &UpdateRepoSyncRequest{Id: id, Filter: "# sub dir\na/\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.RepoSync.Id != id || resp.RepoSync.Filter.RawText != "# sub dir\na/\n" {
+		t.Errorf("unexpected repo sync: %v", resp.RepoSync)
+	}
+
+	_, err = s.UpdateRepoSync(ctx, &UpdateRepoSyncRequest{Id: id, Filter: "b/\n"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("want failed precondition for changed filter, got %v", err)
+	}
+
+	_, err = s.UpdateRepoSync(ctx, &UpdateRepoSyncRequest{Id: id, ToRepo: &GitRepoIdentifier{Repo: "other"}})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("want already exists, got %v", err)
+	}
+
+	// renaming the repo moves the repo sync.
+	resp, err = s.UpdateRepoSync(ctx, &UpdateRepoSyncRequest{Id: id, ToRepo: &GitRepoIdentifier{Repo: "renamed"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantid := hex.EncodeToString(NewRepoSyncId(from, "main", &GitRepoIdentifier{RemoteName: "gitea", Owner: "org", Repo: "renamed"}, "main"))
+	if resp.RepoSync.Id != wantid {
+		t.Errorf("want id %s, got %s", wantid, resp.RepoSync.Id)
+	}
+	if _, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("old id still exists: %v", err)
+	}
+	got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: wantid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Secret != resp.Secret || got.RepoSync.Filter.RawText != "# sub dir\na/\n" {
+		t.Errorf("unexpected repo sync after move: %v", got)
+	}
+	if n := countBucket(t, s, SECRET_TO_ID_BUCKET); n != 2 {
+		t.Errorf("want 2 secrets, got %d", n)
+	}
+
+	if _, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: otherid}); err != nil {
+		t.Errorf("other repo sync is changed: %v", err)
+	}
+
+	// a concurrent update committed before the lock is obtained is kept.
+	unlock, err := s.lockIds(ctx, wantid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		_, err := s.UpdateRepoSync(ctx, &UpdateRepoSyncRequest{Id: wantid, FromBranch: "dev"})
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	reposync, dbid, err := getRepoSync(s.db, wantid, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reposync.SyncData.Filter, err = NewCanonicalFilter("# concurrent\na/\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.db.Update(putRepoSyncFunc(dbid, reposync)); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	got, err = s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: hex.EncodeToString(NewRepoSyncId(from, "dev", &GitRepoIdentifier{RemoteName: "gitea", Owner: "org", Repo: "renamed"}, "main"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.RepoSync.Filter.RawText != "# concurrent\na/\n" {
+		t.Errorf("concurrent update is reverted: %v", got.RepoSync)
+	}
+}
+
+func TestSvc_DeleteRepoSync(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSvc(t, nil)
+
+	id := putTestRepoSync(t, s,
+		&GitRepoIdentifier{RemoteName: "github", Owner: "org", Repo: "repo"},
+		&GitRepoIdentifier{RemoteName: "gitea", Owner: "org", Repo: "sub"},
+		"a/")
+
+	resp, err := s.DeleteRepoSync(ctx, &DeleteRepoSyncRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.RepoSync.Id != id {
+		t.Errorf("want deleted %s, got %s", id, resp.RepoSync.Id)
+	}
+
+	for _, bucket := range []string{REPO_SYNC_BUCKET, ID_TO_SECRET_BUCKET, SECRET_TO_ID_BUCKET} {
+		if n := countBucket(t, s, bucket); n != 0 {
+			t.Errorf("%s: want empty, got %d", bucket, n)
+		}
+	}
+
+	if _, err := s.DeleteRepoSync(ctx, &DeleteRepoSyncRequest{Id: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("want not found, got %v", err)
+	}
+}
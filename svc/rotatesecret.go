@@ -0,0 +1,156 @@
+package svc
+
+import (
+	"context"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultSecretGracePeriod is how long the replaced secret is accepted if grace_period_secs is zero.
+const defaultSecretGracePeriod = 24 * time.Hour
+
+func getPreviousSecrets(tx *bbolt.Tx, id []byte) (*DbPreviousSecrets, error) {
+	previous := &DbPreviousSecrets{}
+	b := tx.Bucket([]byte(PREVIOUS_SECRET_BUCKET))
+	if b == nil {
+		return previous, nil
+	}
+	v := b.Get(id)
+	if v == nil {
+		return previous, nil
+	}
+	if err := proto.Unmarshal(v, previous); err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// rotateSecretFunc replaces the secret of the repo sync, and keeps the old secret until expiresat. The expired
+// previous secrets are dropped, and all the previous secrets are dropped if expiresat is zero.
+func rotateSecretFunc(id []byte, oldsecret []byte, newsecret []byte, expiresat int64, now int64) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		previous, err := getPreviousSecrets(tx, id)
+		if err != nil {
+			return err
+		}
+		previous.Secrets = slices.DeleteFunc(previous.Secrets, func(v *DbPreviousSecrets_Secret) bool {
+			return expiresat == 0 || v.ExpiresAt <= now
+		})
+		if expiresat != 0 {
+			previous.Secrets = append(previous.Secrets, &DbPreviousSecrets_Secret{Secret: oldsecret, ExpiresAt: expiresat})
+		}
+
+		previousbucket, err := tx.CreateBucketIfNotExists([]byte(PREVIOUS_SECRET_BUCKET))
+		if err != nil {
+			return err
+		}
+		if len(previous.Secrets) == 0 {
+			if err := previousbucket.Delete(id); err != nil {
+				return err
+			}
+		} else {
+			data, err := proto.Marshal(previous)
+			if err != nil {
+				return err
+			}
+			if err := previousbucket.Put(id, data); err != nil {
+				return err
+			}
+		}
+
+		if b := tx.Bucket([]byte(SECRET_TO_ID_BUCKET)); b != nil {
+			if err := b.Delete(oldsecret); err != nil {
+				return err
+			}
+		}
+
+		return putSecretFunc(id, newsecret)(tx)
+	}
+}
+
+// getWebhookSecretsForId returns the secrets accepted by the webhook of the repo sync: the current secret, and the
+// previous secrets not expired yet.
+func getWebhookSecretsForId(db *bbolt.DB, id []byte) ([][]byte, error) {
+	secret, err := getSecretForId(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := [][]byte{secret}
+	now := time.Now().Unix()
+	if err := db.View(func(tx *bbolt.Tx) error {
+		previous, err := getPreviousSecrets(tx, id)
+		if err != nil {
+			return err
+		}
+		for _, v := range previous.Secrets {
+			if v.ExpiresAt > now {
+				secrets = append(secrets, v.Secret)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+func (s *Svc) RotateSecret(ctx context.Context, req *RotateSecretRequest) (*RotateSecretResponse, error) {
+	idwaiter, err := s.lockId(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlockId(req.Id, idwaiter)
+
+	reposync, id, err := getRepoSync(s.db, req.Id, true)
+	if err != nil {
+		return nil, err
+	}
+	oldsecret, err := getSecretForId(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.keyring.newSecret(id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate secret: %s", err.Error())
+	}
+
+	resp := &RotateSecretResponse{
+		Secret: hex.EncodeToString(secret),
+		KeyId:  s.keyring.activeId,
+	}
+
+	now := time.Now()
+	switch {
+	case req.GracePeriodSecs == 0:
+		resp.PreviousSecretExpiresAt = now.Add(defaultSecretGracePeriod).Unix()
+	case req.GracePeriodSecs > 0:
+		resp.PreviousSecretExpiresAt = now.Add(time.Duration(req.GracePeriodSecs) * time.Second).Unix()
+	}
+
+	if err := s.db.Update(rotateSecretFunc(id, oldsecret, secret, resp.PreviousSecretExpiresAt, now.Unix())); err != nil {
+		return nil, err
+	}
+	if err := s.db.Sync(); err != nil {
+		return nil, ErrStatusDBFailure
+	}
+
+	logger.Info("rotated secret", "id", req.Id, "key-id", resp.KeyId, "previous-expires-at", resp.PreviousSecretExpiresAt)
+
+	// the new secret is saved, and the webhooks still signing with the old one are accepted until it expires.
+	resp.WebhookRepos, err = s.registerWebhooks(ctx, reposync.SyncData, resp.Secret)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "rotated secret, but failed to update webhooks: %s", err.Error())
+	}
+
+	return resp, nil
+}
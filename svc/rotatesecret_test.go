@@ -0,0 +1,104 @@
+package svc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSvc_RotateSecret(t *testing.T) {
+	ctx := context.Background()
+
+	s := newTestSvc(t, &GiTrimConfig{
+		AesKeys: []*AesKey{{Id: 3, Key: "000102030405060708090a0b0c0d0e0f"}},
+	})
+	id := putTestRepoSync(t, s,
+		&GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		&GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		"a/")
+	get, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := get.Secret
+
+	server := httptest.NewServer(s.WebhookHandler())
+	defer server.Close()
+
+	ping := func(t *testing.T, secret string) int {
+		t.Helper()
+
+		body := `{"zen":"Design for failure."}`
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/webhook/"+id, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(githubEventHeader, "ping")
+		req.Header.Set(githubSignatureHeader, signGitHub(secret, []byte(body)))
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	rotated, err := s.RotateSecret(ctx, &RotateSecretRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.Secret == first || rotated.KeyId != 3 {
+		t.Errorf("want a new secret sealed with key 3, got %v", rotated)
+	}
+	if wantexpiry := time.Now().Add(defaultSecretGracePeriod).Unix(); rotated.PreviousSecretExpiresAt < wantexpiry-10 || rotated.PreviousSecretExpiresAt > wantexpiry {
+		t.Errorf("want previous secret to expire in a day, got %d", rotated.PreviousSecretExpiresAt)
+	}
+	get, err = s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.Secret != rotated.Secret {
+		t.Errorf("want secret %s, got %s", rotated.Secret, get.Secret)
+	}
+	for _, secret := range []string{first, rotated.Secret} {
+		if code := ping(t, secret); code != http.StatusOK {
+			t.Errorf("want secret accepted in grace period, got %d", code)
+		}
+	}
+
+	expired, err := s.RotateSecret(ctx, &RotateSecretRequest{Id: id, GracePeriodSecs: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expired.PreviousSecretExpiresAt != 0 {
+		t.Errorf("want previous secret expired immediately, got %d", expired.PreviousSecretExpiresAt)
+	}
+	for _, secret := range []string{first, rotated.Secret} {
+		if code := ping(t, secret); code != http.StatusUnauthorized {
+			t.Errorf("want expired secret rejected, got %d", code)
+		}
+	}
+	if code := ping(t, expired.Secret); code != http.StatusOK {
+		t.Errorf("want new secret accepted, got %d", code)
+	}
+	if n := countBucket(t, s, PREVIOUS_SECRET_BUCKET); n != 0 {
+		t.Errorf("want no previous secrets, got %d", n)
+	}
+	if n := countBucket(t, s, SECRET_TO_ID_BUCKET); n != 1 {
+		t.Errorf("want the old secrets removed, got %d", n)
+	}
+
+	if _, err := s.RotateSecret(ctx, &RotateSecretRequest{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteRepoSync(ctx, &DeleteRepoSyncRequest{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	if n := countBucket(t, s, PREVIOUS_SECRET_BUCKET); n != 0 {
+		t.Errorf("want previous secrets deleted with the repo sync, got %d", n)
+	}
+}
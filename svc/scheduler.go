@@ -0,0 +1,266 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultPollConcurrency = 4
+	// maxSchedulerScanPeriod is the max period between the scans for the due repo syncs.
+	maxSchedulerScanPeriod = 30 * time.Second
+)
+
+var ErrSchedulerDisabled = errors.New("scheduler is disabled, poll_interval_secs is not set")
+
+// scheduler polls the repo syncs in the background.
+//
+// Each scan lists the repo syncs, and starts a poll for the ones whose next_run_at is due, as long as there are
+// fewer than concurrency polls running. A repo sync never polled before is scheduled at a random time within the
+// first interval, so the polls are spread out.
+type scheduler struct {
+	s *Svc
+
+	interval   time.Duration
+	jitter     time.Duration
+	maxBackoff time.Duration
+
+	// sem limits the number of polls running at the same time.
+	sem chan struct{}
+
+	// mu guards running.
+	mu      sync.Mutex
+	running map[string]bool
+
+	wg sync.WaitGroup
+}
+
+func (s *Svc) newScheduler() (*scheduler, error) {
+	if s.config.PollIntervalSecs <= 0 {
+		return nil, ErrSchedulerDisabled
+	}
+
+	interval := time.Duration(s.config.PollIntervalSecs) * time.Second
+	jitter := time.Duration(s.config.PollJitterSecs) * time.Second
+	if jitter <= 0 {
+		jitter = interval / 10
+	}
+	maxbackoff := time.Duration(s.config.PollMaxBackoffSecs) * time.Second
+	if maxbackoff <= 0 {
+		maxbackoff = 24 * interval
+	}
+	concurrency := int(s.config.PollConcurrency)
+	if concurrency <= 0 {
+		concurrency = defaultPollConcurrency
+	}
+
+	return &scheduler{
+		s:          s,
+		interval:   interval,
+		jitter:     jitter,
+		maxBackoff: maxbackoff,
+		sem:        make(chan struct{}, concurrency),
+		running:    make(map[string]bool),
+	}, nil
+}
+
+// RunScheduler polls the repo syncs every poll_interval_secs until ctx is done, and syncs the from repo to the to
+// repo when the from repo has new commits. The result of the last poll and the time of the next poll are saved
+// in the db, and returned by [Svc.GetRepoSync].
+//
+// A repo sync whose poll fails, or needs attention because the repos are diverged, is polled with exponential
+// backoff up to poll_max_backoff_secs.
+//
+// Once ctx is done, it waits for the running polls to finish.
+func (s *Svc) RunScheduler(ctx context.Context) error {
+	sc, err := s.newScheduler()
+	if err != nil {
+		return err
+	}
+	defer sc.wg.Wait()
+
+	period := min(sc.interval/2, maxSchedulerScanPeriod)
+	period = max(period, time.Second)
+
+	logger.Info("running scheduler", "interval", sc.interval, "jitter", sc.jitter, "concurrency", cap(sc.sem))
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		if err := sc.scan(ctx, time.Now()); err != nil {
+			logger.Error("failed to scan repo syncs", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("stopping scheduler")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// randomDuration returns a random duration in [0, d).
+func randomDuration(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+
+	return rand.N(d)
+}
+
+// backoff returns the delay before the next poll after the number of consecutive failures.
+func (sc *scheduler) backoff(failures int32) time.Duration {
+	return exponentialBackoff(sc.interval, sc.maxBackoff, failures)
+}
+
+// scan starts the polls for the repo syncs that are due at now.
+func (sc *scheduler) scan(ctx context.Context, now time.Time) error {
+	type entry struct {
+		id    []byte
+		state *PollState
+	}
+	var entries []entry
+
+	if err := sc.s.db.View(func(tx *bbolt.Tx) error {
+		reposyncbucket := tx.Bucket([]byte(REPO_SYNC_BUCKET))
+		if reposyncbucket == nil {
+			return nil
+		}
+		pollstatebucket := tx.Bucket([]byte(POLL_STATE_BUCKET))
+
+		return reposyncbucket.ForEach(func(k, _ []byte) error {
+			e := entry{id: bytes.Clone(k)}
+			if pollstatebucket != nil {
+				if v := pollstatebucket.Get(k); v != nil {
+					e.state = &PollState{}
+					if err := proto.Unmarshal(v, e.state); err != nil {
+						return err
+					}
+				}
+			}
+			entries = append(entries, e)
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if e.state == nil {
+			state := &PollState{NextRunAt: now.Add(randomDuration(sc.interval)).Unix()}
+			if err := sc.s.db.Update(putPollStateFunc(e.id, state)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if e.state.NextRunAt > now.Unix() {
+			continue
+		}
+
+		idhex := hex.EncodeToString(e.id)
+		if !sc.start(idhex) {
+			continue
+		}
+
+		select {
+		case sc.sem <- struct{}{}:
+		default:
+			// the rest are polled in the next scan.
+			sc.finish(idhex)
+			return nil
+		}
+
+		sc.wg.Add(1)
+		go func() {
+			defer sc.wg.Done()
+			defer func() { <-sc.sem }()
+			defer sc.finish(idhex)
+
+			sc.poll(ctx, e.id, e.state, now)
+		}()
+	}
+
+	return nil
+}
+
+// start marks the repo sync running, and returns false if it is already running.
+func (sc *scheduler) start(idhex string) bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.running[idhex] {
+		return false
+	}
+	sc.running[idhex] = true
+
+	return true
+}
+
+func (sc *scheduler) finish(idhex string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	delete(sc.running, idhex)
+}
+
+// poll checks the repo sync, syncs the new commits of the from repo, and saves the poll state.
+// The next poll is scheduled relative to now, the time of the scan.
+func (sc *scheduler) poll(ctx context.Context, id []byte, last *PollState, now time.Time) {
+	idhex := hex.EncodeToString(id)
+	startedat := time.Now()
+
+	state := &PollState{LastRunAt: now.Unix()}
+
+	check, err := sc.s.CheckRepoSyncUpToDate(ctx, &CheckRepoSyncUpToDateRequest{Id: idhex})
+	if err == nil {
+		state.LastFromRepoStatus = check.FromRepoStatus
+		state.LastToRepoStatus = check.ToRepoStatus
+
+		switch {
+		case check.ToRepoStatus != LastSyncCommitStatus_INSYNC || check.FromRepoStatus == LastSyncCommitStatus_DIVERGED:
+			state.LastResult = PollState_NEEDS_ATTENTION
+		case check.FromRepoStatus == LastSyncCommitStatus_ADVANCED:
+			var resp *SyncToSubRepoResponse
+			resp, err = sc.s.SyncToSubRepo(withTriggeredBy(ctx, triggeredByScheduler), &SyncToSubRepoRequest{Id: idhex})
+			if err == nil {
+				state.LastResult = PollState_SYNCED
+				state.LastNumberOfNewCommits = resp.NumberOfNewCommits
+			}
+		default:
+			state.LastResult = PollState_UP_TO_DATE
+		}
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			// the poll is interrupted by shutdown, and it is retried on start up.
+			return
+		}
+		state.LastResult = PollState_FAILED
+		state.LastError = err.Error()
+	}
+
+	delay := sc.interval
+	if state.LastResult == PollState_FAILED || state.LastResult == PollState_NEEDS_ATTENTION {
+		state.ConsecutiveFailures = last.GetConsecutiveFailures() + 1
+		delay = sc.backoff(state.ConsecutiveFailures)
+	}
+	state.NextRunAt = now.Add(delay + randomDuration(sc.jitter)).Unix()
+
+	logger.Info("polled repo sync", "id", idhex, "result", state.LastResult.String(), "err", state.LastError, "next-run-at", time.Unix(state.NextRunAt, 0), "duration", time.Since(startedat))
+
+	if err := sc.s.db.Update(putPollStateFunc(id, state)); err != nil {
+		logger.Error("failed to save poll state", "id", idhex, "err", err)
+	}
+}
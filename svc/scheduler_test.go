@@ -0,0 +1,213 @@
+package svc
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScheduler_backoff(t *testing.T) {
+	sc := &scheduler{interval: time.Minute, maxBackoff: 10 * time.Minute}
+
+	tests := []struct {
+		failures int32
+		want     time.Duration
+	}{
+		{failures: 0, want: time.Minute},
+		{failures: 1, want: 2 * time.Minute},
+		{failures: 3, want: 8 * time.Minute},
+		{failures: 4, want: 10 * time.Minute},
+		{failures: 100, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := sc.backoff(tt.failures); got != tt.want {
+			t.Errorf("backoff(%d): want %s, got %s", tt.failures, tt.want, got)
+		}
+	}
+}
+
+func TestSvc_scheduler(t *testing.T) {
+	ctx := context.Background()
+
+	fromroot := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(fromroot, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+
+	toroot := t.TempDir()
+	newLocalRepo(t, filepath.Join(toroot, "org", "to.git"), true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"from": {RemoteName: "from", RemoteType: RemoteConfig_LOCAL, RemoteUrl: fromroot},
+			"to":   {RemoteName: "to", RemoteType: RemoteConfig_LOCAL, RemoteUrl: toroot},
+		},
+		PollIntervalSecs:   3600,
+		PollJitterSecs:     60,
+		PollMaxBackoffSecs: 4 * 3600,
+		PollConcurrency:    1,
+	})
+
+	if _, err := (&Svc{config: &GiTrimConfig{}}).newScheduler(); err != ErrSchedulerDisabled {
+		t.Errorf("want scheduler disabled, got %v", err)
+	}
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "from", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "to", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := hex.DecodeString(initresp.Id)
+
+	sc, err := s.newScheduler()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getstate := func(t *testing.T) *PollState {
+		t.Helper()
+		resp, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: initresp.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.PollState == nil {
+			t.Fatal("missing poll state")
+		}
+		return resp.PollState
+	}
+
+	now := time.Now()
+
+	t.Run("first scan schedules", func(t *testing.T) {
+		if err := sc.scan(ctx, now); err != nil {
+			t.Fatal(err)
+		}
+		sc.wg.Wait()
+
+		state := getstate(t)
+		if state.LastRunAt != 0 || state.LastResult != PollState_UNKNOWN {
+			t.Errorf("repo sync polled on first scan: %v", state)
+		}
+		if state.NextRunAt < now.Unix() || state.NextRunAt > now.Add(time.Hour).Unix() {
+			t.Errorf("next run at %d is not within the first interval", state.NextRunAt)
+		}
+	})
+
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	pushLocal(t, fromwork)
+
+	t.Run("sync advanced", func(t *testing.T) {
+		now = now.Add(2 * time.Hour)
+		if err := sc.scan(ctx, now); err != nil {
+			t.Fatal(err)
+		}
+		sc.wg.Wait()
+
+		state := getstate(t)
+		if state.LastResult != PollState_SYNCED || state.LastNumberOfNewCommits != 1 {
+			t.Fatalf("want synced with 1 new commit, got %v", state)
+		}
+		if state.LastFromRepoStatus != LastSyncCommitStatus_ADVANCED || state.LastToRepoStatus != LastSyncCommitStatus_INSYNC {
+			t.Errorf("unexpected repo status: %v", state)
+		}
+		if state.LastRunAt != now.Unix() {
+			t.Errorf("want last run at %d, got %d", now.Unix(), state.LastRunAt)
+		}
+		if state.NextRunAt < now.Add(time.Hour).Unix() || state.NextRunAt > now.Add(time.Hour+time.Minute).Unix() {
+			t.Errorf("next run at %d is not one interval later", state.NextRunAt)
+		}
+
+		uptodate, err := s.CheckRepoSyncUpToDate(ctx, &CheckRepoSyncUpToDateRequest{Id: initresp.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uptodate.FromRepoStatus != LastSyncCommitStatus_INSYNC || uptodate.ToRepoStatus != LastSyncCommitStatus_INSYNC {
+			t.Errorf("repos are not in sync after poll: %v", uptodate)
+		}
+	})
+
+	t.Run("not due", func(t *testing.T) {
+		before := getstate(t)
+		if err := sc.scan(ctx, now.Add(time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+		sc.wg.Wait()
+
+		if state := getstate(t); state.LastRunAt != before.LastRunAt {
+			t.Errorf("repo sync polled before due: %v", state)
+		}
+	})
+
+	t.Run("concurrency limit", func(t *testing.T) {
+		sc.sem <- struct{}{}
+		if err := sc.scan(ctx, now.Add(2*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		sc.wg.Wait()
+		<-sc.sem
+
+		if state := getstate(t); state.LastRunAt != now.Unix() {
+			t.Errorf("repo sync polled with no free slot: %v", state)
+		}
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		now = now.Add(2 * time.Hour)
+		if err := sc.scan(ctx, now); err != nil {
+			t.Fatal(err)
+		}
+		sc.wg.Wait()
+
+		if state := getstate(t); state.LastResult != PollState_UP_TO_DATE || state.ConsecutiveFailures != 0 {
+			t.Errorf("want up to date, got %v", state)
+		}
+	})
+
+	if err := os.RemoveAll(filepath.Join(fromroot, "org", "from")); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("failure backoff", func(t *testing.T) {
+		for i := 1; i <= 3; i++ {
+			now = now.Add(24 * time.Hour)
+			if err := sc.scan(ctx, now); err != nil {
+				t.Fatal(err)
+			}
+			sc.wg.Wait()
+
+			state := getstate(t)
+			if state.LastResult != PollState_FAILED || state.LastError == "" {
+				t.Fatalf("want failed, got %v", state)
+			}
+			if state.ConsecutiveFailures != int32(i) {
+				t.Errorf("want %d consecutive failures, got %d", i, state.ConsecutiveFailures)
+			}
+			wantdelay := sc.backoff(int32(i))
+			if state.NextRunAt < now.Add(wantdelay).Unix() || state.NextRunAt > now.Add(wantdelay+time.Minute).Unix() {
+				t.Errorf("want next run after %s, got %d", wantdelay, state.NextRunAt-now.Unix())
+			}
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if _, err := s.DeleteRepoSync(ctx, &DeleteRepoSyncRequest{Id: initresp.Id}); err != nil {
+			t.Fatal(err)
+		}
+		state, err := getPollStateFromDb(s.db, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state != nil {
+			t.Errorf("poll state is not deleted: %v", state)
+		}
+	})
+}
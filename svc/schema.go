@@ -0,0 +1,210 @@
+package svc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var schemaVersionKey = []byte("schema-version")
+
+var ErrDbSchemaTooNew = errors.New("db schema version is newer than the supported one, upgrade gitrim")
+
+// errMigrationDryRun rolls back the transaction of a dry run.
+var errMigrationDryRun = errors.New("dry run")
+
+// Migration upgrades the db to Version. The migrations run in the order of their versions, and each of them is
+// committed with the new version in one transaction.
+type Migration struct {
+	Version uint64
+	Name    string
+
+	migrate func(tx *bbolt.Tx) error
+}
+
+// migrations are all the migrations, ordered by their versions. A db without a schema version is at version 0.
+// Never change or remove a released migration, add a new one with the next version instead.
+var migrations = []*Migration{
+	{Version: 1, Name: "move the synced commits and their mappings of the stats to sync-stats", migrate: migrateSyncStatsFunc},
+	{Version: 2, Name: "move the synced commits and their mappings of the previous filters to sync-stats", migrate: migratePreviousSyncStatsFunc},
+}
+
+// LatestSchemaVersion is the schema version of the db created or migrated by this gitrim.
+func LatestSchemaVersion() uint64 {
+	return migrations[len(migrations)-1].Version
+}
+
+// MigrationResult is the result of migrating a db.
+type MigrationResult struct {
+	FromVersion uint64
+	ToVersion   uint64
+	// Applied are the migrations run, or to run for a dry run.
+	Applied []*Migration
+	// BackupPath is the copy of the db before the migrations, empty if nothing is migrated or for a dry run.
+	BackupPath string
+}
+
+// getSchemaVersion returns the schema version of the db, found is false if the version is never saved.
+func getSchemaVersion(tx *bbolt.Tx) (version uint64, found bool, err error) {
+	b := tx.Bucket([]byte(META_BUCKET))
+	if b == nil {
+		return 0, false, nil
+	}
+	v := b.Get(schemaVersionKey)
+	if v == nil {
+		return 0, false, nil
+	}
+	if len(v) != 8 {
+		return 0, false, fmt.Errorf("invalid length %d of schema version", len(v))
+	}
+
+	return binary.BigEndian.Uint64(v), true, nil
+}
+
+func putSchemaVersionFunc(version uint64) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(META_BUCKET))
+		if err != nil {
+			return err
+		}
+
+		return b.Put(schemaVersionKey, binary.BigEndian.AppendUint64(nil, version))
+	}
+}
+
+// isEmptyDb checks if the db has no buckets, which is a new db.
+func isEmptyDb(tx *bbolt.Tx) bool {
+	k, _ := tx.Cursor().First()
+	return k == nil
+}
+
+// getPendingMigrations returns the schema version of the db, and the migrations to run. isnew is set for a new db,
+// which has nothing to migrate.
+func getPendingMigrations(db *bbolt.DB) (version uint64, pending []*Migration, isnew bool, err error) {
+	err = db.View(func(tx *bbolt.Tx) error {
+		var found bool
+		var err error
+		version, found, err = getSchemaVersion(tx)
+		if err != nil {
+			return err
+		}
+		if !found && isEmptyDb(tx) {
+			isnew = true
+			return nil
+		}
+		if version > LatestSchemaVersion() {
+			return fmt.Errorf("%w: db is at %d, supported %d", ErrDbSchemaTooNew, version, LatestSchemaVersion())
+		}
+		for _, m := range migrations {
+			if m.Version > version {
+				pending = append(pending, m)
+			}
+		}
+		return nil
+	})
+
+	return
+}
+
+// backupDb copies the db into dir before it is migrated from version, and returns the path of the copy.
+func backupDb(db *bbolt.DB, dir string, version uint64) (string, error) {
+	if dir == "" {
+		dir = filepath.Dir(db.Path())
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s.v%d.%s.bak", filepath.Base(db.Path()), version, time.Now().UTC().Format("20060102T150405Z")))
+
+	if err := db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(path, 0o600)
+	}); err != nil {
+		return "", fmt.Errorf("failed to back up db to %s: %w", path, err)
+	}
+
+	return path, nil
+}
+
+// migrateDb runs the pending migrations of the db after copying it to backupdir. A dry run runs the migrations in a
+// transaction that is rolled back, so the db is not changed.
+func migrateDb(db *bbolt.DB, backupdir string, dryrun bool) (*MigrationResult, error) {
+	version, pending, isnew, err := getPendingMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrationResult{FromVersion: version, ToVersion: version, Applied: pending}
+	if isnew {
+		result.ToVersion = LatestSchemaVersion()
+		if dryrun {
+			return result, nil
+		}
+		return result, db.Update(putSchemaVersionFunc(result.ToVersion))
+	}
+	if len(pending) == 0 {
+		return result, nil
+	}
+	result.ToVersion = pending[len(pending)-1].Version
+
+	if dryrun {
+		err := db.Update(func(tx *bbolt.Tx) error {
+			for _, m := range pending {
+				if err := m.migrate(tx); err != nil {
+					return fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+				}
+				logger.Info("dry run of db migration", "version", m.Version, "name", m.Name)
+			}
+			return errMigrationDryRun
+		})
+		if !errors.Is(err, errMigrationDryRun) {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	result.BackupPath, err = backupDb(db, backupdir, version)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("backed up db before migration", "path", result.BackupPath, "version", version)
+
+	for _, m := range pending {
+		if err := db.Update(func(tx *bbolt.Tx) error {
+			if err := m.migrate(tx); err != nil {
+				return err
+			}
+			return putSchemaVersionFunc(m.Version)(tx)
+		}); err != nil {
+			return nil, fmt.Errorf("migration %d (%s), restore from %s if needed: %w", m.Version, m.Name, result.BackupPath, err)
+		}
+		logger.Info("migrated db", "version", m.Version, "name", m.Name)
+	}
+
+	if err := db.Sync(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *Svc) migrateDb() error {
+	_, err := migrateDb(s.db, s.config.DbBackupDir, false)
+	return err
+}
+
+// MigrateDb opens the db of the config, and runs the pending migrations, or only checks them for a dry run. The db
+// must not be used by a running service.
+func MigrateDb(cfg *GiTrimConfig, dryrun bool) (*MigrationResult, error) {
+	if cfg.DbPath == "" {
+		return nil, errors.New("db_path is empty")
+	}
+
+	db, err := bbolt.Open(cfg.DbPath, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open db %s, is it used by a running service: %w", cfg.DbPath, err)
+	}
+	defer db.Close()
+
+	return migrateDb(db, cfg.DbBackupDir, dryrun)
+}
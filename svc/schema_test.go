@@ -0,0 +1,126 @@
+package svc
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.etcd.io/bbolt"
+)
+
+// getTestSchemaVersion returns the schema version of the db at path.
+func getTestSchemaVersion(t *testing.T, path string) (uint64, bool) {
+	t.Helper()
+
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var version uint64
+	var found bool
+	if err := db.View(func(tx *bbolt.Tx) error {
+		version, found, err = getSchemaVersion(tx)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return version, found
+}
+
+func TestNew_schemaVersion(t *testing.T) {
+	dir := t.TempDir()
+	dbpath := filepath.Join(dir, "gitrim.db")
+
+	s := newTestSvc(t, &GiTrimConfig{DbPath: dbpath})
+	s.Close()
+	if version, _ := getTestSchemaVersion(t, dbpath); version != LatestSchemaVersion() {
+		t.Errorf("want new db at version %d, got %d", LatestSchemaVersion(), version)
+	}
+	if backups, _ := filepath.Glob(filepath.Join(dir, "*.bak")); len(backups) != 0 {
+		t.Errorf("want no backups for new db, got %v", backups)
+	}
+
+	db, err := bbolt.Open(dbpath, 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(putSchemaVersionFunc(LatestSchemaVersion() + 1)); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	if _, err := New(&GiTrimConfig{DbPath: dbpath}); !errors.Is(err, ErrDbSchemaTooNew) {
+		t.Errorf("want error for newer schema, got %v", err)
+	}
+}
+
+func TestMigrateDb(t *testing.T) {
+	dir := t.TempDir()
+	dbpath := filepath.Join(dir, "gitrim.db")
+	backupdir := filepath.Join(dir, "backups")
+	if err := os.Mkdir(backupdir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	// a db from before the schema version, with a stat to move.
+	db, err := bbolt.Open(dbpath, 0o600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := []byte("legacy")
+	if err := db.Update(putRepoSyncFunc(id, &DbRepoSync{
+		SyncData: &RepoSync{Id: "legacy"},
+		Stat: &SyncStat{
+			LastSyncFromCommit: "0000000000000000000000000000000000000001",
+			FromDfs:            []string{"0000000000000000000000000000000000000001"},
+		},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	cfg := &GiTrimConfig{DbPath: dbpath, DbBackupDir: backupdir}
+
+	dryrun, err := MigrateDb(cfg, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dryrun.FromVersion != 0 || dryrun.ToVersion != LatestSchemaVersion() || len(dryrun.Applied) != len(migrations) || dryrun.BackupPath != "" {
+		t.Errorf("unexpected dry run: %+v", dryrun)
+	}
+	if _, found := getTestSchemaVersion(t, dbpath); found {
+		t.Errorf("dry run saves schema version")
+	}
+
+	s := newTestSvc(t, cfg)
+	if reposync, err := getRepoSyncFromDb(s.db, id); err != nil || hasStatMaps(reposync.Stat) {
+		t.Errorf("stat is not migrated: %v, %v", reposync, err)
+	}
+	s.Close()
+
+	if version, _ := getTestSchemaVersion(t, dbpath); version != LatestSchemaVersion() {
+		t.Errorf("want db at version %d, got %d", LatestSchemaVersion(), version)
+	}
+	backups, err := filepath.Glob(filepath.Join(backupdir, "gitrim.db.v0.*.bak"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("want 1 backup, got %v", backups)
+	}
+	if _, found := getTestSchemaVersion(t, backups[0]); found {
+		t.Errorf("backup is taken after migration")
+	}
+
+	again, err := MigrateDb(cfg, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Applied) != 0 || again.BackupPath != "" {
+		t.Errorf("want nothing to migrate, got %+v", again)
+	}
+}
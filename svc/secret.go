@@ -3,6 +3,7 @@ package svc
 import (
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
 	"fmt"
 )
 
@@ -35,3 +36,50 @@ func decodeSecret(encryptor cipher.AEAD, secret []byte) ([]byte, error) {
 
 	return id, nil
 }
+
+// secretVersion is the first byte of the secrets with the id of the key sealing them.
+const secretVersion byte = 1
+
+// keyring holds the AES keys by their ids. New secrets are sealed with the active key, and embed its id so they can
+// be opened after the active key changes.
+type keyring struct {
+	keys     map[uint32]cipher.AEAD
+	activeId uint32
+}
+
+// newSecret creates a new secret for the id with the active key. The secret is the version, the big endian key id,
+// and the sealed id.
+func (k *keyring) newSecret(id []byte) ([]byte, error) {
+	sealed, err := newSecret(k.keys[k.activeId], id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := binary.BigEndian.AppendUint32([]byte{secretVersion}, k.activeId)
+
+	return append(secret, sealed...), nil
+}
+
+// decodeSecret opens the secret, and returns the id and the id of the key sealing it. The secrets created before the
+// keyring have no key id, and are sealed with key 0.
+func (k *keyring) decodeSecret(secret []byte) ([]byte, uint32, error) {
+	if len(secret) > 5 && secret[0] == secretVersion {
+		keyid := binary.BigEndian.Uint32(secret[1:5])
+		if encryptor, found := k.keys[keyid]; found {
+			if id, err := decodeSecret(encryptor, secret[5:]); err == nil {
+				return id, keyid, nil
+			}
+		}
+	}
+
+	encryptor, found := k.keys[0]
+	if !found {
+		return nil, 0, fmt.Errorf("no key to unseal the secret")
+	}
+	id, err := decodeSecret(encryptor, secret)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return id, 0, nil
+}
@@ -3,6 +3,7 @@ package svc
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"encoding/hex"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -35,3 +36,80 @@ func TestSVC_newSecret(t *testing.T) {
 		t.Fatalf("want: %v, got: %v", toencode, decoded)
 	}
 }
+
+func TestSvc_setupCipher(t *testing.T) {
+	id := []byte("id of the repo sync")
+	key0 := "0f0e0d0c0b0a09080706050403020100"
+	key1 := "000102030405060708090a0b0c0d0e0f"
+	key2 := "101112131415161718191a1b1c1d1e1f"
+
+	newKeyring := func(t *testing.T, cfg *GiTrimConfig) *keyring {
+		t.Helper()
+
+		s := &Svc{config: cfg}
+		if err := s.setupCipher(); err != nil {
+			t.Fatal(err)
+		}
+		return s.keyring
+	}
+
+	legacy := newKeyring(t, &GiTrimConfig{AesKey: key0})
+	legacysecret, err := newSecret(legacy.keys[0], id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := newKeyring(t, &GiTrimConfig{AesKey: key0, AesKeys: []*AesKey{{Id: 1, Key: key1}}})
+	oldsecret, err := old.newSecret(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k := newKeyring(t, &GiTrimConfig{AesKey: key0, AesKeys: []*AesKey{{Id: 1, Key: key1}, {Id: 2, Key: key2}}})
+	if k.activeId != 2 {
+		t.Errorf("want the largest key id active, got %d", k.activeId)
+	}
+	newsecret, err := k.newSecret(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for keyid, secret := range map[uint32][]byte{0: legacysecret, 1: oldsecret, 2: newsecret} {
+		decoded, gotkeyid, err := k.decodeSecret(secret)
+		if err != nil {
+			t.Fatalf("key %d: %v", keyid, err)
+		}
+		if gotkeyid != keyid || !cmp.Equal(decoded, id) {
+			t.Errorf("want %s sealed with key %d, got %s with key %d", id, keyid, decoded, gotkeyid)
+		}
+	}
+
+	if _, _, err := old.decodeSecret(newsecret); err == nil {
+		t.Errorf("want failure without the key sealing the secret")
+	}
+
+	active := newKeyring(t, &GiTrimConfig{AesKeys: []*AesKey{{Id: 1, Key: key1}, {Id: 2, Key: key2}}, ActiveAesKeyId: 1})
+	if active.activeId != 1 {
+		t.Errorf("want active key 1, got %d", active.activeId)
+	}
+
+	for name, cfg := range map[string]*GiTrimConfig{
+		"zero key required":  {RequireAesKey: true},
+		"zero aes key":       {RequireAesKey: true, AesKey: hex.EncodeToString(zeroKey)},
+		"zero active key":    {RequireAesKey: true, AesKeys: []*AesKey{{Id: 1, Key: hex.EncodeToString(zeroKey)}}},
+		"zero key id":        {AesKeys: []*AesKey{{Key: key1}}},
+		"duplicated key id":  {AesKeys: []*AesKey{{Id: 1, Key: key1}, {Id: 1, Key: key2}}},
+		"unknown active key": {AesKeys: []*AesKey{{Id: 1, Key: key1}}, ActiveAesKeyId: 2},
+		"short key":          {AesKeys: []*AesKey{{Id: 1, Key: "0001"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := (&Svc{config: cfg}).setupCipher(); err == nil {
+				t.Errorf("want error for %v", cfg)
+			}
+		})
+	}
+
+	if err := (&Svc{config: &GiTrimConfig{RequireAesKey: true, AesKey: hex.EncodeToString(zeroKey), AesKeys: []*AesKey{{Id: 1, Key: key1}}}}).setupCipher(); err != nil {
+		t.Errorf("want the zero key allowed to open the old secrets: %v", err)
+	}
+}
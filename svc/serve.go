@@ -0,0 +1,106 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+var ErrEmptyAdminAddress = errors.New("empty admin address")
+
+// NewGrpcServer creates a [grpc.Server] with the GiTrim service, the health service, and the reflection service registered.
+// The returned [health.Server] reports the GiTrim service as serving.
+//
+// If admin_tls is configured, the server serves with TLS. If the auth is configured, the callers of the GiTrim service and the reflection service must be authenticated, and
+// are checked against the role bindings before the interceptors in opts. The health service is open to all.
+func (s *Svc) NewGrpcServer(opts ...grpc.ServerOption) (*grpc.Server, *health.Server) {
+	serveropts := s.authServerOptions()
+	if s.adminTLS != nil {
+		serveropts = append(serveropts, grpc.Creds(credentials.NewTLS(s.adminTLS)))
+	}
+	server := grpc.NewServer(append(serveropts, opts...)...)
+
+	RegisterGiTrimServer(server, s)
+
+	healthserver := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthserver)
+	healthserver.SetServingStatus(GiTrim_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	reflection.Register(server)
+
+	return server, healthserver
+}
+
+// Serve listens on admin_address and serves the gRPC service until ctx is done.
+// See [Svc.ServeListener].
+func (s *Svc) Serve(ctx context.Context, opts ...grpc.ServerOption) error {
+	if s.config.AdminAddress == "" {
+		return ErrEmptyAdminAddress
+	}
+
+	lis, err := net.Listen("tcp", s.config.AdminAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.AdminAddress, err)
+	}
+
+	return s.ServeListener(ctx, lis, opts...)
+}
+
+// ServeListener serves the gRPC service on lis until ctx is done.
+//
+// Once ctx is done, the health service reports not serving, the streams of [Svc.WatchSyncEvents] end, and the
+// server stops accepting new requests and waits for the pending ones to finish. If the pending requests don't finish in
+// shutdown_wait_secs, the server is stopped forcefully.
+func (s *Svc) ServeListener(ctx context.Context, lis net.Listener, opts ...grpc.ServerOption) error {
+	server, healthserver := s.NewGrpcServer(opts...)
+
+	serveerr := make(chan error, 1)
+	go func() {
+		logger.Info("serving grpc", "address", lis.Addr().String())
+		serveerr <- server.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveerr:
+		return err
+	case <-ctx.Done():
+	}
+
+	waitsecs := s.config.GetProperShutdownWaitSecs()
+	logger.Info("shutting down grpc server", "wait-secs", waitsecs)
+	healthserver.Shutdown()
+	// the watch streams never finish by themselves.
+	s.events.dropAll()
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(time.Duration(waitsecs) * time.Second)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		logger.Info("grpc server stopped")
+	case <-timer.C:
+		logger.Warn("grpc server failed to stop in time, force stopping", "wait-secs", waitsecs)
+		server.Stop()
+		<-stopped
+	}
+
+	if err := <-serveerr; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package svc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestSvc creates a [Svc] with a temporary db, which is removed when the test finishes.
+func newTestSvc(t *testing.T, cfg *GiTrimConfig) *Svc {
+	t.Helper()
+
+	if cfg == nil {
+		cfg = &GiTrimConfig{}
+	}
+	if cfg.DbPath == "" {
+		cfg.DbPath = t.TempDir() + "/gitrim.db"
+	}
+	s, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+// serveTestSvc serves s on an in-memory listener, and returns a client connection to it.
+func serveTestSvc(t *testing.T, s *Svc, opts ...grpc.ServerOption) (*grpc.ClientConn, context.CancelFunc, <-chan error) {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- s.ServeListener(ctx, lis, opts...)
+	}()
+	t.Cleanup(cancel)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, cancel, served
+}
+
+func TestSvc_ServeListener(t *testing.T) {
+	s := newTestSvc(t, &GiTrimConfig{ShutdownWaitSecs: 1})
+	conn, cancel, served := serveTestSvc(t, s)
+
+	ctx := context.Background()
+
+	health, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: GiTrim_ServiceDesc.ServiceName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if health.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("want serving, got %s", health.Status)
+	}
+
+	_, err = NewGiTrimClient(conn).GetRepoSync(ctx, &GetRepoSyncRequest{Id: "00"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("want not found, got %v", err)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("failed to shutdown: %s", err)
+	}
+}
@@ -1,42 +1,132 @@
 package svc
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"slices"
+
+	"go.etcd.io/bbolt"
 )
 
 var zeroKey []byte = make([]byte, 16)
 
-// setupCipher
-func (s *Svc) setupCipher() error {
-	keyHex := s.config.AesKey
-	if keyHex == "" {
-		logger.Warn("empty cipher key")
-		keyHex = hex.EncodeToString(zeroKey)
-	}
+var ErrZeroAesKey = errors.New("the active aes key is the all-zero key, set aes_key or aes_keys")
 
+// newAesCipher parses the hex of the key and creates the AEAD for it.
+func newAesCipher(keyHex string) (cipher.AEAD, []byte, error) {
 	key, err := hex.DecodeString(keyHex)
 	if err != nil {
-		return fmt.Errorf("failed to parse hex for key: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse hex for key: %w", err)
 	}
 
 	if len(key) != aes.BlockSize {
-		return fmt.Errorf("length of parse key %d is not right", len(key))
+		return nil, nil, fmt.Errorf("length of parse key %d is not right", len(key))
 	}
 
 	b, err := aes.NewCipher(key)
 	if err != nil {
-		return fmt.Errorf("failed to create id block: %w", err)
+		return nil, nil, fmt.Errorf("failed to create id block: %w", err)
 	}
 
 	v, err := cipher.NewGCM(b)
 	if err != nil {
-		return fmt.Errorf("failed to create GCM: %w", err)
+		return nil, nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return v, key, nil
+}
+
+// setupCipher creates the keyring from aes_key and aes_keys.
+func (s *Svc) setupCipher() error {
+	k := &keyring{keys: make(map[uint32]cipher.AEAD)}
+	var zeroids []uint32
+
+	// aes_key is key 0, which seals the secrets created before the keyring.
+	if s.config.AesKey != "" || len(s.config.AesKeys) == 0 {
+		keyHex := s.config.AesKey
+		if keyHex == "" {
+			logger.Warn("empty cipher key")
+			keyHex = hex.EncodeToString(zeroKey)
+		}
+		v, key, err := newAesCipher(keyHex)
+		if err != nil {
+			return err
+		}
+		k.keys[0] = v
+		if bytes.Equal(key, zeroKey) {
+			zeroids = append(zeroids, 0)
+		}
+	}
+
+	for _, c := range s.config.AesKeys {
+		if c.Id == 0 {
+			return fmt.Errorf("id of aes key must be positive")
+		}
+		if _, found := k.keys[c.Id]; found {
+			return fmt.Errorf("duplicated aes key id %d", c.Id)
+		}
+		v, key, err := newAesCipher(c.Key)
+		if err != nil {
+			return fmt.Errorf("aes key %d: %w", c.Id, err)
+		}
+		k.keys[c.Id] = v
+		if bytes.Equal(key, zeroKey) {
+			zeroids = append(zeroids, c.Id)
+		}
+		k.activeId = max(k.activeId, c.Id)
+	}
+
+	if s.config.ActiveAesKeyId != 0 {
+		if _, found := k.keys[s.config.ActiveAesKeyId]; !found {
+			return fmt.Errorf("active aes key %d is not in aes_keys", s.config.ActiveAesKeyId)
+		}
+		k.activeId = s.config.ActiveAesKeyId
+	}
+
+	if s.config.RequireAesKey && slices.Contains(zeroids, k.activeId) {
+		return ErrZeroAesKey
+	}
+
+	s.keyring = k
+
+	return nil
+}
+
+// checkSecretKeys logs the number of the secrets sealed with each key, so the secrets sealed with the keys other than
+// the active one can be rotated before the keys are removed from the keyring.
+func (s *Svc) checkSecretKeys() error {
+	counts := make(map[uint32]int)
+	unknown := 0
+
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(ID_TO_SECRET_BUCKET))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			if _, keyid, err := s.keyring.decodeSecret(v); err != nil {
+				unknown++
+			} else {
+				counts[keyid]++
+			}
+			return nil
+		})
+	}); err != nil {
+		return err
 	}
 
-	s.encryptor = v
+	for keyid, count := range counts {
+		if keyid != s.keyring.activeId {
+			logger.Warn("secrets sealed with inactive aes key, rotate them before removing the key", "key-id", keyid, "count", count)
+		}
+	}
+	if unknown > 0 {
+		logger.Warn("secrets sealed with aes keys not in the keyring", "count", unknown)
+	}
 
 	return nil
 }
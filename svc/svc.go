@@ -2,9 +2,10 @@
 package svc
 
 import (
-	"crypto/cipher"
+	"crypto/tls"
 	"net/http"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.etcd.io/bbolt"
 )
 
@@ -25,9 +26,32 @@ type Svc struct {
 	// we are going to risk it.
 	UnsafeGiTrimServer
 
-	encryptor cipher.AEAD
+	// keyring seals the secrets of the repo syncs.
+	keyring *keyring
 
 	idmutex chan map[string]*waitingChan
+
+	// objectCache is nil if the cache is not configured.
+	objectCache *objectCache
+
+	// jobNotify wakes up an idle job worker when a job is enqueued.
+	jobNotify chan struct{}
+
+	// events publishes the sync events to WatchSyncEvents.
+	events *eventBus
+
+	// audit records the pushes to the repos.
+	audit *auditLog
+
+	// metrics is the registry of the prometheus metrics.
+	metrics *prometheus.Registry
+
+	// authenticator identifies the callers of the rpcs, nil if the auth is disabled.
+	authenticator Authenticator
+
+	// adminTLS and webhookTLS are the tls configs of the listeners, nil if they serve in plain text.
+	adminTLS   *tls.Config
+	webhookTLS *tls.Config
 }
 
 var _ GiTrimServer = (*Svc)(nil)
@@ -78,7 +78,7 @@ func (x LastSyncCommitStatus_Enum) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use LastSyncCommitStatus_Enum.Descriptor instead.
 func (LastSyncCommitStatus_Enum) EnumDescriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{4, 0}
+	return file_svc_proto_rawDescGZIP(), []int{5, 0}
 }
 
 type SubRepoCommitsCheck_Status int32
@@ -142,7 +142,292 @@ func (x SubRepoCommitsCheck_Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SubRepoCommitsCheck_Status.Descriptor instead.
 func (SubRepoCommitsCheck_Status) EnumDescriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{5, 0}
+	return file_svc_proto_rawDescGZIP(), []int{6, 0}
+}
+
+type PollState_Result int32
+
+const (
+	PollState_UNKNOWN PollState_Result = 0
+	// from repo has no new commits.
+	PollState_UP_TO_DATE PollState_Result = 1
+	// new commits of from repo are synced to the to repo.
+	PollState_SYNCED PollState_Result = 2
+	// from repo is diverged, or to repo has commits not synced back.
+	PollState_NEEDS_ATTENTION PollState_Result = 3
+	PollState_FAILED          PollState_Result = 4
+)
+
+// Enum value maps for PollState_Result.
+var (
+	PollState_Result_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "UP_TO_DATE",
+		2: "SYNCED",
+		3: "NEEDS_ATTENTION",
+		4: "FAILED",
+	}
+	PollState_Result_value = map[string]int32{
+		"UNKNOWN":         0,
+		"UP_TO_DATE":      1,
+		"SYNCED":          2,
+		"NEEDS_ATTENTION": 3,
+		"FAILED":          4,
+	}
+)
+
+func (x PollState_Result) Enum() *PollState_Result {
+	p := new(PollState_Result)
+	*p = x
+	return p
+}
+
+func (x PollState_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PollState_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_svc_proto_enumTypes[2].Descriptor()
+}
+
+func (PollState_Result) Type() protoreflect.EnumType {
+	return &file_svc_proto_enumTypes[2]
+}
+
+func (x PollState_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PollState_Result.Descriptor instead.
+func (PollState_Result) EnumDescriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{19, 0}
+}
+
+type Job_State int32
+
+const (
+	Job_UNKNOWN Job_State = 0
+	// waiting for a worker, or for the retry after a failure.
+	Job_PENDING   Job_State = 1
+	Job_RUNNING   Job_State = 2
+	Job_SUCCEEDED Job_State = 3
+	// failed with an error that cannot be retried, or ran out of attempts.
+	Job_FAILED Job_State = 4
+)
+
+// Enum value maps for Job_State.
+var (
+	Job_State_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "PENDING",
+		2: "RUNNING",
+		3: "SUCCEEDED",
+		4: "FAILED",
+	}
+	Job_State_value = map[string]int32{
+		"UNKNOWN":   0,
+		"PENDING":   1,
+		"RUNNING":   2,
+		"SUCCEEDED": 3,
+		"FAILED":    4,
+	}
+)
+
+func (x Job_State) Enum() *Job_State {
+	p := new(Job_State)
+	*p = x
+	return p
+}
+
+func (x Job_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Job_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_svc_proto_enumTypes[3].Descriptor()
+}
+
+func (Job_State) Type() protoreflect.EnumType {
+	return &file_svc_proto_enumTypes[3]
+}
+
+func (x Job_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Job_State.Descriptor instead.
+func (Job_State) EnumDescriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{32, 0}
+}
+
+type SyncEvent_Type int32
+
+const (
+	SyncEvent_UNKNOWN SyncEvent_Type = 0
+	// new commits of the from repo are filtered into commits of the to repo.
+	SyncEvent_COMMITS_FILTERED SyncEvent_Type = 1
+	// new commits of the to repo are expanded into commits of the from repo.
+	SyncEvent_COMMITS_EXPANDED SyncEvent_Type = 2
+	// a branch of the from repo or the to repo is pushed.
+	SyncEvent_PUSHED SyncEvent_Type = 3
+	// new commits of the to repo are rejected, because they change files
+	// outside of the filter, or have pgp signatures.
+	SyncEvent_CONTRIBUTION_REJECTED SyncEvent_Type = 4
+)
+
+// Enum value maps for SyncEvent_Type.
+var (
+	SyncEvent_Type_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "COMMITS_FILTERED",
+		2: "COMMITS_EXPANDED",
+		3: "PUSHED",
+		4: "CONTRIBUTION_REJECTED",
+	}
+	SyncEvent_Type_value = map[string]int32{
+		"UNKNOWN":               0,
+		"COMMITS_FILTERED":      1,
+		"COMMITS_EXPANDED":      2,
+		"PUSHED":                3,
+		"CONTRIBUTION_REJECTED": 4,
+	}
+)
+
+func (x SyncEvent_Type) Enum() *SyncEvent_Type {
+	p := new(SyncEvent_Type)
+	*p = x
+	return p
+}
+
+func (x SyncEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SyncEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_svc_proto_enumTypes[4].Descriptor()
+}
+
+func (SyncEvent_Type) Type() protoreflect.EnumType {
+	return &file_svc_proto_enumTypes[4]
+}
+
+func (x SyncEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SyncEvent_Type.Descriptor instead.
+func (SyncEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{39, 0}
+}
+
+type AuditEvent_Operation int32
+
+const (
+	AuditEvent_UNKNOWN                 AuditEvent_Operation = 0
+	AuditEvent_INIT_REPO_SYNC          AuditEvent_Operation = 1
+	AuditEvent_SYNC_TO_SUB_REPO        AuditEvent_Operation = 2
+	AuditEvent_COMMITS_FROM_SUB_REPO   AuditEvent_Operation = 3
+	AuditEvent_COMMITS_FROM_PATCHES    AuditEvent_Operation = 4
+	AuditEvent_UPDATE_REPO_SYNC_FILTER AuditEvent_Operation = 5
+)
+
+// Enum value maps for AuditEvent_Operation.
+var (
+	AuditEvent_Operation_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "INIT_REPO_SYNC",
+		2: "SYNC_TO_SUB_REPO",
+		3: "COMMITS_FROM_SUB_REPO",
+		4: "COMMITS_FROM_PATCHES",
+		5: "UPDATE_REPO_SYNC_FILTER",
+	}
+	AuditEvent_Operation_value = map[string]int32{
+		"UNKNOWN":                 0,
+		"INIT_REPO_SYNC":          1,
+		"SYNC_TO_SUB_REPO":        2,
+		"COMMITS_FROM_SUB_REPO":   3,
+		"COMMITS_FROM_PATCHES":    4,
+		"UPDATE_REPO_SYNC_FILTER": 5,
+	}
+)
+
+func (x AuditEvent_Operation) Enum() *AuditEvent_Operation {
+	p := new(AuditEvent_Operation)
+	*p = x
+	return p
+}
+
+func (x AuditEvent_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditEvent_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_svc_proto_enumTypes[5].Descriptor()
+}
+
+func (AuditEvent_Operation) Type() protoreflect.EnumType {
+	return &file_svc_proto_enumTypes[5]
+}
+
+func (x AuditEvent_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuditEvent_Operation.Descriptor instead.
+func (AuditEvent_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{40, 0}
+}
+
+type ImportRequest_OnConflict int32
+
+const (
+	// fails the import, and nothing is imported.
+	ImportRequest_FAIL ImportRequest_OnConflict = 0
+	// keeps the existing repo sync.
+	ImportRequest_SKIP ImportRequest_OnConflict = 1
+	// replaces the existing repo sync.
+	ImportRequest_OVERWRITE ImportRequest_OnConflict = 2
+)
+
+// Enum value maps for ImportRequest_OnConflict.
+var (
+	ImportRequest_OnConflict_name = map[int32]string{
+		0: "FAIL",
+		1: "SKIP",
+		2: "OVERWRITE",
+	}
+	ImportRequest_OnConflict_value = map[string]int32{
+		"FAIL":      0,
+		"SKIP":      1,
+		"OVERWRITE": 2,
+	}
+)
+
+func (x ImportRequest_OnConflict) Enum() *ImportRequest_OnConflict {
+	p := new(ImportRequest_OnConflict)
+	*p = x
+	return p
+}
+
+func (x ImportRequest_OnConflict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportRequest_OnConflict) Descriptor() protoreflect.EnumDescriptor {
+	return file_svc_proto_enumTypes[6].Descriptor()
+}
+
+func (ImportRequest_OnConflict) Type() protoreflect.EnumType {
+	return &file_svc_proto_enumTypes[6]
+}
+
+func (x ImportRequest_OnConflict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportRequest_OnConflict.Descriptor instead.
+func (ImportRequest_OnConflict) EnumDescriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{52, 0}
 }
 
 // GitRepoIdentifier is a combination of [organization or user]/[repo-name] on a
@@ -217,7 +502,7 @@ func (x *GitRepoIdentifier) GetRepo() string {
 // of strings. Filter is considered changed if and only if canonical_filters are
 // changed.
 // Changing filter means a new repo, and the whole history of the sub repo will
-// need to be rebuilt.
+// need to be rebuilt, see UpdateRepoSyncFilter.
 type Filter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -393,6 +678,10 @@ func (x *RepoSync) GetRootCommits() []string {
 }
 
 // SyncStat contains the information about the sync-ing between two repos.
+//
+// The synced commits and their mappings are saved separately from the heads,
+// and are only filled by GetRepoSync with with_stat_maps. The ones of the
+// previous filters are kept for auditing, and are filled the same way.
 type SyncStat struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -480,6 +769,80 @@ func (x *SyncStat) GetToToFrom() map[string]string {
 	return nil
 }
 
+// FilterRevision is a filter of a repo sync replaced by UpdateRepoSyncFilter,
+// and the sub repo generated by it.
+type FilterRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filter   *Filter   `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	ToBranch string    `protobuf:"bytes,2,opt,name=to_branch,json=toBranch,proto3" json:"to_branch,omitempty"`
+	SyncStat *SyncStat `protobuf:"bytes,3,opt,name=sync_stat,json=syncStat,proto3" json:"sync_stat,omitempty"`
+	// unix seconds when the filter is replaced.
+	ReplacedAt int64 `protobuf:"varint,4,opt,name=replaced_at,json=replacedAt,proto3" json:"replaced_at,omitempty"`
+}
+
+func (x *FilterRevision) Reset() {
+	*x = FilterRevision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterRevision) ProtoMessage() {}
+
+func (x *FilterRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterRevision.ProtoReflect.Descriptor instead.
+func (*FilterRevision) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FilterRevision) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *FilterRevision) GetToBranch() string {
+	if x != nil {
+		return x.ToBranch
+	}
+	return ""
+}
+
+func (x *FilterRevision) GetSyncStat() *SyncStat {
+	if x != nil {
+		return x.SyncStat
+	}
+	return nil
+}
+
+func (x *FilterRevision) GetReplacedAt() int64 {
+	if x != nil {
+		return x.ReplacedAt
+	}
+	return 0
+}
+
 // LastSyncCommitStatus indicates the status the last sync commit compared with
 // the repo on remote.
 type LastSyncCommitStatus struct {
@@ -491,7 +854,7 @@ type LastSyncCommitStatus struct {
 func (x *LastSyncCommitStatus) Reset() {
 	*x = LastSyncCommitStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[4]
+		mi := &file_svc_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -504,7 +867,7 @@ func (x *LastSyncCommitStatus) String() string {
 func (*LastSyncCommitStatus) ProtoMessage() {}
 
 func (x *LastSyncCommitStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[4]
+	mi := &file_svc_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -517,7 +880,7 @@ func (x *LastSyncCommitStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LastSyncCommitStatus.ProtoReflect.Descriptor instead.
 func (*LastSyncCommitStatus) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{4}
+	return file_svc_proto_rawDescGZIP(), []int{5}
 }
 
 type SubRepoCommitsCheck struct {
@@ -529,7 +892,7 @@ type SubRepoCommitsCheck struct {
 func (x *SubRepoCommitsCheck) Reset() {
 	*x = SubRepoCommitsCheck{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[5]
+		mi := &file_svc_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -542,7 +905,7 @@ func (x *SubRepoCommitsCheck) String() string {
 func (*SubRepoCommitsCheck) ProtoMessage() {}
 
 func (x *SubRepoCommitsCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[5]
+	mi := &file_svc_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -555,7 +918,7 @@ func (x *SubRepoCommitsCheck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubRepoCommitsCheck.ProtoReflect.Descriptor instead.
 func (*SubRepoCommitsCheck) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{5}
+	return file_svc_proto_rawDescGZIP(), []int{6}
 }
 
 type InitRepoSyncRequest struct {
@@ -582,7 +945,7 @@ type InitRepoSyncRequest struct {
 func (x *InitRepoSyncRequest) Reset() {
 	*x = InitRepoSyncRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[6]
+		mi := &file_svc_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -595,7 +958,7 @@ func (x *InitRepoSyncRequest) String() string {
 func (*InitRepoSyncRequest) ProtoMessage() {}
 
 func (x *InitRepoSyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[6]
+	mi := &file_svc_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,7 +971,7 @@ func (x *InitRepoSyncRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InitRepoSyncRequest.ProtoReflect.Descriptor instead.
 func (*InitRepoSyncRequest) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{6}
+	return file_svc_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *InitRepoSyncRequest) GetFromRepo() *GitRepoIdentifier {
@@ -660,12 +1023,16 @@ type InitRepoSyncResponse struct {
 
 	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// to_repo_created indicates the to repo is created through the forge api.
+	ToRepoCreated bool `protobuf:"varint,3,opt,name=to_repo_created,json=toRepoCreated,proto3" json:"to_repo_created,omitempty"`
+	// webhook_repos are the repos the webhook is registered on.
+	WebhookRepos []*GitRepoIdentifier `protobuf:"bytes,4,rep,name=webhook_repos,json=webhookRepos,proto3" json:"webhook_repos,omitempty"`
 }
 
 func (x *InitRepoSyncResponse) Reset() {
 	*x = InitRepoSyncResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[7]
+		mi := &file_svc_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -678,7 +1045,7 @@ func (x *InitRepoSyncResponse) String() string {
 func (*InitRepoSyncResponse) ProtoMessage() {}
 
 func (x *InitRepoSyncResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[7]
+	mi := &file_svc_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -691,7 +1058,7 @@ func (x *InitRepoSyncResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InitRepoSyncResponse.ProtoReflect.Descriptor instead.
 func (*InitRepoSyncResponse) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{7}
+	return file_svc_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *InitRepoSyncResponse) GetId() string {
@@ -708,6 +1075,20 @@ func (x *InitRepoSyncResponse) GetSecret() string {
 	return ""
 }
 
+func (x *InitRepoSyncResponse) GetToRepoCreated() bool {
+	if x != nil {
+		return x.ToRepoCreated
+	}
+	return false
+}
+
+func (x *InitRepoSyncResponse) GetWebhookRepos() []*GitRepoIdentifier {
+	if x != nil {
+		return x.WebhookRepos
+	}
+	return nil
+}
+
 type SyncToSubRepoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -722,7 +1103,7 @@ type SyncToSubRepoRequest struct {
 func (x *SyncToSubRepoRequest) Reset() {
 	*x = SyncToSubRepoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[8]
+		mi := &file_svc_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -735,7 +1116,7 @@ func (x *SyncToSubRepoRequest) String() string {
 func (*SyncToSubRepoRequest) ProtoMessage() {}
 
 func (x *SyncToSubRepoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[8]
+	mi := &file_svc_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +1129,7 @@ func (x *SyncToSubRepoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncToSubRepoRequest.ProtoReflect.Descriptor instead.
 func (*SyncToSubRepoRequest) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{8}
+	return file_svc_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SyncToSubRepoRequest) GetId() string {
@@ -792,7 +1173,7 @@ type SyncToSubRepoResponse struct {
 func (x *SyncToSubRepoResponse) Reset() {
 	*x = SyncToSubRepoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[9]
+		mi := &file_svc_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -805,7 +1186,7 @@ func (x *SyncToSubRepoResponse) String() string {
 func (*SyncToSubRepoResponse) ProtoMessage() {}
 
 func (x *SyncToSubRepoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[9]
+	mi := &file_svc_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -818,7 +1199,7 @@ func (x *SyncToSubRepoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncToSubRepoResponse.ProtoReflect.Descriptor instead.
 func (*SyncToSubRepoResponse) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{9}
+	return file_svc_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SyncToSubRepoResponse) GetNumberOfNewCommits() int32 {
@@ -860,7 +1241,7 @@ type CommitsFromSubRepoRequest struct {
 func (x *CommitsFromSubRepoRequest) Reset() {
 	*x = CommitsFromSubRepoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[10]
+		mi := &file_svc_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -873,7 +1254,7 @@ func (x *CommitsFromSubRepoRequest) String() string {
 func (*CommitsFromSubRepoRequest) ProtoMessage() {}
 
 func (x *CommitsFromSubRepoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[10]
+	mi := &file_svc_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -886,7 +1267,7 @@ func (x *CommitsFromSubRepoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitsFromSubRepoRequest.ProtoReflect.Descriptor instead.
 func (*CommitsFromSubRepoRequest) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{10}
+	return file_svc_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CommitsFromSubRepoRequest) GetId() string {
@@ -942,7 +1323,7 @@ type CommitsFromSubRepoResponse struct {
 func (x *CommitsFromSubRepoResponse) Reset() {
 	*x = CommitsFromSubRepoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[11]
+		mi := &file_svc_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -955,7 +1336,7 @@ func (x *CommitsFromSubRepoResponse) String() string {
 func (*CommitsFromSubRepoResponse) ProtoMessage() {}
 
 func (x *CommitsFromSubRepoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[11]
+	mi := &file_svc_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -968,7 +1349,7 @@ func (x *CommitsFromSubRepoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitsFromSubRepoResponse.ProtoReflect.Descriptor instead.
 func (*CommitsFromSubRepoResponse) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{11}
+	return file_svc_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CommitsFromSubRepoResponse) GetResult() SubRepoCommitsCheck_Status {
@@ -1024,7 +1405,7 @@ type CheckRepoSyncUpToDateRequest struct {
 func (x *CheckRepoSyncUpToDateRequest) Reset() {
 	*x = CheckRepoSyncUpToDateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[12]
+		mi := &file_svc_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1037,7 +1418,7 @@ func (x *CheckRepoSyncUpToDateRequest) String() string {
 func (*CheckRepoSyncUpToDateRequest) ProtoMessage() {}
 
 func (x *CheckRepoSyncUpToDateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[12]
+	mi := &file_svc_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1050,7 +1431,7 @@ func (x *CheckRepoSyncUpToDateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckRepoSyncUpToDateRequest.ProtoReflect.Descriptor instead.
 func (*CheckRepoSyncUpToDateRequest) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{12}
+	return file_svc_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CheckRepoSyncUpToDateRequest) GetId() string {
@@ -1072,7 +1453,7 @@ type CheckRepoSyncUpToDateResponse struct {
 func (x *CheckRepoSyncUpToDateResponse) Reset() {
 	*x = CheckRepoSyncUpToDateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[13]
+		mi := &file_svc_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1085,7 +1466,7 @@ func (x *CheckRepoSyncUpToDateResponse) String() string {
 func (*CheckRepoSyncUpToDateResponse) ProtoMessage() {}
 
 func (x *CheckRepoSyncUpToDateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[13]
+	mi := &file_svc_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1098,7 +1479,7 @@ func (x *CheckRepoSyncUpToDateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckRepoSyncUpToDateResponse.ProtoReflect.Descriptor instead.
 func (*CheckRepoSyncUpToDateResponse) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{13}
+	return file_svc_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CheckRepoSyncUpToDateResponse) GetFromRepoStatus() LastSyncCommitStatus_Enum {
@@ -1132,7 +1513,7 @@ type CheckCommitsFromSubRepoRequest struct {
 func (x *CheckCommitsFromSubRepoRequest) Reset() {
 	*x = CheckCommitsFromSubRepoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[14]
+		mi := &file_svc_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1145,7 +1526,7 @@ func (x *CheckCommitsFromSubRepoRequest) String() string {
 func (*CheckCommitsFromSubRepoRequest) ProtoMessage() {}
 
 func (x *CheckCommitsFromSubRepoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[14]
+	mi := &file_svc_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1158,7 +1539,7 @@ func (x *CheckCommitsFromSubRepoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckCommitsFromSubRepoRequest.ProtoReflect.Descriptor instead.
 func (*CheckCommitsFromSubRepoRequest) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{14}
+	return file_svc_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CheckCommitsFromSubRepoRequest) GetId() string {
@@ -1206,7 +1587,7 @@ type CheckCommitsFromSubRepoResponse struct {
 func (x *CheckCommitsFromSubRepoResponse) Reset() {
 	*x = CheckCommitsFromSubRepoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[15]
+		mi := &file_svc_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1219,7 +1600,7 @@ func (x *CheckCommitsFromSubRepoResponse) String() string {
 func (*CheckCommitsFromSubRepoResponse) ProtoMessage() {}
 
 func (x *CheckCommitsFromSubRepoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[15]
+	mi := &file_svc_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1232,7 +1613,7 @@ func (x *CheckCommitsFromSubRepoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckCommitsFromSubRepoResponse.ProtoReflect.Descriptor instead.
 func (*CheckCommitsFromSubRepoResponse) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{15}
+	return file_svc_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CheckCommitsFromSubRepoResponse) GetResult() SubRepoCommitsCheck_Status {
@@ -1276,12 +1657,15 @@ type GetRepoSyncRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// with_stat_maps fills the synced commits and their mappings in sync_stat
+	// and in the stats of previous_filters, which are only heads otherwise.
+	WithStatMaps bool `protobuf:"varint,2,opt,name=with_stat_maps,json=withStatMaps,proto3" json:"with_stat_maps,omitempty"`
 }
 
 func (x *GetRepoSyncRequest) Reset() {
 	*x = GetRepoSyncRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[16]
+		mi := &file_svc_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1294,7 +1678,7 @@ func (x *GetRepoSyncRequest) String() string {
 func (*GetRepoSyncRequest) ProtoMessage() {}
 
 func (x *GetRepoSyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[16]
+	mi := &file_svc_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1307,7 +1691,7 @@ func (x *GetRepoSyncRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRepoSyncRequest.ProtoReflect.Descriptor instead.
 func (*GetRepoSyncRequest) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{16}
+	return file_svc_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetRepoSyncRequest) GetId() string {
@@ -1317,6 +1701,13 @@ func (x *GetRepoSyncRequest) GetId() string {
 	return ""
 }
 
+func (x *GetRepoSyncRequest) GetWithStatMaps() bool {
+	if x != nil {
+		return x.WithStatMaps
+	}
+	return false
+}
+
 type GetRepoSyncResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1325,12 +1716,18 @@ type GetRepoSyncResponse struct {
 	RepoSync *RepoSync `protobuf:"bytes,1,opt,name=repo_sync,json=repoSync,proto3" json:"repo_sync,omitempty"`
 	Secret   string    `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
 	SyncStat *SyncStat `protobuf:"bytes,3,opt,name=sync_stat,json=syncStat,proto3" json:"sync_stat,omitempty"`
+	// filters replaced by UpdateRepoSyncFilter, oldest first.
+	PreviousFilters []*FilterRevision `protobuf:"bytes,4,rep,name=previous_filters,json=previousFilters,proto3" json:"previous_filters,omitempty"`
+	// poll_state is empty if the repo sync is not polled yet.
+	PollState *PollState `protobuf:"bytes,5,opt,name=poll_state,json=pollState,proto3" json:"poll_state,omitempty"`
+	// head of the last bundle of SyncToSubRepoBundle.
+	LastBundleToCommit string `protobuf:"bytes,6,opt,name=last_bundle_to_commit,json=lastBundleToCommit,proto3" json:"last_bundle_to_commit,omitempty"`
 }
 
 func (x *GetRepoSyncResponse) Reset() {
 	*x = GetRepoSyncResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_svc_proto_msgTypes[17]
+		mi := &file_svc_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1343,7 +1740,7 @@ func (x *GetRepoSyncResponse) String() string {
 func (*GetRepoSyncResponse) ProtoMessage() {}
 
 func (x *GetRepoSyncResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_svc_proto_msgTypes[17]
+	mi := &file_svc_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1356,7 +1753,7 @@ func (x *GetRepoSyncResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRepoSyncResponse.ProtoReflect.Descriptor instead.
 func (*GetRepoSyncResponse) Descriptor() ([]byte, []int) {
-	return file_svc_proto_rawDescGZIP(), []int{17}
+	return file_svc_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetRepoSyncResponse) GetRepoSync() *RepoSync {
@@ -1380,356 +1777,4174 @@ func (x *GetRepoSyncResponse) GetSyncStat() *SyncStat {
 	return nil
 }
 
-var File_svc_proto protoreflect.FileDescriptor
+func (x *GetRepoSyncResponse) GetPreviousFilters() []*FilterRevision {
+	if x != nil {
+		return x.PreviousFilters
+	}
+	return nil
+}
 
-var file_svc_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x73, 0x76, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x67, 0x69, 0x74,
-	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x22, 0x5e, 0x0a, 0x11, 0x47, 0x69, 0x74, 0x52, 0x65,
-	0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b,
-	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77,
-	0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x50, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x61, 0x77, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x61, 0x77, 0x54, 0x65, 0x78, 0x74, 0x12, 0x2b, 0x0a, 0x11,
-	0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63,
-	0x61, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x9b, 0x02, 0x0a, 0x08, 0x52, 0x65,
-	0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72,
-	0x65, 0x70, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72,
-	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65,
-	0x70, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63,
-	0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61,
-	0x6e, 0x63, 0x68, 0x12, 0x36, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x15,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
-	0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
-	0x69, 0x65, 0x72, 0x52, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x74,
-	0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x74, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69,
-	0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x73, 0x18, 0x29, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x6f, 0x6f, 0x74,
-	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x9c, 0x03, 0x0a, 0x08, 0x53, 0x79, 0x6e, 0x63,
-	0x53, 0x74, 0x61, 0x74, 0x12, 0x31, 0x0a, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x79, 0x6e,
-	0x63, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x72, 0x6f,
-	0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x5f,
-	0x64, 0x66, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x44,
-	0x66, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f,
-	0x74, 0x6f, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x10, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x6f, 0x5f, 0x64, 0x66, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x6f, 0x44, 0x66, 0x73, 0x12, 0x40, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d,
-	0x5f, 0x74, 0x6f, 0x5f, 0x74, 0x6f, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67,
-	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74,
-	0x61, 0x74, 0x2e, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x6f, 0x54, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x6f, 0x54, 0x6f, 0x12, 0x40, 0x0a, 0x0a, 0x74, 0x6f,
-	0x5f, 0x74, 0x6f, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
-	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63,
-	0x53, 0x74, 0x61, 0x74, 0x2e, 0x54, 0x6f, 0x54, 0x6f, 0x46, 0x72, 0x6f, 0x6d, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x08, 0x74, 0x6f, 0x54, 0x6f, 0x46, 0x72, 0x6f, 0x6d, 0x1a, 0x3b, 0x0a, 0x0d,
-	0x46, 0x72, 0x6f, 0x6d, 0x54, 0x6f, 0x54, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3b, 0x0a, 0x0d, 0x54, 0x6f, 0x54,
-	0x6f, 0x46, 0x72, 0x6f, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5e, 0x0a, 0x14, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79,
-	0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x46,
-	0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
-	0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x4d, 0x50, 0x54, 0x59, 0x10, 0x01, 0x12, 0x0c,
-	0x0a, 0x08, 0x41, 0x44, 0x56, 0x41, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06,
-	0x49, 0x4e, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x49, 0x56, 0x45,
-	0x52, 0x47, 0x45, 0x44, 0x10, 0x04, 0x22, 0x92, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x52, 0x65,
-	0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0x7b,
-	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
-	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x50,
-	0x41, 0x53, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x52, 0x4f, 0x4d, 0x5f,
-	0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x02, 0x12, 0x0f, 0x0a,
-	0x0b, 0x54, 0x4f, 0x5f, 0x44, 0x49, 0x56, 0x45, 0x52, 0x47, 0x45, 0x44, 0x10, 0x03, 0x12, 0x15,
-	0x0a, 0x11, 0x54, 0x4f, 0x5f, 0x4e, 0x4f, 0x5f, 0x4e, 0x45, 0x57, 0x5f, 0x43, 0x4f, 0x4d, 0x4d,
-	0x49, 0x54, 0x53, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x53,
-	0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x05, 0x22, 0x82, 0x02, 0x0a, 0x13,
-	0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6f,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
-	0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74,
-	0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x12,
-	0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
-	0x12, 0x36, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x15, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47,
-	0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
-	0x52, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f, 0x62,
-	0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x42,
-	0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
-	0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a,
-	0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x29, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73,
-	0x22, 0x3e, 0x0a, 0x14, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
-	0x22, 0x9c, 0x01, 0x0a, 0x14, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65,
-	0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72,
-	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12,
-	0x30, 0x0a, 0x14, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d,
-	0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f,
-	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63,
-	0x68, 0x12, 0x2c, 0x0a, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x74, 0x6f,
-	0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f,
-	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x54, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x22,
-	0x8a, 0x01, 0x0a, 0x15, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70,
-	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x15, 0x6e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x4f, 0x66, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d,
-	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x48, 0x65, 0x61,
-	0x64, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x48, 0x65, 0x61, 0x64, 0x22, 0xd4, 0x01, 0x0a,
-	0x19, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52,
-	0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x6f, 0x76,
-	0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61, 0x6e,
-	0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69,
-	0x64, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2c, 0x0a, 0x12,
-	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e,
-	0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69,
-	0x64, 0x65, 0x54, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6c,
-	0x6c, 0x6f, 0x77, 0x5f, 0x70, 0x67, 0x70, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x67,
-	0x70, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x6f,
-	0x5f, 0x70, 0x75, 0x73, 0x68, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x6f, 0x50,
-	0x75, 0x73, 0x68, 0x22, 0xf0, 0x02, 0x0a, 0x1a, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46,
-	0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x26, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
-	0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x43, 0x68,
-	0x65, 0x63, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x12, 0x4f, 0x0a, 0x10, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67,
-	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79,
-	0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x45,
-	0x6e, 0x75, 0x6d, 0x52, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a, 0x0e, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69,
-	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e,
-	0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e,
-	0x75, 0x6d, 0x52, 0x0c, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x2c, 0x0a, 0x12, 0x68, 0x61, 0x73, 0x5f, 0x67, 0x70, 0x67, 0x5f, 0x73, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x68, 0x61,
-	0x73, 0x47, 0x70, 0x67, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x25,
-	0x0a, 0x0e, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73,
-	0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
-	0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x43,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x2e, 0x0a, 0x1c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
-	0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x70, 0x54, 0x6f, 0x44, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xbd, 0x01, 0x0a, 0x1d, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x70, 0x54, 0x6f, 0x44, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x10, 0x66, 0x72, 0x6f, 0x6d,
-	0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
-	0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x52,
-	0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a, 0x0e, 0x74, 0x6f, 0x5f,
-	0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c,
-	0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0c, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xc0, 0x01, 0x0a, 0x1e, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65,
-	0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x6f, 0x76, 0x65,
-	0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
-	0x65, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x6f,
-	0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63,
-	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
-	0x65, 0x54, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6c, 0x6c,
-	0x6f, 0x77, 0x5f, 0x70, 0x67, 0x70, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x67, 0x70,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xd4, 0x02, 0x0a, 0x1f, 0x43, 0x68,
-	0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75,
-	0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
-	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
-	0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65,
-	0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4f, 0x0a,
-	0x10, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d,
-	0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0e,
-	0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b,
-	0x0a, 0x0e, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
-	0x73, 0x76, 0x63, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0c, 0x74,
-	0x6f, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x68,
-	0x61, 0x73, 0x5f, 0x67, 0x70, 0x67, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x68, 0x61, 0x73, 0x47, 0x70, 0x67, 0x53,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6a,
-	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0d, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73,
-	0x22, 0x24, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x93, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31,
-	0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x52,
-	0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e,
-	0x63, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x09, 0x73, 0x79, 0x6e,
-	0x63, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
-	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74,
-	0x61, 0x74, 0x52, 0x08, 0x73, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x32, 0xd4, 0x04, 0x0a,
-	0x06, 0x47, 0x69, 0x54, 0x72, 0x69, 0x6d, 0x12, 0x53, 0x0a, 0x0c, 0x49, 0x6e, 0x69, 0x74, 0x52,
-	0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x1f, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d,
-	0x2e, 0x73, 0x76, 0x63, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e,
-	0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69,
-	0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79,
-	0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x0d,
-	0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x20, 0x2e,
-	0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54,
-	0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x21, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e,
-	0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46,
-	0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x25, 0x2e, 0x67, 0x69, 0x74,
-	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46,
-	0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x26, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70,
-	0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6e, 0x0a, 0x15, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x70, 0x54, 0x6f,
-	0x44, 0x61, 0x74, 0x65, 0x12, 0x28, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
-	0x63, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x55,
-	0x70, 0x54, 0x6f, 0x44, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29,
-	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x70, 0x54, 0x6f, 0x44, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x74, 0x0a, 0x17, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53,
-	0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x2a, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
-	0x73, 0x76, 0x63, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73,
-	0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d,
-	0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x50, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63,
-	0x12, 0x1e, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1f, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x66, 0x61, 0x72, 0x64, 0x72, 0x65, 0x61, 0x6d, 0x2f, 0x67, 0x69, 0x74, 0x72, 0x69,
-	0x6d, 0x2f, 0x73, 0x76, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *GetRepoSyncResponse) GetPollState() *PollState {
+	if x != nil {
+		return x.PollState
+	}
+	return nil
 }
 
-var (
-	file_svc_proto_rawDescOnce sync.Once
-	file_svc_proto_rawDescData = file_svc_proto_rawDesc
-)
+func (x *GetRepoSyncResponse) GetLastBundleToCommit() string {
+	if x != nil {
+		return x.LastBundleToCommit
+	}
+	return ""
+}
 
-func file_svc_proto_rawDescGZIP() []byte {
-	file_svc_proto_rawDescOnce.Do(func() {
-		file_svc_proto_rawDescData = protoimpl.X.CompressGZIP(file_svc_proto_rawDescData)
-	})
-	return file_svc_proto_rawDescData
+// PollState is the state of the repo sync in the background scheduler.
+type PollState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// unix time of the last poll, zero if never polled.
+	LastRunAt int64 `protobuf:"varint,1,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`
+	// unix time of the next poll.
+	NextRunAt  int64            `protobuf:"varint,2,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	LastResult PollState_Result `protobuf:"varint,3,opt,name=last_result,json=lastResult,proto3,enum=gitrim.svc.PollState_Result" json:"last_result,omitempty"`
+	LastError  string           `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// number of polls in a row that are not UP_TO_DATE or SYNCED, which
+	// decides the backoff.
+	ConsecutiveFailures    int32                     `protobuf:"varint,5,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	LastNumberOfNewCommits int32                     `protobuf:"varint,6,opt,name=last_number_of_new_commits,json=lastNumberOfNewCommits,proto3" json:"last_number_of_new_commits,omitempty"`
+	LastFromRepoStatus     LastSyncCommitStatus_Enum `protobuf:"varint,7,opt,name=last_from_repo_status,json=lastFromRepoStatus,proto3,enum=gitrim.svc.LastSyncCommitStatus_Enum" json:"last_from_repo_status,omitempty"`
+	LastToRepoStatus       LastSyncCommitStatus_Enum `protobuf:"varint,8,opt,name=last_to_repo_status,json=lastToRepoStatus,proto3,enum=gitrim.svc.LastSyncCommitStatus_Enum" json:"last_to_repo_status,omitempty"`
+}
+
+func (x *PollState) Reset() {
+	*x = PollState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var file_svc_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_svc_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
-var file_svc_proto_goTypes = []interface{}{
-	(LastSyncCommitStatus_Enum)(0),          // 0: gitrim.svc.LastSyncCommitStatus.Enum
-	(SubRepoCommitsCheck_Status)(0),         // 1: gitrim.svc.SubRepoCommitsCheck.Status
-	(*GitRepoIdentifier)(nil),               // 2: gitrim.svc.GitRepoIdentifier
-	(*Filter)(nil),                          // 3: gitrim.svc.Filter
-	(*RepoSync)(nil),                        // 4: gitrim.svc.RepoSync
-	(*SyncStat)(nil),                        // 5: gitrim.svc.SyncStat
-	(*LastSyncCommitStatus)(nil),            // 6: gitrim.svc.LastSyncCommitStatus
-	(*SubRepoCommitsCheck)(nil),             // 7: gitrim.svc.SubRepoCommitsCheck
-	(*InitRepoSyncRequest)(nil),             // 8: gitrim.svc.InitRepoSyncRequest
-	(*InitRepoSyncResponse)(nil),            // 9: gitrim.svc.InitRepoSyncResponse
-	(*SyncToSubRepoRequest)(nil),            // 10: gitrim.svc.SyncToSubRepoRequest
-	(*SyncToSubRepoResponse)(nil),           // 11: gitrim.svc.SyncToSubRepoResponse
-	(*CommitsFromSubRepoRequest)(nil),       // 12: gitrim.svc.CommitsFromSubRepoRequest
-	(*CommitsFromSubRepoResponse)(nil),      // 13: gitrim.svc.CommitsFromSubRepoResponse
-	(*CheckRepoSyncUpToDateRequest)(nil),    // 14: gitrim.svc.CheckRepoSyncUpToDateRequest
-	(*CheckRepoSyncUpToDateResponse)(nil),   // 15: gitrim.svc.CheckRepoSyncUpToDateResponse
-	(*CheckCommitsFromSubRepoRequest)(nil),  // 16: gitrim.svc.CheckCommitsFromSubRepoRequest
-	(*CheckCommitsFromSubRepoResponse)(nil), // 17: gitrim.svc.CheckCommitsFromSubRepoResponse
-	(*GetRepoSyncRequest)(nil),              // 18: gitrim.svc.GetRepoSyncRequest
-	(*GetRepoSyncResponse)(nil),             // 19: gitrim.svc.GetRepoSyncResponse
-	nil,                                     // 20: gitrim.svc.SyncStat.FromToToEntry
-	nil,                                     // 21: gitrim.svc.SyncStat.ToToFromEntry
+func (x *PollState) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-var file_svc_proto_depIdxs = []int32{
-	2,  // 0: gitrim.svc.RepoSync.from_repo:type_name -> gitrim.svc.GitRepoIdentifier
-	2,  // 1: gitrim.svc.RepoSync.to_repo:type_name -> gitrim.svc.GitRepoIdentifier
-	3,  // 2: gitrim.svc.RepoSync.filter:type_name -> gitrim.svc.Filter
-	20, // 3: gitrim.svc.SyncStat.from_to_to:type_name -> gitrim.svc.SyncStat.FromToToEntry
-	21, // 4: gitrim.svc.SyncStat.to_to_from:type_name -> gitrim.svc.SyncStat.ToToFromEntry
-	2,  // 5: gitrim.svc.InitRepoSyncRequest.from_repo:type_name -> gitrim.svc.GitRepoIdentifier
-	2,  // 6: gitrim.svc.InitRepoSyncRequest.to_repo:type_name -> gitrim.svc.GitRepoIdentifier
-	1,  // 7: gitrim.svc.CommitsFromSubRepoResponse.result:type_name -> gitrim.svc.SubRepoCommitsCheck.Status
-	0,  // 8: gitrim.svc.CommitsFromSubRepoResponse.from_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
-	0,  // 9: gitrim.svc.CommitsFromSubRepoResponse.to_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
-	0,  // 10: gitrim.svc.CheckRepoSyncUpToDateResponse.from_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
-	0,  // 11: gitrim.svc.CheckRepoSyncUpToDateResponse.to_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
-	1,  // 12: gitrim.svc.CheckCommitsFromSubRepoResponse.result:type_name -> gitrim.svc.SubRepoCommitsCheck.Status
-	0,  // 13: gitrim.svc.CheckCommitsFromSubRepoResponse.from_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
-	0,  // 14: gitrim.svc.CheckCommitsFromSubRepoResponse.to_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
-	4,  // 15: gitrim.svc.GetRepoSyncResponse.repo_sync:type_name -> gitrim.svc.RepoSync
-	5,  // 16: gitrim.svc.GetRepoSyncResponse.sync_stat:type_name -> gitrim.svc.SyncStat
-	8,  // 17: gitrim.svc.GiTrim.InitRepoSync:input_type -> gitrim.svc.InitRepoSyncRequest
-	10, // 18: gitrim.svc.GiTrim.SyncToSubRepo:input_type -> gitrim.svc.SyncToSubRepoRequest
-	12, // 19: gitrim.svc.GiTrim.CommitsFromSubRepo:input_type -> gitrim.svc.CommitsFromSubRepoRequest
-	14, // 20: gitrim.svc.GiTrim.CheckRepoSyncUpToDate:input_type -> gitrim.svc.CheckRepoSyncUpToDateRequest
-	16, // 21: gitrim.svc.GiTrim.CheckCommitsFromSubRepo:input_type -> gitrim.svc.CheckCommitsFromSubRepoRequest
-	18, // 22: gitrim.svc.GiTrim.GetRepoSync:input_type -> gitrim.svc.GetRepoSyncRequest
-	9,  // 23: gitrim.svc.GiTrim.InitRepoSync:output_type -> gitrim.svc.InitRepoSyncResponse
-	11, // 24: gitrim.svc.GiTrim.SyncToSubRepo:output_type -> gitrim.svc.SyncToSubRepoResponse
-	13, // 25: gitrim.svc.GiTrim.CommitsFromSubRepo:output_type -> gitrim.svc.CommitsFromSubRepoResponse
-	15, // 26: gitrim.svc.GiTrim.CheckRepoSyncUpToDate:output_type -> gitrim.svc.CheckRepoSyncUpToDateResponse
-	17, // 27: gitrim.svc.GiTrim.CheckCommitsFromSubRepo:output_type -> gitrim.svc.CheckCommitsFromSubRepoResponse
-	19, // 28: gitrim.svc.GiTrim.GetRepoSync:output_type -> gitrim.svc.GetRepoSyncResponse
-	23, // [23:29] is the sub-list for method output_type
-	17, // [17:23] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+
+func (*PollState) ProtoMessage() {}
+
+func (x *PollState) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func init() { file_svc_proto_init() }
-func file_svc_proto_init() {
-	if File_svc_proto != nil {
-		return
+// Deprecated: Use PollState.ProtoReflect.Descriptor instead.
+func (*PollState) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PollState) GetLastRunAt() int64 {
+	if x != nil {
+		return x.LastRunAt
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_svc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GitRepoIdentifier); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return 0
+}
+
+func (x *PollState) GetNextRunAt() int64 {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return 0
+}
+
+func (x *PollState) GetLastResult() PollState_Result {
+	if x != nil {
+		return x.LastResult
+	}
+	return PollState_UNKNOWN
+}
+
+func (x *PollState) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *PollState) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *PollState) GetLastNumberOfNewCommits() int32 {
+	if x != nil {
+		return x.LastNumberOfNewCommits
+	}
+	return 0
+}
+
+func (x *PollState) GetLastFromRepoStatus() LastSyncCommitStatus_Enum {
+	if x != nil {
+		return x.LastFromRepoStatus
+	}
+	return LastSyncCommitStatus_UNKNOWN
+}
+
+func (x *PollState) GetLastToRepoStatus() LastSyncCommitStatus_Enum {
+	if x != nil {
+		return x.LastToRepoStatus
+	}
+	return LastSyncCommitStatus_UNKNOWN
+}
+
+type CommitsFromPatchesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OverrideFromBranch string `protobuf:"bytes,2,opt,name=override_from_branch,json=overrideFromBranch,proto3" json:"override_from_branch,omitempty"`
+	OverrideToBranch   string `protobuf:"bytes,3,opt,name=override_to_branch,json=overrideToBranch,proto3" json:"override_to_branch,omitempty"`
+	// content of the mbox generated by "git format-patch".
+	Mbox   []byte `protobuf:"bytes,11,opt,name=mbox,proto3" json:"mbox,omitempty"`
+	DoPush bool   `protobuf:"varint,31,opt,name=do_push,json=doPush,proto3" json:"do_push,omitempty"`
+}
+
+func (x *CommitsFromPatchesRequest) Reset() {
+	*x = CommitsFromPatchesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitsFromPatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitsFromPatchesRequest) ProtoMessage() {}
+
+func (x *CommitsFromPatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_svc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Filter); i {
-			case 0:
-				return &v.state
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitsFromPatchesRequest.ProtoReflect.Descriptor instead.
+func (*CommitsFromPatchesRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CommitsFromPatchesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CommitsFromPatchesRequest) GetOverrideFromBranch() string {
+	if x != nil {
+		return x.OverrideFromBranch
+	}
+	return ""
+}
+
+func (x *CommitsFromPatchesRequest) GetOverrideToBranch() string {
+	if x != nil {
+		return x.OverrideToBranch
+	}
+	return ""
+}
+
+func (x *CommitsFromPatchesRequest) GetMbox() []byte {
+	if x != nil {
+		return x.Mbox
+	}
+	return nil
+}
+
+func (x *CommitsFromPatchesRequest) GetDoPush() bool {
+	if x != nil {
+		return x.DoPush
+	}
+	return false
+}
+
+type CommitsFromPatchesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result         SubRepoCommitsCheck_Status `protobuf:"varint,1,opt,name=result,proto3,enum=gitrim.svc.SubRepoCommitsCheck_Status" json:"result,omitempty"`
+	FromRepoStatus LastSyncCommitStatus_Enum  `protobuf:"varint,2,opt,name=from_repo_status,json=fromRepoStatus,proto3,enum=gitrim.svc.LastSyncCommitStatus_Enum" json:"from_repo_status,omitempty"`
+	ToRepoStatus   LastSyncCommitStatus_Enum  `protobuf:"varint,3,opt,name=to_repo_status,json=toRepoStatus,proto3,enum=gitrim.svc.LastSyncCommitStatus_Enum" json:"to_repo_status,omitempty"`
+	// files rejected by the filter
+	RejectedFiles []string `protobuf:"bytes,12,rep,name=rejected_files,json=rejectedFiles,proto3" json:"rejected_files,omitempty"`
+	// new commits in the original repo
+	NewCommits []string `protobuf:"bytes,21,rep,name=new_commits,json=newCommits,proto3" json:"new_commits,omitempty"`
+	// new commits in the sub repo
+	NewSubCommits []string `protobuf:"bytes,22,rep,name=new_sub_commits,json=newSubCommits,proto3" json:"new_sub_commits,omitempty"`
+}
+
+func (x *CommitsFromPatchesResponse) Reset() {
+	*x = CommitsFromPatchesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitsFromPatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitsFromPatchesResponse) ProtoMessage() {}
+
+func (x *CommitsFromPatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitsFromPatchesResponse.ProtoReflect.Descriptor instead.
+func (*CommitsFromPatchesResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CommitsFromPatchesResponse) GetResult() SubRepoCommitsCheck_Status {
+	if x != nil {
+		return x.Result
+	}
+	return SubRepoCommitsCheck_UNKNOWN
+}
+
+func (x *CommitsFromPatchesResponse) GetFromRepoStatus() LastSyncCommitStatus_Enum {
+	if x != nil {
+		return x.FromRepoStatus
+	}
+	return LastSyncCommitStatus_UNKNOWN
+}
+
+func (x *CommitsFromPatchesResponse) GetToRepoStatus() LastSyncCommitStatus_Enum {
+	if x != nil {
+		return x.ToRepoStatus
+	}
+	return LastSyncCommitStatus_UNKNOWN
+}
+
+func (x *CommitsFromPatchesResponse) GetRejectedFiles() []string {
+	if x != nil {
+		return x.RejectedFiles
+	}
+	return nil
+}
+
+func (x *CommitsFromPatchesResponse) GetNewCommits() []string {
+	if x != nil {
+		return x.NewCommits
+	}
+	return nil
+}
+
+func (x *CommitsFromPatchesResponse) GetNewSubCommits() []string {
+	if x != nil {
+		return x.NewSubCommits
+	}
+	return nil
+}
+
+type SyncToSubRepoBundleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OverrideFromBranch string `protobuf:"bytes,2,opt,name=override_from_branch,json=overrideFromBranch,proto3" json:"override_from_branch,omitempty"`
+	OverrideToBranch   string `protobuf:"bytes,3,opt,name=override_to_branch,json=overrideToBranch,proto3" json:"override_to_branch,omitempty"`
+	// use the last synced commit as the prerequisite of the bundle.
+	Incremental bool `protobuf:"varint,11,opt,name=incremental,proto3" json:"incremental,omitempty"`
+	// version of the bundle, 2 or 3. Default to 2.
+	BundleVersion int32 `protobuf:"varint,12,opt,name=bundle_version,json=bundleVersion,proto3" json:"bundle_version,omitempty"`
+}
+
+func (x *SyncToSubRepoBundleRequest) Reset() {
+	*x = SyncToSubRepoBundleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncToSubRepoBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncToSubRepoBundleRequest) ProtoMessage() {}
+
+func (x *SyncToSubRepoBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncToSubRepoBundleRequest.ProtoReflect.Descriptor instead.
+func (*SyncToSubRepoBundleRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SyncToSubRepoBundleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SyncToSubRepoBundleRequest) GetOverrideFromBranch() string {
+	if x != nil {
+		return x.OverrideFromBranch
+	}
+	return ""
+}
+
+func (x *SyncToSubRepoBundleRequest) GetOverrideToBranch() string {
+	if x != nil {
+		return x.OverrideToBranch
+	}
+	return ""
+}
+
+func (x *SyncToSubRepoBundleRequest) GetIncremental() bool {
+	if x != nil {
+		return x.Incremental
+	}
+	return false
+}
+
+func (x *SyncToSubRepoBundleRequest) GetBundleVersion() int32 {
+	if x != nil {
+		return x.BundleVersion
+	}
+	return 0
+}
+
+// SyncToSubRepoBundleResponse is streamed. The first response has the fields
+// describing the bundle, and the content of the bundle is split into the
+// responses after it.
+type SyncToSubRepoBundleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NumberOfNewCommits int32 `protobuf:"varint,1,opt,name=number_of_new_commits,json=numberOfNewCommits,proto3" json:"number_of_new_commits,omitempty"`
+	// prerequisite of the bundle, empty if the bundle contains the full history.
+	Prerequisite string `protobuf:"bytes,2,opt,name=prerequisite,proto3" json:"prerequisite,omitempty"`
+	NewHead      string `protobuf:"bytes,3,opt,name=new_head,json=newHead,proto3" json:"new_head,omitempty"`
+	// a chunk of the bundle, there are no chunks if there are no new commits.
+	Bundle []byte `protobuf:"bytes,11,opt,name=bundle,proto3" json:"bundle,omitempty"`
+}
+
+func (x *SyncToSubRepoBundleResponse) Reset() {
+	*x = SyncToSubRepoBundleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncToSubRepoBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncToSubRepoBundleResponse) ProtoMessage() {}
+
+func (x *SyncToSubRepoBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncToSubRepoBundleResponse.ProtoReflect.Descriptor instead.
+func (*SyncToSubRepoBundleResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SyncToSubRepoBundleResponse) GetNumberOfNewCommits() int32 {
+	if x != nil {
+		return x.NumberOfNewCommits
+	}
+	return 0
+}
+
+func (x *SyncToSubRepoBundleResponse) GetPrerequisite() string {
+	if x != nil {
+		return x.Prerequisite
+	}
+	return ""
+}
+
+func (x *SyncToSubRepoBundleResponse) GetNewHead() string {
+	if x != nil {
+		return x.NewHead
+	}
+	return ""
+}
+
+func (x *SyncToSubRepoBundleResponse) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type ListRepoSyncsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RemoteName string `protobuf:"bytes,1,opt,name=remote_name,json=remoteName,proto3" json:"remote_name,omitempty"`
+	Owner      string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Repo       string `protobuf:"bytes,3,opt,name=repo,proto3" json:"repo,omitempty"`
+	// max number of repo syncs to return, defaults to 100, and at most 1000.
+	PageSize int32 `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous response.
+	PageToken string `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListRepoSyncsRequest) Reset() {
+	*x = ListRepoSyncsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRepoSyncsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRepoSyncsRequest) ProtoMessage() {}
+
+func (x *ListRepoSyncsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRepoSyncsRequest.ProtoReflect.Descriptor instead.
+func (*ListRepoSyncsRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListRepoSyncsRequest) GetRemoteName() string {
+	if x != nil {
+		return x.RemoteName
+	}
+	return ""
+}
+
+func (x *ListRepoSyncsRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ListRepoSyncsRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *ListRepoSyncsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRepoSyncsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListRepoSyncsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RepoSyncs []*RepoSync `protobuf:"bytes,1,rep,name=repo_syncs,json=repoSyncs,proto3" json:"repo_syncs,omitempty"`
+	// empty if there are no more repo syncs.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListRepoSyncsResponse) Reset() {
+	*x = ListRepoSyncsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRepoSyncsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRepoSyncsResponse) ProtoMessage() {}
+
+func (x *ListRepoSyncsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRepoSyncsResponse.ProtoReflect.Descriptor instead.
+func (*ListRepoSyncsResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListRepoSyncsResponse) GetRepoSyncs() []*RepoSync {
+	if x != nil {
+		return x.RepoSyncs
+	}
+	return nil
+}
+
+func (x *ListRepoSyncsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateRepoSyncRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the non-empty fields replace the ones of the repo sync.
+	FromRepo   *GitRepoIdentifier `protobuf:"bytes,11,opt,name=from_repo,json=fromRepo,proto3" json:"from_repo,omitempty"`
+	FromBranch string             `protobuf:"bytes,12,opt,name=from_branch,json=fromBranch,proto3" json:"from_branch,omitempty"`
+	// the non-empty fields replace the ones of the repo sync.
+	ToRepo   *GitRepoIdentifier `protobuf:"bytes,21,opt,name=to_repo,json=toRepo,proto3" json:"to_repo,omitempty"`
+	ToBranch string             `protobuf:"bytes,22,opt,name=to_branch,json=toBranch,proto3" json:"to_branch,omitempty"`
+	// new raw text of the filter, unchanged if empty.
+	Filter string `protobuf:"bytes,31,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *UpdateRepoSyncRequest) Reset() {
+	*x = UpdateRepoSyncRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRepoSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRepoSyncRequest) ProtoMessage() {}
+
+func (x *UpdateRepoSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRepoSyncRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRepoSyncRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateRepoSyncRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncRequest) GetFromRepo() *GitRepoIdentifier {
+	if x != nil {
+		return x.FromRepo
+	}
+	return nil
+}
+
+func (x *UpdateRepoSyncRequest) GetFromBranch() string {
+	if x != nil {
+		return x.FromBranch
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncRequest) GetToRepo() *GitRepoIdentifier {
+	if x != nil {
+		return x.ToRepo
+	}
+	return nil
+}
+
+func (x *UpdateRepoSyncRequest) GetToBranch() string {
+	if x != nil {
+		return x.ToBranch
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+type UpdateRepoSyncResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RepoSync *RepoSync `protobuf:"bytes,1,opt,name=repo_sync,json=repoSync,proto3" json:"repo_sync,omitempty"`
+	// secret of the repo sync, which is changed if the id is changed.
+	Secret string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// the repos the webhooks with the new secret are registered on if the id is
+	// changed.
+	WebhookRepos []*GitRepoIdentifier `protobuf:"bytes,3,rep,name=webhook_repos,json=webhookRepos,proto3" json:"webhook_repos,omitempty"`
+}
+
+func (x *UpdateRepoSyncResponse) Reset() {
+	*x = UpdateRepoSyncResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRepoSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRepoSyncResponse) ProtoMessage() {}
+
+func (x *UpdateRepoSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRepoSyncResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRepoSyncResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateRepoSyncResponse) GetRepoSync() *RepoSync {
+	if x != nil {
+		return x.RepoSync
+	}
+	return nil
+}
+
+func (x *UpdateRepoSyncResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncResponse) GetWebhookRepos() []*GitRepoIdentifier {
+	if x != nil {
+		return x.WebhookRepos
+	}
+	return nil
+}
+
+type DeleteRepoSyncRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteRepoSyncRequest) Reset() {
+	*x = DeleteRepoSyncRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRepoSyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRepoSyncRequest) ProtoMessage() {}
+
+func (x *DeleteRepoSyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRepoSyncRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRepoSyncRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteRepoSyncRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteRepoSyncResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the deleted repo sync.
+	RepoSync *RepoSync `protobuf:"bytes,1,opt,name=repo_sync,json=repoSync,proto3" json:"repo_sync,omitempty"`
+}
+
+func (x *DeleteRepoSyncResponse) Reset() {
+	*x = DeleteRepoSyncResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRepoSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRepoSyncResponse) ProtoMessage() {}
+
+func (x *DeleteRepoSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRepoSyncResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRepoSyncResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteRepoSyncResponse) GetRepoSync() *RepoSync {
+	if x != nil {
+		return x.RepoSync
+	}
+	return nil
+}
+
+type UpdateRepoSyncFilterRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// raw text of the new filter.
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// branch to push the rebuilt history to, defaults to to_branch.
+	NewToBranch string `protobuf:"bytes,11,opt,name=new_to_branch,json=newToBranch,proto3" json:"new_to_branch,omitempty"`
+	// force push the rebuilt history.
+	Force  bool `protobuf:"varint,12,opt,name=force,proto3" json:"force,omitempty"`
+	DoPush bool `protobuf:"varint,31,opt,name=do_push,json=doPush,proto3" json:"do_push,omitempty"`
+}
+
+func (x *UpdateRepoSyncFilterRequest) Reset() {
+	*x = UpdateRepoSyncFilterRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRepoSyncFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRepoSyncFilterRequest) ProtoMessage() {}
+
+func (x *UpdateRepoSyncFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRepoSyncFilterRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRepoSyncFilterRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateRepoSyncFilterRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncFilterRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncFilterRequest) GetNewToBranch() string {
+	if x != nil {
+		return x.NewToBranch
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncFilterRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *UpdateRepoSyncFilterRequest) GetDoPush() bool {
+	if x != nil {
+		return x.DoPush
+	}
+	return false
+}
+
+type UpdateRepoSyncFilterResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OldFilter *Filter `protobuf:"bytes,1,opt,name=old_filter,json=oldFilter,proto3" json:"old_filter,omitempty"`
+	NewFilter *Filter `protobuf:"bytes,2,opt,name=new_filter,json=newFilter,proto3" json:"new_filter,omitempty"`
+	// paths in the history of the original repo that are only in the new
+	// filter, truncated to the first 1000 paths.
+	AddedPaths []string `protobuf:"bytes,11,rep,name=added_paths,json=addedPaths,proto3" json:"added_paths,omitempty"`
+	// paths in the history of the original repo that are only in the old
+	// filter, truncated to the first 1000 paths.
+	RemovedPaths         []string `protobuf:"bytes,12,rep,name=removed_paths,json=removedPaths,proto3" json:"removed_paths,omitempty"`
+	NumberOfAddedPaths   int32    `protobuf:"varint,13,opt,name=number_of_added_paths,json=numberOfAddedPaths,proto3" json:"number_of_added_paths,omitempty"`
+	NumberOfRemovedPaths int32    `protobuf:"varint,14,opt,name=number_of_removed_paths,json=numberOfRemovedPaths,proto3" json:"number_of_removed_paths,omitempty"`
+	// below are only set with do_push.
+	NumberOfNewCommits int32     `protobuf:"varint,21,opt,name=number_of_new_commits,json=numberOfNewCommits,proto3" json:"number_of_new_commits,omitempty"`
+	OriginalHead       string    `protobuf:"bytes,22,opt,name=original_head,json=originalHead,proto3" json:"original_head,omitempty"`
+	NewHead            string    `protobuf:"bytes,23,opt,name=new_head,json=newHead,proto3" json:"new_head,omitempty"`
+	RepoSync           *RepoSync `protobuf:"bytes,31,opt,name=repo_sync,json=repoSync,proto3" json:"repo_sync,omitempty"`
+	// secret of the repo sync, which is changed if the id is changed.
+	Secret string `protobuf:"bytes,32,opt,name=secret,proto3" json:"secret,omitempty"`
+	// the repos the webhooks with the new secret are registered on if the id is
+	// changed.
+	WebhookRepos []*GitRepoIdentifier `protobuf:"bytes,33,rep,name=webhook_repos,json=webhookRepos,proto3" json:"webhook_repos,omitempty"`
+}
+
+func (x *UpdateRepoSyncFilterResponse) Reset() {
+	*x = UpdateRepoSyncFilterResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRepoSyncFilterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRepoSyncFilterResponse) ProtoMessage() {}
+
+func (x *UpdateRepoSyncFilterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRepoSyncFilterResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRepoSyncFilterResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetOldFilter() *Filter {
+	if x != nil {
+		return x.OldFilter
+	}
+	return nil
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetNewFilter() *Filter {
+	if x != nil {
+		return x.NewFilter
+	}
+	return nil
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetAddedPaths() []string {
+	if x != nil {
+		return x.AddedPaths
+	}
+	return nil
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetRemovedPaths() []string {
+	if x != nil {
+		return x.RemovedPaths
+	}
+	return nil
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetNumberOfAddedPaths() int32 {
+	if x != nil {
+		return x.NumberOfAddedPaths
+	}
+	return 0
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetNumberOfRemovedPaths() int32 {
+	if x != nil {
+		return x.NumberOfRemovedPaths
+	}
+	return 0
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetNumberOfNewCommits() int32 {
+	if x != nil {
+		return x.NumberOfNewCommits
+	}
+	return 0
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetOriginalHead() string {
+	if x != nil {
+		return x.OriginalHead
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetNewHead() string {
+	if x != nil {
+		return x.NewHead
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetRepoSync() *RepoSync {
+	if x != nil {
+		return x.RepoSync
+	}
+	return nil
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *UpdateRepoSyncFilterResponse) GetWebhookRepos() []*GitRepoIdentifier {
+	if x != nil {
+		return x.WebhookRepos
+	}
+	return nil
+}
+
+// Job is a sync queued to run in the background.
+type Job struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// id of the repo sync.
+	RepoSyncId string    `protobuf:"bytes,2,opt,name=repo_sync_id,json=repoSyncId,proto3" json:"repo_sync_id,omitempty"`
+	State      Job_State `protobuf:"varint,3,opt,name=state,proto3,enum=gitrim.svc.Job_State" json:"state,omitempty"`
+	// number of times the job has run.
+	Attempts int32 `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// error of the last attempt.
+	LastError string `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// unix times.
+	CreatedAt int64 `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt int64 `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// when the job is pending, the earliest time it runs.
+	NextAttemptAt int64 `protobuf:"varint,13,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	// who queued the job, see triggered_by of AuditEvent.
+	TriggeredBy string `protobuf:"bytes,14,opt,name=triggered_by,json=triggeredBy,proto3" json:"triggered_by,omitempty"`
+	// Types that are assignable to Request:
+	//	*Job_SyncToSubRepo
+	//	*Job_CommitsFromSubRepo
+	Request isJob_Request `protobuf_oneof:"request"`
+	// set once the job succeeds.
+	//
+	// Types that are assignable to Result:
+	//	*Job_SyncToSubRepoResult
+	//	*Job_CommitsFromSubRepoResult
+	Result isJob_Result `protobuf_oneof:"result"`
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Job) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Job) GetRepoSyncId() string {
+	if x != nil {
+		return x.RepoSyncId
+	}
+	return ""
+}
+
+func (x *Job) GetState() Job_State {
+	if x != nil {
+		return x.State
+	}
+	return Job_UNKNOWN
+}
+
+func (x *Job) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Job) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Job) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Job) GetNextAttemptAt() int64 {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return 0
+}
+
+func (x *Job) GetTriggeredBy() string {
+	if x != nil {
+		return x.TriggeredBy
+	}
+	return ""
+}
+
+func (m *Job) GetRequest() isJob_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *Job) GetSyncToSubRepo() *SyncToSubRepoRequest {
+	if x, ok := x.GetRequest().(*Job_SyncToSubRepo); ok {
+		return x.SyncToSubRepo
+	}
+	return nil
+}
+
+func (x *Job) GetCommitsFromSubRepo() *CommitsFromSubRepoRequest {
+	if x, ok := x.GetRequest().(*Job_CommitsFromSubRepo); ok {
+		return x.CommitsFromSubRepo
+	}
+	return nil
+}
+
+func (m *Job) GetResult() isJob_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *Job) GetSyncToSubRepoResult() *SyncToSubRepoResponse {
+	if x, ok := x.GetResult().(*Job_SyncToSubRepoResult); ok {
+		return x.SyncToSubRepoResult
+	}
+	return nil
+}
+
+func (x *Job) GetCommitsFromSubRepoResult() *CommitsFromSubRepoResponse {
+	if x, ok := x.GetResult().(*Job_CommitsFromSubRepoResult); ok {
+		return x.CommitsFromSubRepoResult
+	}
+	return nil
+}
+
+type isJob_Request interface {
+	isJob_Request()
+}
+
+type Job_SyncToSubRepo struct {
+	SyncToSubRepo *SyncToSubRepoRequest `protobuf:"bytes,21,opt,name=sync_to_sub_repo,json=syncToSubRepo,proto3,oneof"`
+}
+
+type Job_CommitsFromSubRepo struct {
+	CommitsFromSubRepo *CommitsFromSubRepoRequest `protobuf:"bytes,22,opt,name=commits_from_sub_repo,json=commitsFromSubRepo,proto3,oneof"`
+}
+
+func (*Job_SyncToSubRepo) isJob_Request() {}
+
+func (*Job_CommitsFromSubRepo) isJob_Request() {}
+
+type isJob_Result interface {
+	isJob_Result()
+}
+
+type Job_SyncToSubRepoResult struct {
+	SyncToSubRepoResult *SyncToSubRepoResponse `protobuf:"bytes,31,opt,name=sync_to_sub_repo_result,json=syncToSubRepoResult,proto3,oneof"`
+}
+
+type Job_CommitsFromSubRepoResult struct {
+	CommitsFromSubRepoResult *CommitsFromSubRepoResponse `protobuf:"bytes,32,opt,name=commits_from_sub_repo_result,json=commitsFromSubRepoResult,proto3,oneof"`
+}
+
+func (*Job_SyncToSubRepoResult) isJob_Result() {}
+
+func (*Job_CommitsFromSubRepoResult) isJob_Result() {}
+
+type EnqueueJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// overrides of the branches are not supported.
+	//
+	// Types that are assignable to Request:
+	//	*EnqueueJobRequest_SyncToSubRepo
+	//	*EnqueueJobRequest_CommitsFromSubRepo
+	Request isEnqueueJobRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *EnqueueJobRequest) Reset() {
+	*x = EnqueueJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnqueueJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueJobRequest) ProtoMessage() {}
+
+func (x *EnqueueJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueJobRequest.ProtoReflect.Descriptor instead.
+func (*EnqueueJobRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{33}
+}
+
+func (m *EnqueueJobRequest) GetRequest() isEnqueueJobRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *EnqueueJobRequest) GetSyncToSubRepo() *SyncToSubRepoRequest {
+	if x, ok := x.GetRequest().(*EnqueueJobRequest_SyncToSubRepo); ok {
+		return x.SyncToSubRepo
+	}
+	return nil
+}
+
+func (x *EnqueueJobRequest) GetCommitsFromSubRepo() *CommitsFromSubRepoRequest {
+	if x, ok := x.GetRequest().(*EnqueueJobRequest_CommitsFromSubRepo); ok {
+		return x.CommitsFromSubRepo
+	}
+	return nil
+}
+
+type isEnqueueJobRequest_Request interface {
+	isEnqueueJobRequest_Request()
+}
+
+type EnqueueJobRequest_SyncToSubRepo struct {
+	SyncToSubRepo *SyncToSubRepoRequest `protobuf:"bytes,1,opt,name=sync_to_sub_repo,json=syncToSubRepo,proto3,oneof"`
+}
+
+type EnqueueJobRequest_CommitsFromSubRepo struct {
+	CommitsFromSubRepo *CommitsFromSubRepoRequest `protobuf:"bytes,2,opt,name=commits_from_sub_repo,json=commitsFromSubRepo,proto3,oneof"`
+}
+
+func (*EnqueueJobRequest_SyncToSubRepo) isEnqueueJobRequest_Request() {}
+
+func (*EnqueueJobRequest_CommitsFromSubRepo) isEnqueueJobRequest_Request() {}
+
+type EnqueueJobResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+}
+
+func (x *EnqueueJobResponse) Reset() {
+	*x = EnqueueJobResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnqueueJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueJobResponse) ProtoMessage() {}
+
+func (x *EnqueueJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueJobResponse.ProtoReflect.Descriptor instead.
+func (*EnqueueJobResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *EnqueueJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetJobRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetJobResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Job *Job `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+}
+
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResponse) ProtoMessage() {}
+
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetJobResponse) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type ListJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only list the jobs of the repo sync.
+	RepoSyncId string `protobuf:"bytes,1,opt,name=repo_sync_id,json=repoSyncId,proto3" json:"repo_sync_id,omitempty"`
+	// only list the jobs in the states.
+	States []Job_State `protobuf:"varint,2,rep,packed,name=states,proto3,enum=gitrim.svc.Job_State" json:"states,omitempty"`
+	// max number of jobs to return, defaults to 100, and at most 1000.
+	PageSize int32 `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous response.
+	PageToken string `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListJobsRequest) GetRepoSyncId() string {
+	if x != nil {
+		return x.RepoSyncId
+	}
+	return ""
+}
+
+func (x *ListJobsRequest) GetStates() []Job_State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListJobsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListJobsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Jobs []*Job `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// empty if there are no more jobs.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListJobsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// SyncEvent is an event of a repo sync, see WatchSyncEvents.
+type SyncEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sequence of the event, starting from 1.
+	Sequence   uint64         `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	RepoSyncId string         `protobuf:"bytes,2,opt,name=repo_sync_id,json=repoSyncId,proto3" json:"repo_sync_id,omitempty"`
+	Type       SyncEvent_Type `protobuf:"varint,3,opt,name=type,proto3,enum=gitrim.svc.SyncEvent_Type" json:"type,omitempty"`
+	// unix time.
+	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// COMMITS_FILTERED and COMMITS_EXPANDED, the commits are truncated to the
+	// first 100.
+	FromCommits         []string `protobuf:"bytes,11,rep,name=from_commits,json=fromCommits,proto3" json:"from_commits,omitempty"`
+	ToCommits           []string `protobuf:"bytes,12,rep,name=to_commits,json=toCommits,proto3" json:"to_commits,omitempty"`
+	NumberOfFromCommits int32    `protobuf:"varint,13,opt,name=number_of_from_commits,json=numberOfFromCommits,proto3" json:"number_of_from_commits,omitempty"`
+	NumberOfToCommits   int32    `protobuf:"varint,14,opt,name=number_of_to_commits,json=numberOfToCommits,proto3" json:"number_of_to_commits,omitempty"`
+	// PUSHED.
+	Repo   *GitRepoIdentifier `protobuf:"bytes,21,opt,name=repo,proto3" json:"repo,omitempty"`
+	Branch string             `protobuf:"bytes,22,opt,name=branch,proto3" json:"branch,omitempty"`
+	// empty if the branch is created.
+	OldHead string `protobuf:"bytes,23,opt,name=old_head,json=oldHead,proto3" json:"old_head,omitempty"`
+	NewHead string `protobuf:"bytes,24,opt,name=new_head,json=newHead,proto3" json:"new_head,omitempty"`
+	Force   bool   `protobuf:"varint,25,opt,name=force,proto3" json:"force,omitempty"`
+	// CONTRIBUTION_REJECTED, the commits of the to repo checked and the files
+	// outside of the filter.
+	RejectedCommits  []string `protobuf:"bytes,31,rep,name=rejected_commits,json=rejectedCommits,proto3" json:"rejected_commits,omitempty"`
+	RejectedFiles    []string `protobuf:"bytes,32,rep,name=rejected_files,json=rejectedFiles,proto3" json:"rejected_files,omitempty"`
+	HasGpgSignatures bool     `protobuf:"varint,33,opt,name=has_gpg_signatures,json=hasGpgSignatures,proto3" json:"has_gpg_signatures,omitempty"`
+}
+
+func (x *SyncEvent) Reset() {
+	*x = SyncEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncEvent) ProtoMessage() {}
+
+func (x *SyncEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncEvent.ProtoReflect.Descriptor instead.
+func (*SyncEvent) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SyncEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *SyncEvent) GetRepoSyncId() string {
+	if x != nil {
+		return x.RepoSyncId
+	}
+	return ""
+}
+
+func (x *SyncEvent) GetType() SyncEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return SyncEvent_UNKNOWN
+}
+
+func (x *SyncEvent) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *SyncEvent) GetFromCommits() []string {
+	if x != nil {
+		return x.FromCommits
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetToCommits() []string {
+	if x != nil {
+		return x.ToCommits
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetNumberOfFromCommits() int32 {
+	if x != nil {
+		return x.NumberOfFromCommits
+	}
+	return 0
+}
+
+func (x *SyncEvent) GetNumberOfToCommits() int32 {
+	if x != nil {
+		return x.NumberOfToCommits
+	}
+	return 0
+}
+
+func (x *SyncEvent) GetRepo() *GitRepoIdentifier {
+	if x != nil {
+		return x.Repo
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *SyncEvent) GetOldHead() string {
+	if x != nil {
+		return x.OldHead
+	}
+	return ""
+}
+
+func (x *SyncEvent) GetNewHead() string {
+	if x != nil {
+		return x.NewHead
+	}
+	return ""
+}
+
+func (x *SyncEvent) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *SyncEvent) GetRejectedCommits() []string {
+	if x != nil {
+		return x.RejectedCommits
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetRejectedFiles() []string {
+	if x != nil {
+		return x.RejectedFiles
+	}
+	return nil
+}
+
+func (x *SyncEvent) GetHasGpgSignatures() bool {
+	if x != nil {
+		return x.HasGpgSignatures
+	}
+	return false
+}
+
+// AuditEvent records a push to the from repo or the to repo, see
+// ListAuditEvents.
+type AuditEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sequence of the event, starting from 1.
+	Sequence   uint64               `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	RepoSyncId string               `protobuf:"bytes,2,opt,name=repo_sync_id,json=repoSyncId,proto3" json:"repo_sync_id,omitempty"`
+	Operation  AuditEvent_Operation `protobuf:"varint,3,opt,name=operation,proto3,enum=gitrim.svc.AuditEvent_Operation" json:"operation,omitempty"`
+	// unix time.
+	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// who triggered the push: "rpc:{identity of the caller}", or
+	// "rpc:{address of the caller}" if the auth is disabled,
+	// "webhook:{delivery id}", "scheduler", or "local" for the calls without a
+	// server. For the jobs, who queued the job.
+	TriggeredBy string `protobuf:"bytes,5,opt,name=triggered_by,json=triggeredBy,proto3" json:"triggered_by,omitempty"`
+	// the job running the operation, zero if the operation is not queued.
+	JobId  uint64             `protobuf:"varint,6,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Repo   *GitRepoIdentifier `protobuf:"bytes,11,opt,name=repo,proto3" json:"repo,omitempty"`
+	Branch string             `protobuf:"bytes,12,opt,name=branch,proto3" json:"branch,omitempty"`
+	// empty if the branch is created.
+	OldHead string `protobuf:"bytes,13,opt,name=old_head,json=oldHead,proto3" json:"old_head,omitempty"`
+	NewHead string `protobuf:"bytes,14,opt,name=new_head,json=newHead,proto3" json:"new_head,omitempty"`
+	Force   bool   `protobuf:"varint,15,opt,name=force,proto3" json:"force,omitempty"`
+	// the new commits pushed.
+	Commits []string `protobuf:"bytes,16,rep,name=commits,proto3" json:"commits,omitempty"`
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AuditEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetRepoSyncId() string {
+	if x != nil {
+		return x.RepoSyncId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOperation() AuditEvent_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return AuditEvent_UNKNOWN
+}
+
+func (x *AuditEvent) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetTriggeredBy() string {
+	if x != nil {
+		return x.TriggeredBy
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetRepo() *GitRepoIdentifier {
+	if x != nil {
+		return x.Repo
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOldHead() string {
+	if x != nil {
+		return x.OldHead
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetNewHead() string {
+	if x != nil {
+		return x.NewHead
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *AuditEvent) GetCommits() []string {
+	if x != nil {
+		return x.Commits
+	}
+	return nil
+}
+
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only list the events of the repo sync.
+	RepoSyncId string `protobuf:"bytes,1,opt,name=repo_sync_id,json=repoSyncId,proto3" json:"repo_sync_id,omitempty"`
+	// only list the events after the sequence.
+	AfterSequence uint64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	// max number of events to return, defaults to 100, and at most 1000.
+	PageSize int32 `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous response.
+	PageToken string `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListAuditEventsRequest) GetRepoSyncId() string {
+	if x != nil {
+		return x.RepoSyncId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// empty if there are no more events.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type WatchSyncEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only stream the events of the repo syncs, all if empty.
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// resume the stream after the sequence, zero streams from the oldest event
+	// kept. Without resume, only the new events are streamed.
+	AfterSequence uint64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	Resume        bool   `protobuf:"varint,3,opt,name=resume,proto3" json:"resume,omitempty"`
+}
+
+func (x *WatchSyncEventsRequest) Reset() {
+	*x = WatchSyncEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchSyncEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSyncEventsRequest) ProtoMessage() {}
+
+func (x *WatchSyncEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSyncEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchSyncEventsRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *WatchSyncEventsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *WatchSyncEventsRequest) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+func (x *WatchSyncEventsRequest) GetResume() bool {
+	if x != nil {
+		return x.Resume
+	}
+	return false
+}
+
+type RotateSecretRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// how long the current secret is still accepted, defaults to 1 day. Negative
+	// expires the current secret and the other previous secrets immediately.
+	GracePeriodSecs int32 `protobuf:"varint,2,opt,name=grace_period_secs,json=gracePeriodSecs,proto3" json:"grace_period_secs,omitempty"`
+}
+
+func (x *RotateSecretRequest) Reset() {
+	*x = RotateSecretRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateSecretRequest) ProtoMessage() {}
+
+func (x *RotateSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateSecretRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RotateSecretRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RotateSecretRequest) GetGracePeriodSecs() int32 {
+	if x != nil {
+		return x.GracePeriodSecs
+	}
+	return 0
+}
+
+type RotateSecretResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// id of the AES key sealing the new secret.
+	KeyId uint32 `protobuf:"varint,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// when the previous secret expires, zero if it is expired immediately.
+	PreviousSecretExpiresAt int64 `protobuf:"varint,3,opt,name=previous_secret_expires_at,json=previousSecretExpiresAt,proto3" json:"previous_secret_expires_at,omitempty"`
+	// the repos the webhooks with the new secret are registered on.
+	WebhookRepos []*GitRepoIdentifier `protobuf:"bytes,4,rep,name=webhook_repos,json=webhookRepos,proto3" json:"webhook_repos,omitempty"`
+}
+
+func (x *RotateSecretResponse) Reset() {
+	*x = RotateSecretResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateSecretResponse) ProtoMessage() {}
+
+func (x *RotateSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateSecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateSecretResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RotateSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *RotateSecretResponse) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *RotateSecretResponse) GetPreviousSecretExpiresAt() int64 {
+	if x != nil {
+		return x.PreviousSecretExpiresAt
+	}
+	return 0
+}
+
+func (x *RotateSecretResponse) GetWebhookRepos() []*GitRepoIdentifier {
+	if x != nil {
+		return x.WebhookRepos
+	}
+	return nil
+}
+
+type BackupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BackupRequest) Reset() {
+	*x = BackupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupRequest) ProtoMessage() {}
+
+func (x *BackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupRequest.ProtoReflect.Descriptor instead.
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{46}
+}
+
+type BackupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// size of the snapshot, only set in the first response.
+	Size int64  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *BackupResponse) Reset() {
+	*x = BackupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupResponse) ProtoMessage() {}
+
+func (x *BackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupResponse.ProtoReflect.Descriptor instead.
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *BackupResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BackupResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ExportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// hex of the 16 bytes AES key sealing the secrets in the export.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// only export the repo syncs, all if empty.
+	Ids []string `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ExportRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// ExportedRecord is a record of an export. Each repo sync is followed by the
+// chunks of its stat.
+type ExportedRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Record:
+	//	*ExportedRecord_RepoSync
+	//	*ExportedRecord_StatChunk
+	Record isExportedRecord_Record `protobuf_oneof:"record"`
+}
+
+func (x *ExportedRecord) Reset() {
+	*x = ExportedRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedRecord) ProtoMessage() {}
+
+func (x *ExportedRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedRecord.ProtoReflect.Descriptor instead.
+func (*ExportedRecord) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{49}
+}
+
+func (m *ExportedRecord) GetRecord() isExportedRecord_Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (x *ExportedRecord) GetRepoSync() *ExportedRepoSync {
+	if x, ok := x.GetRecord().(*ExportedRecord_RepoSync); ok {
+		return x.RepoSync
+	}
+	return nil
+}
+
+func (x *ExportedRecord) GetStatChunk() *ExportedStatChunk {
+	if x, ok := x.GetRecord().(*ExportedRecord_StatChunk); ok {
+		return x.StatChunk
+	}
+	return nil
+}
+
+type isExportedRecord_Record interface {
+	isExportedRecord_Record()
+}
+
+type ExportedRecord_RepoSync struct {
+	RepoSync *ExportedRepoSync `protobuf:"bytes,1,opt,name=repo_sync,json=repoSync,proto3,oneof"`
+}
+
+type ExportedRecord_StatChunk struct {
+	StatChunk *ExportedStatChunk `protobuf:"bytes,2,opt,name=stat_chunk,json=statChunk,proto3,oneof"`
+}
+
+func (*ExportedRecord_RepoSync) isExportedRecord_Record() {}
+
+func (*ExportedRecord_StatChunk) isExportedRecord_Record() {}
+
+type ExportedRepoSync struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SyncData *RepoSync `protobuf:"bytes,1,opt,name=sync_data,json=syncData,proto3" json:"sync_data,omitempty"`
+	// only the heads, the synced commits and their mappings are in the stat
+	// chunks.
+	Stat *SyncStat `protobuf:"bytes,2,opt,name=stat,proto3" json:"stat,omitempty"`
+	// the same as stat, only the heads.
+	PreviousFilters []*FilterRevision `protobuf:"bytes,3,rep,name=previous_filters,json=previousFilters,proto3" json:"previous_filters,omitempty"`
+	// sealed with the key of the export.
+	Secret             []byte                             `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	PreviousSecrets    []*ExportedRepoSync_PreviousSecret `protobuf:"bytes,5,rep,name=previous_secrets,json=previousSecrets,proto3" json:"previous_secrets,omitempty"`
+	LastBundleToCommit string                             `protobuf:"bytes,6,opt,name=last_bundle_to_commit,json=lastBundleToCommit,proto3" json:"last_bundle_to_commit,omitempty"`
+}
+
+func (x *ExportedRepoSync) Reset() {
+	*x = ExportedRepoSync{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedRepoSync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedRepoSync) ProtoMessage() {}
+
+func (x *ExportedRepoSync) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedRepoSync.ProtoReflect.Descriptor instead.
+func (*ExportedRepoSync) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ExportedRepoSync) GetSyncData() *RepoSync {
+	if x != nil {
+		return x.SyncData
+	}
+	return nil
+}
+
+func (x *ExportedRepoSync) GetStat() *SyncStat {
+	if x != nil {
+		return x.Stat
+	}
+	return nil
+}
+
+func (x *ExportedRepoSync) GetPreviousFilters() []*FilterRevision {
+	if x != nil {
+		return x.PreviousFilters
+	}
+	return nil
+}
+
+func (x *ExportedRepoSync) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *ExportedRepoSync) GetPreviousSecrets() []*ExportedRepoSync_PreviousSecret {
+	if x != nil {
+		return x.PreviousSecrets
+	}
+	return nil
+}
+
+func (x *ExportedRepoSync) GetLastBundleToCommit() string {
+	if x != nil {
+		return x.LastBundleToCommit
+	}
+	return ""
+}
+
+// ExportedStatChunk is a part of the synced commits and their mappings of the
+// repo sync before it, the commits are the raw 20 bytes hashes.
+type ExportedStatChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// appended to the commits in the previous chunks.
+	FromDfs  [][]byte                     `protobuf:"bytes,1,rep,name=from_dfs,json=fromDfs,proto3" json:"from_dfs,omitempty"`
+	ToDfs    [][]byte                     `protobuf:"bytes,2,rep,name=to_dfs,json=toDfs,proto3" json:"to_dfs,omitempty"`
+	FromToTo []*ExportedStatChunk_Mapping `protobuf:"bytes,3,rep,name=from_to_to,json=fromToTo,proto3" json:"from_to_to,omitempty"`
+	ToToFrom []*ExportedStatChunk_Mapping `protobuf:"bytes,4,rep,name=to_to_from,json=toToFrom,proto3" json:"to_to_from,omitempty"`
+	// the chunk belongs to the stat of the previous filter at
+	// previous_filter - 1 of the repo sync, or to the stat of the repo sync if
+	// zero.
+	PreviousFilter uint32 `protobuf:"varint,5,opt,name=previous_filter,json=previousFilter,proto3" json:"previous_filter,omitempty"`
+}
+
+func (x *ExportedStatChunk) Reset() {
+	*x = ExportedStatChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedStatChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedStatChunk) ProtoMessage() {}
+
+func (x *ExportedStatChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedStatChunk.ProtoReflect.Descriptor instead.
+func (*ExportedStatChunk) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ExportedStatChunk) GetFromDfs() [][]byte {
+	if x != nil {
+		return x.FromDfs
+	}
+	return nil
+}
+
+func (x *ExportedStatChunk) GetToDfs() [][]byte {
+	if x != nil {
+		return x.ToDfs
+	}
+	return nil
+}
+
+func (x *ExportedStatChunk) GetFromToTo() []*ExportedStatChunk_Mapping {
+	if x != nil {
+		return x.FromToTo
+	}
+	return nil
+}
+
+func (x *ExportedStatChunk) GetToToFrom() []*ExportedStatChunk_Mapping {
+	if x != nil {
+		return x.ToToFrom
+	}
+	return nil
+}
+
+func (x *ExportedStatChunk) GetPreviousFilter() uint32 {
+	if x != nil {
+		return x.PreviousFilter
+	}
+	return 0
+}
+
+type ImportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// hex of the key sealing the secrets of the export, only read from the
+	// first request.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// what to do with the repo syncs already existing, only read from the first
+	// request.
+	OnConflict ImportRequest_OnConflict `protobuf:"varint,2,opt,name=on_conflict,json=onConflict,proto3,enum=gitrim.svc.ImportRequest_OnConflict" json:"on_conflict,omitempty"`
+	Record     *ExportedRecord          `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (x *ImportRequest) Reset() {
+	*x = ImportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRequest) ProtoMessage() {}
+
+func (x *ImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRequest.ProtoReflect.Descriptor instead.
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ImportRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ImportRequest) GetOnConflict() ImportRequest_OnConflict {
+	if x != nil {
+		return x.OnConflict
+	}
+	return ImportRequest_FAIL
+}
+
+func (x *ImportRequest) GetRecord() *ExportedRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type ImportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ImportedIds []string `protobuf:"bytes,1,rep,name=imported_ids,json=importedIds,proto3" json:"imported_ids,omitempty"`
+	SkippedIds  []string `protobuf:"bytes,2,rep,name=skipped_ids,json=skippedIds,proto3" json:"skipped_ids,omitempty"`
+}
+
+func (x *ImportResponse) Reset() {
+	*x = ImportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResponse) ProtoMessage() {}
+
+func (x *ImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResponse.ProtoReflect.Descriptor instead.
+func (*ImportResponse) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ImportResponse) GetImportedIds() []string {
+	if x != nil {
+		return x.ImportedIds
+	}
+	return nil
+}
+
+func (x *ImportResponse) GetSkippedIds() []string {
+	if x != nil {
+		return x.SkippedIds
+	}
+	return nil
+}
+
+type ExportedRepoSync_PreviousSecret struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sealed with the key of the export.
+	Secret    []byte `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *ExportedRepoSync_PreviousSecret) Reset() {
+	*x = ExportedRepoSync_PreviousSecret{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedRepoSync_PreviousSecret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedRepoSync_PreviousSecret) ProtoMessage() {}
+
+func (x *ExportedRepoSync_PreviousSecret) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedRepoSync_PreviousSecret.ProtoReflect.Descriptor instead.
+func (*ExportedRepoSync_PreviousSecret) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{50, 0}
+}
+
+func (x *ExportedRepoSync_PreviousSecret) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *ExportedRepoSync_PreviousSecret) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type ExportedStatChunk_Mapping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// empty for a commit dropped by the filter.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ExportedStatChunk_Mapping) Reset() {
+	*x = ExportedStatChunk_Mapping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_svc_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedStatChunk_Mapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedStatChunk_Mapping) ProtoMessage() {}
+
+func (x *ExportedStatChunk_Mapping) ProtoReflect() protoreflect.Message {
+	mi := &file_svc_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedStatChunk_Mapping.ProtoReflect.Descriptor instead.
+func (*ExportedStatChunk_Mapping) Descriptor() ([]byte, []int) {
+	return file_svc_proto_rawDescGZIP(), []int{51, 0}
+}
+
+func (x *ExportedStatChunk_Mapping) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ExportedStatChunk_Mapping) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+var File_svc_proto protoreflect.FileDescriptor
+
+var file_svc_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x73, 0x76, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x22, 0x5e, 0x0a, 0x11, 0x47, 0x69, 0x74, 0x52, 0x65,
+	0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x50, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x61, 0x77, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x61, 0x77, 0x54, 0x65, 0x78, 0x74, 0x12, 0x2b, 0x0a, 0x11,
+	0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63,
+	0x61, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x9b, 0x02, 0x0a, 0x08, 0x52, 0x65,
+	0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72,
+	0x65, 0x70, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65,
+	0x70, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63,
+	0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61,
+	0x6e, 0x63, 0x68, 0x12, 0x36, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x52, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69,
+	0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x73, 0x18, 0x29, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x6f, 0x6f, 0x74,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x9c, 0x03, 0x0a, 0x08, 0x53, 0x79, 0x6e, 0x63,
+	0x53, 0x74, 0x61, 0x74, 0x12, 0x31, 0x0a, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x79, 0x6e,
+	0x63, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x72, 0x6f,
+	0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x5f,
+	0x64, 0x66, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x44,
+	0x66, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f,
+	0x74, 0x6f, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x6f, 0x5f, 0x64, 0x66, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x44, 0x66, 0x73, 0x12, 0x40, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x74, 0x6f, 0x5f, 0x74, 0x6f, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67,
+	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74,
+	0x61, 0x74, 0x2e, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x6f, 0x54, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x6f, 0x54, 0x6f, 0x12, 0x40, 0x0a, 0x0a, 0x74, 0x6f,
+	0x5f, 0x74, 0x6f, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63,
+	0x53, 0x74, 0x61, 0x74, 0x2e, 0x54, 0x6f, 0x54, 0x6f, 0x46, 0x72, 0x6f, 0x6d, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x08, 0x74, 0x6f, 0x54, 0x6f, 0x46, 0x72, 0x6f, 0x6d, 0x1a, 0x3b, 0x0a, 0x0d,
+	0x46, 0x72, 0x6f, 0x6d, 0x54, 0x6f, 0x54, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3b, 0x0a, 0x0d, 0x54, 0x6f, 0x54,
+	0x6f, 0x46, 0x72, 0x6f, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xad, 0x01, 0x0a, 0x0e, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e,
+	0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x42, 0x72, 0x61, 0x6e,
+	0x63, 0x68, 0x12, 0x31, 0x0a, 0x09, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73,
+	0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x52, 0x08, 0x73, 0x79, 0x6e,
+	0x63, 0x53, 0x74, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x64, 0x41, 0x74, 0x22, 0x5e, 0x0a, 0x14, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79,
+	0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x46,
+	0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x4d, 0x50, 0x54, 0x59, 0x10, 0x01, 0x12, 0x0c,
+	0x0a, 0x08, 0x41, 0x44, 0x56, 0x41, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06,
+	0x49, 0x4e, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x49, 0x56, 0x45,
+	0x52, 0x47, 0x45, 0x44, 0x10, 0x04, 0x22, 0x92, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x52, 0x65,
+	0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0x7b,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x50,
+	0x41, 0x53, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x52, 0x4f, 0x4d, 0x5f,
+	0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x02, 0x12, 0x0f, 0x0a,
+	0x0b, 0x54, 0x4f, 0x5f, 0x44, 0x49, 0x56, 0x45, 0x52, 0x47, 0x45, 0x44, 0x10, 0x03, 0x12, 0x15,
+	0x0a, 0x11, 0x54, 0x4f, 0x5f, 0x4e, 0x4f, 0x5f, 0x4e, 0x45, 0x57, 0x5f, 0x43, 0x4f, 0x4d, 0x4d,
+	0x49, 0x54, 0x53, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x53,
+	0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x05, 0x22, 0x82, 0x02, 0x0a, 0x13,
+	0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6f,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
+	0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x12,
+	0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x12, 0x36, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47,
+	0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x52, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x5f, 0x62,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f, 0x42,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x29, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73,
+	0x22, 0xaa, 0x01, 0x0a, 0x14, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e,
+	0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x6f, 0x52, 0x65,
+	0x70, 0x6f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x42, 0x0a, 0x0d, 0x77, 0x65, 0x62,
+	0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69,
+	0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52,
+	0x0c, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x22, 0x9c, 0x01,
+	0x0a, 0x14, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x14,
+	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72,
+	0x61, 0x6e, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2c,
+	0x0a, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x72,
+	0x61, 0x6e, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x54, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x22, 0x8a, 0x01, 0x0a,
+	0x15, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x15, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x5f, 0x6f, 0x66, 0x5f, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x4e,
+	0x65, 0x77, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x48, 0x65, 0x61, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6e, 0x65, 0x77, 0x48, 0x65, 0x61, 0x64, 0x22, 0xd4, 0x01, 0x0a, 0x19, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x6f, 0x76, 0x65, 0x72, 0x72,
+	0x69, 0x64, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x46,
+	0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x6f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x54,
+	0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x5f, 0x70, 0x67, 0x70, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x67, 0x70, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x6f, 0x5f, 0x70, 0x75,
+	0x73, 0x68, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x6f, 0x50, 0x75, 0x73, 0x68,
+	0x22, 0xf0, 0x02, 0x0a, 0x1a, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d,
+	0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x26, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x75, 0x62,
+	0x52, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x4f, 0x0a, 0x10, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d,
+	0x52, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x4b, 0x0a, 0x0e, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69,
+	0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52,
+	0x0c, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2c, 0x0a,
+	0x12, 0x68, 0x61, 0x73, 0x5f, 0x67, 0x70, 0x67, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x68, 0x61, 0x73, 0x47, 0x70,
+	0x67, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x73, 0x22, 0x2e, 0x0a, 0x1c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f,
+	0x53, 0x79, 0x6e, 0x63, 0x55, 0x70, 0x54, 0x6f, 0x44, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0xbd, 0x01, 0x0a, 0x1d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70,
+	0x6f, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x70, 0x54, 0x6f, 0x44, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x10, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65,
+	0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x61, 0x73,
+	0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6f,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a, 0x0e, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70,
+	0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25,
+	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x61, 0x73, 0x74,
+	0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0c, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0xc0, 0x01, 0x0a, 0x1e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69,
+	0x64, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x46, 0x72,
+	0x6f, 0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x6f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x54, 0x6f,
+	0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f,
+	0x70, 0x67, 0x70, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x67, 0x70, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xd4, 0x02, 0x0a, 0x1f, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65,
+	0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4f, 0x0a, 0x10, 0x66, 0x72,
+	0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0e, 0x66, 0x72, 0x6f,
+	0x6d, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a, 0x0e, 0x74,
+	0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63,
+	0x2e, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0c, 0x74, 0x6f, 0x52, 0x65,
+	0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x68, 0x61, 0x73, 0x5f,
+	0x67, 0x70, 0x67, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x68, 0x61, 0x73, 0x47, 0x70, 0x67, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x4a, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x5f, 0x6d, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x77, 0x69, 0x74,
+	0x68, 0x53, 0x74, 0x61, 0x74, 0x4d, 0x61, 0x70, 0x73, 0x22, 0xc3, 0x02, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f,
+	0x53, 0x79, 0x6e, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x09,
+	0x73, 0x79, 0x6e, 0x63, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e,
+	0x63, 0x53, 0x74, 0x61, 0x74, 0x52, 0x08, 0x73, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x12,
+	0x45, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x09, 0x70, 0x6f, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x15,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6c, 0x61, 0x73,
+	0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x54, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22,
+	0x9c, 0x04, 0x0a, 0x09, 0x50, 0x6f, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a,
+	0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x41, 0x74, 0x12, 0x1e, 0x0a,
+	0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x75, 0x6e, 0x41, 0x74, 0x12, 0x3d, 0x0a,
+	0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
+	0x50, 0x6f, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x31, 0x0a, 0x14, 0x63,
+	0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x63, 0x6f, 0x6e, 0x73, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x3a,
+	0x0a, 0x1a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66,
+	0x5f, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x16, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66,
+	0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x58, 0x0a, 0x15, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d,
+	0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x54, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x5f,
+	0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c,
+	0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x54, 0x6f,
+	0x52, 0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x52, 0x0a, 0x06, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x50, 0x5f, 0x54, 0x4f, 0x5f, 0x44, 0x41, 0x54, 0x45, 0x10,
+	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x59, 0x4e, 0x43, 0x45, 0x44, 0x10, 0x02, 0x12, 0x13, 0x0a,
+	0x0f, 0x4e, 0x45, 0x45, 0x44, 0x53, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x4e, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x22, 0xb8,
+	0x01, 0x0a, 0x19, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x50, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x14,
+	0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72,
+	0x61, 0x6e, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2c,
+	0x0a, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x72,
+	0x61, 0x6e, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x54, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x6d, 0x62, 0x6f, 0x78, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6d, 0x62, 0x6f, 0x78,
+	0x12, 0x17, 0x0a, 0x07, 0x64, 0x6f, 0x5f, 0x70, 0x75, 0x73, 0x68, 0x18, 0x1f, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x64, 0x6f, 0x50, 0x75, 0x73, 0x68, 0x22, 0xea, 0x02, 0x0a, 0x1a, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x50, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69,
+	0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4f, 0x0a, 0x10, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
+	0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x52,
+	0x65, 0x70, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a, 0x0e, 0x74, 0x6f, 0x5f,
+	0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c,
+	0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0c, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x15, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x77, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x77, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x77, 0x53, 0x75, 0x62, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x22, 0xd5, 0x01, 0x0a, 0x1a, 0x53, 0x79, 0x6e, 0x63, 0x54,
+	0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x46, 0x72, 0x6f,
+	0x6d, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x72,
+	0x69, 0x64, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x54, 0x6f, 0x42,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xa7,
+	0x01, 0x0a, 0x1b, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31,
+	0x0a, 0x15, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x6e, 0x65, 0x77, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x4e, 0x65, 0x77, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x72, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x73, 0x69, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x68, 0x65, 0x61,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x48, 0x65, 0x61, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x74, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x33, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73,
+	0x76, 0x63, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x09, 0x72, 0x65, 0x70,
+	0x6f, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xf1,
+	0x01, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e,
+	0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3a, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d,
+	0x52, 0x65, 0x70, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x62, 0x72, 0x61,
+	0x6e, 0x63, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x42,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x36, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x70, 0x6f,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
+	0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x06, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x74, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x22, 0xa7, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a,
+	0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x65,
+	0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x42, 0x0a, 0x0d, 0x77, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74,
+	0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0c,
+	0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x22, 0x27, 0x0a, 0x15,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4b, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x31, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x53, 0x79,
+	0x6e, 0x63, 0x22, 0x98, 0x01, 0x0a, 0x1b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0d, 0x6e, 0x65,
+	0x77, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x54, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66,
+	0x6f, 0x72, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x6f, 0x5f, 0x70, 0x75, 0x73, 0x68, 0x18,
+	0x1f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x6f, 0x50, 0x75, 0x73, 0x68, 0x22, 0xb6, 0x04,
+	0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31,
+	0x0a, 0x0a, 0x6f, 0x6c, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x09, 0x6f, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x31, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73,
+	0x76, 0x63, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x65, 0x64,
+	0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x31, 0x0a, 0x15, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x4f, 0x66, 0x41, 0x64, 0x64, 0x65, 0x64, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x35, 0x0a,
+	0x17, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x50,
+	0x61, 0x74, 0x68, 0x73, 0x12, 0x31, 0x0a, 0x15, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f,
+	0x66, 0x5f, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x12, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x4e, 0x65, 0x77,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x61, 0x6c, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x48, 0x65, 0x61, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x6e, 0x65, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6e, 0x65, 0x77, 0x48, 0x65, 0x61, 0x64, 0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f,
+	0x73, 0x79, 0x6e, 0x63, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63,
+	0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x12, 0x42, 0x0a, 0x0d, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x18, 0x21, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0c, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x22, 0xf6, 0x05, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20,
+	0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x64,
+	0x12, 0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x15, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4a, 0x6f, 0x62,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c,
+	0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x41, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x65, 0x64, 0x42,
+	0x79, 0x12, 0x4b, 0x0a, 0x10, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x74, 0x6f, 0x5f, 0x73, 0x75, 0x62,
+	0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53,
+	0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0d, 0x73, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x5a,
+	0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73,
+	0x75, 0x62, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x12, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46,
+	0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x59, 0x0a, 0x17, 0x73, 0x79,
+	0x6e, 0x63, 0x5f, 0x74, 0x6f, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53,
+	0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x01,
+	0x52, 0x13, 0x73, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x68, 0x0a, 0x1c, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73,
+	0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x01, 0x52, 0x18, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72,
+	0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
+	0x49, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47,
+	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12,
+	0x0d, 0x0a, 0x09, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0a,
+	0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
+	0xc7, 0x01, 0x0a, 0x11, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x10, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x74, 0x6f,
+	0x5f, 0x73, 0x75, 0x62, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e,
+	0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65,
+	0x70, 0x6f, 0x12, 0x5a, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x5f, 0x66, 0x72,
+	0x6f, 0x6d, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x12, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x42, 0x09,
+	0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x37, 0x0a, 0x12, 0x45, 0x6e, 0x71,
+	0x75, 0x65, 0x75, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x21, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
+	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a,
+	0x6f, 0x62, 0x22, 0x1f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x33, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
+	0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x22, 0x9e, 0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x73,
+	0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0c,
+	0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x64, 0x12, 0x2d,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15,
+	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5f, 0x0a, 0x10, 0x4c, 0x69, 0x73,
+	0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a,
+	0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x04, 0x6a, 0x6f,
+	0x62, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78,
+	0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xbf, 0x05, 0x0a, 0x09, 0x53,
+	0x79, 0x6e, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x79, 0x6e,
+	0x63, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f,
+	0x53, 0x79, 0x6e, 0x63, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x72, 0x6f,
+	0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x5f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f,
+	0x66, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x14,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x74, 0x6f, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x4f, 0x66, 0x54, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x31, 0x0a,
+	0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x6c, 0x64, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x6c, 0x64, 0x48,
+	0x65, 0x61, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x48, 0x65, 0x61, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66,
+	0x6f, 0x72, 0x63, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x1f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f,
+	0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x68, 0x61, 0x73, 0x5f, 0x67, 0x70,
+	0x67, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x21, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x10, 0x68, 0x61, 0x73, 0x47, 0x70, 0x67, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x22, 0x66, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4d,
+	0x4d, 0x49, 0x54, 0x53, 0x5f, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12,
+	0x14, 0x0a, 0x10, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x53, 0x5f, 0x45, 0x58, 0x50, 0x41, 0x4e,
+	0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x55, 0x53, 0x48, 0x45, 0x44, 0x10,
+	0x03, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x49, 0x42, 0x55, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x04, 0x22, 0xab, 0x04, 0x0a,
+	0x0a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x5f,
+	0x73, 0x79, 0x6e, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x64, 0x12, 0x3e, 0x0a, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x67,
+	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x72, 0x69, 0x67,
+	0x67, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x65, 0x64, 0x42, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x6a,
+	0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6a, 0x6f, 0x62,
+	0x49, 0x64, 0x12, 0x31, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69,
+	0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52,
+	0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x19, 0x0a,
+	0x08, 0x6f, 0x6c, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6f, 0x6c, 0x64, 0x48, 0x65, 0x61, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x48,
+	0x65, 0x61, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x73, 0x22, 0x94, 0x01, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12,
+	0x0a, 0x0e, 0x49, 0x4e, 0x49, 0x54, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x5f, 0x53, 0x59, 0x4e, 0x43,
+	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x59, 0x4e, 0x43, 0x5f, 0x54, 0x4f, 0x5f, 0x53, 0x55,
+	0x42, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x4f, 0x4d, 0x4d,
+	0x49, 0x54, 0x53, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x53, 0x55, 0x42, 0x5f, 0x52, 0x45, 0x50,
+	0x4f, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x53, 0x5f, 0x46,
+	0x52, 0x4f, 0x4d, 0x5f, 0x50, 0x41, 0x54, 0x43, 0x48, 0x45, 0x53, 0x10, 0x04, 0x12, 0x1b, 0x0a,
+	0x17, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x5f, 0x53, 0x59, 0x4e,
+	0x43, 0x5f, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x10, 0x05, 0x22, 0x9d, 0x01, 0x0a, 0x16, 0x4c,
+	0x69, 0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x79,
+	0x6e, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70,
+	0x6f, 0x53, 0x79, 0x6e, 0x63, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0d, 0x61, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x71, 0x0a, 0x17, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73,
+	0x76, 0x63, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x69, 0x0a,
+	0x16, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x79, 0x6e, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x66, 0x74,
+	0x65, 0x72, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x61, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x22, 0x51, 0x0a, 0x13, 0x52, 0x6f, 0x74, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x2a, 0x0a, 0x11, 0x67, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f,
+	0x73, 0x65, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x67, 0x72, 0x61, 0x63,
+	0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x73, 0x22, 0xc6, 0x01, 0x0a, 0x14,
+	0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x15, 0x0a, 0x06,
+	0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6b, 0x65,
+	0x79, 0x49, 0x64, 0x12, 0x3b, 0x0a, 0x1a, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x17, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75,
+	0x73, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x12, 0x42, 0x0a, 0x0d, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d,
+	0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x0c, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52,
+	0x65, 0x70, 0x6f, 0x73, 0x22, 0x0f, 0x0a, 0x0d, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x38, 0x0a, 0x0e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x33, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x03, 0x69, 0x64, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65,
+	0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x3b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f,
+	0x73, 0x79, 0x6e, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f,
+	0x53, 0x79, 0x6e, 0x63, 0x12, 0x3e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x5f, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69,
+	0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0xa2,
+	0x03, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x53,
+	0x79, 0x6e, 0x63, 0x12, 0x31, 0x0a, 0x09, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
+	0x73, 0x76, 0x63, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x08, 0x73, 0x79,
+	0x6e, 0x63, 0x44, 0x61, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x74, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x52, 0x04, 0x73, 0x74, 0x61, 0x74,
+	0x12, 0x45, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12,
+	0x56, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52,
+	0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x54, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x1a, 0x47, 0x0a, 0x0e, 0x50, 0x72,
+	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f,
+	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x41, 0x74, 0x22, 0xab, 0x02, 0x0a, 0x11, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x72, 0x6f,
+	0x6d, 0x5f, 0x64, 0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x66, 0x72, 0x6f,
+	0x6d, 0x44, 0x66, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x6f, 0x5f, 0x64, 0x66, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x6f, 0x44, 0x66, 0x73, 0x12, 0x43, 0x0a, 0x0a, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x74, 0x6f, 0x5f, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x2e, 0x4d,
+	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x6f, 0x54, 0x6f,
+	0x12, 0x43, 0x0a, 0x0a, 0x74, 0x6f, 0x5f, 0x74, 0x6f, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x2e, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x74, 0x6f, 0x54,
+	0x6f, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75,
+	0x73, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x1a, 0x31,
+	0x0a, 0x07, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0xcd, 0x01, 0x0a, 0x0d, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x45, 0x0a, 0x0b, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x52, 0x0a, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x32, 0x0a, 0x06,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x22, 0x2f, 0x0a, 0x0a, 0x4f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x08,
+	0x0a, 0x04, 0x46, 0x41, 0x49, 0x4c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x4b, 0x49, 0x50,
+	0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x56, 0x45, 0x52, 0x57, 0x52, 0x49, 0x54, 0x45, 0x10,
+	0x02, 0x22, 0x54, 0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f,
+	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x49, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65,
+	0x64, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6b, 0x69,
+	0x70, 0x70, 0x65, 0x64, 0x49, 0x64, 0x73, 0x32, 0xd1, 0x0e, 0x0a, 0x06, 0x47, 0x69, 0x54, 0x72,
+	0x69, 0x6d, 0x12, 0x53, 0x0a, 0x0c, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79,
+	0x6e, 0x63, 0x12, 0x1f, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
+	0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63,
+	0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x0d, 0x53, 0x79, 0x6e, 0x63, 0x54,
+	0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x20, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69,
+	0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52,
+	0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75,
+	0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x65, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75,
+	0x62, 0x52, 0x65, 0x70, 0x6f, 0x12, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73,
+	0x76, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75,
+	0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x67,
+	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6e, 0x0a, 0x15, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x70, 0x54, 0x6f, 0x44, 0x61, 0x74, 0x65, 0x12,
+	0x28, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x55, 0x70, 0x54, 0x6f, 0x44, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6f,
+	0x53, 0x79, 0x6e, 0x63, 0x55, 0x70, 0x54, 0x6f, 0x44, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x74, 0x0a, 0x17, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70,
+	0x6f, 0x12, 0x2a, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53,
+	0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e,
+	0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x75, 0x62, 0x52, 0x65,
+	0x70, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x1e, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f,
+	0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f,
+	0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x65,
+	0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x50, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x50, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73,
+	0x46, 0x72, 0x6f, 0x6d, 0x50, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6a, 0x0a, 0x13, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53,
+	0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x26, 0x2e, 0x67,
+	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f,
+	0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x6f, 0x53, 0x75, 0x62, 0x52, 0x65, 0x70, 0x6f, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x56, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e,
+	0x63, 0x73, 0x12, 0x20, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76,
+	0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x0e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x21, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22,
+	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x21, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
+	0x73, 0x76, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79,
+	0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x6b, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e,
+	0x63, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x27, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d,
+	0x2e, 0x73, 0x76, 0x63, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x53,
+	0x79, 0x6e, 0x63, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x28, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x53, 0x79, 0x6e, 0x63, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0a,
+	0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4a, 0x6f, 0x62, 0x12, 0x1d, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x69, 0x74, 0x72,
+	0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4a, 0x6f,
+	0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x47,
+	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x19, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73,
+	0x76, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x47, 0x65,
+	0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47,
+	0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1b, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d,
+	0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0f, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x79, 0x6e, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x22, 0x2e, 0x67, 0x69, 0x74,
+	0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x79, 0x6e,
+	0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x53, 0x79, 0x6e, 0x63,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x5c, 0x0a, 0x0f, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x22, 0x2e, 0x67,
+	0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0c, 0x52, 0x6f, 0x74, 0x61, 0x74,
+	0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x1f, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d,
+	0x2e, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69,
+	0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x06,
+	0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x19, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
+	0x73, 0x76, 0x63, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x42,
+	0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x43, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x19, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e,
+	0x73, 0x76, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x22, 0x00, 0x30, 0x01, 0x12, 0x43, 0x0a, 0x06, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x19, 0x2e, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x49, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x69,
+	0x74, 0x72, 0x69, 0x6d, 0x2e, 0x73, 0x76, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x42, 0x20, 0x5a, 0x1e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x72, 0x64, 0x72, 0x65,
+	0x61, 0x6d, 0x2f, 0x67, 0x69, 0x74, 0x72, 0x69, 0x6d, 0x2f, 0x73, 0x76, 0x63, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_svc_proto_rawDescOnce sync.Once
+	file_svc_proto_rawDescData = file_svc_proto_rawDesc
+)
+
+func file_svc_proto_rawDescGZIP() []byte {
+	file_svc_proto_rawDescOnce.Do(func() {
+		file_svc_proto_rawDescData = protoimpl.X.CompressGZIP(file_svc_proto_rawDescData)
+	})
+	return file_svc_proto_rawDescData
+}
+
+var file_svc_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_svc_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
+var file_svc_proto_goTypes = []interface{}{
+	(LastSyncCommitStatus_Enum)(0),          // 0: gitrim.svc.LastSyncCommitStatus.Enum
+	(SubRepoCommitsCheck_Status)(0),         // 1: gitrim.svc.SubRepoCommitsCheck.Status
+	(PollState_Result)(0),                   // 2: gitrim.svc.PollState.Result
+	(Job_State)(0),                          // 3: gitrim.svc.Job.State
+	(SyncEvent_Type)(0),                     // 4: gitrim.svc.SyncEvent.Type
+	(AuditEvent_Operation)(0),               // 5: gitrim.svc.AuditEvent.Operation
+	(ImportRequest_OnConflict)(0),           // 6: gitrim.svc.ImportRequest.OnConflict
+	(*GitRepoIdentifier)(nil),               // 7: gitrim.svc.GitRepoIdentifier
+	(*Filter)(nil),                          // 8: gitrim.svc.Filter
+	(*RepoSync)(nil),                        // 9: gitrim.svc.RepoSync
+	(*SyncStat)(nil),                        // 10: gitrim.svc.SyncStat
+	(*FilterRevision)(nil),                  // 11: gitrim.svc.FilterRevision
+	(*LastSyncCommitStatus)(nil),            // 12: gitrim.svc.LastSyncCommitStatus
+	(*SubRepoCommitsCheck)(nil),             // 13: gitrim.svc.SubRepoCommitsCheck
+	(*InitRepoSyncRequest)(nil),             // 14: gitrim.svc.InitRepoSyncRequest
+	(*InitRepoSyncResponse)(nil),            // 15: gitrim.svc.InitRepoSyncResponse
+	(*SyncToSubRepoRequest)(nil),            // 16: gitrim.svc.SyncToSubRepoRequest
+	(*SyncToSubRepoResponse)(nil),           // 17: gitrim.svc.SyncToSubRepoResponse
+	(*CommitsFromSubRepoRequest)(nil),       // 18: gitrim.svc.CommitsFromSubRepoRequest
+	(*CommitsFromSubRepoResponse)(nil),      // 19: gitrim.svc.CommitsFromSubRepoResponse
+	(*CheckRepoSyncUpToDateRequest)(nil),    // 20: gitrim.svc.CheckRepoSyncUpToDateRequest
+	(*CheckRepoSyncUpToDateResponse)(nil),   // 21: gitrim.svc.CheckRepoSyncUpToDateResponse
+	(*CheckCommitsFromSubRepoRequest)(nil),  // 22: gitrim.svc.CheckCommitsFromSubRepoRequest
+	(*CheckCommitsFromSubRepoResponse)(nil), // 23: gitrim.svc.CheckCommitsFromSubRepoResponse
+	(*GetRepoSyncRequest)(nil),              // 24: gitrim.svc.GetRepoSyncRequest
+	(*GetRepoSyncResponse)(nil),             // 25: gitrim.svc.GetRepoSyncResponse
+	(*PollState)(nil),                       // 26: gitrim.svc.PollState
+	(*CommitsFromPatchesRequest)(nil),       // 27: gitrim.svc.CommitsFromPatchesRequest
+	(*CommitsFromPatchesResponse)(nil),      // 28: gitrim.svc.CommitsFromPatchesResponse
+	(*SyncToSubRepoBundleRequest)(nil),      // 29: gitrim.svc.SyncToSubRepoBundleRequest
+	(*SyncToSubRepoBundleResponse)(nil),     // 30: gitrim.svc.SyncToSubRepoBundleResponse
+	(*ListRepoSyncsRequest)(nil),            // 31: gitrim.svc.ListRepoSyncsRequest
+	(*ListRepoSyncsResponse)(nil),           // 32: gitrim.svc.ListRepoSyncsResponse
+	(*UpdateRepoSyncRequest)(nil),           // 33: gitrim.svc.UpdateRepoSyncRequest
+	(*UpdateRepoSyncResponse)(nil),          // 34: gitrim.svc.UpdateRepoSyncResponse
+	(*DeleteRepoSyncRequest)(nil),           // 35: gitrim.svc.DeleteRepoSyncRequest
+	(*DeleteRepoSyncResponse)(nil),          // 36: gitrim.svc.DeleteRepoSyncResponse
+	(*UpdateRepoSyncFilterRequest)(nil),     // 37: gitrim.svc.UpdateRepoSyncFilterRequest
+	(*UpdateRepoSyncFilterResponse)(nil),    // 38: gitrim.svc.UpdateRepoSyncFilterResponse
+	(*Job)(nil),                             // 39: gitrim.svc.Job
+	(*EnqueueJobRequest)(nil),               // 40: gitrim.svc.EnqueueJobRequest
+	(*EnqueueJobResponse)(nil),              // 41: gitrim.svc.EnqueueJobResponse
+	(*GetJobRequest)(nil),                   // 42: gitrim.svc.GetJobRequest
+	(*GetJobResponse)(nil),                  // 43: gitrim.svc.GetJobResponse
+	(*ListJobsRequest)(nil),                 // 44: gitrim.svc.ListJobsRequest
+	(*ListJobsResponse)(nil),                // 45: gitrim.svc.ListJobsResponse
+	(*SyncEvent)(nil),                       // 46: gitrim.svc.SyncEvent
+	(*AuditEvent)(nil),                      // 47: gitrim.svc.AuditEvent
+	(*ListAuditEventsRequest)(nil),          // 48: gitrim.svc.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),         // 49: gitrim.svc.ListAuditEventsResponse
+	(*WatchSyncEventsRequest)(nil),          // 50: gitrim.svc.WatchSyncEventsRequest
+	(*RotateSecretRequest)(nil),             // 51: gitrim.svc.RotateSecretRequest
+	(*RotateSecretResponse)(nil),            // 52: gitrim.svc.RotateSecretResponse
+	(*BackupRequest)(nil),                   // 53: gitrim.svc.BackupRequest
+	(*BackupResponse)(nil),                  // 54: gitrim.svc.BackupResponse
+	(*ExportRequest)(nil),                   // 55: gitrim.svc.ExportRequest
+	(*ExportedRecord)(nil),                  // 56: gitrim.svc.ExportedRecord
+	(*ExportedRepoSync)(nil),                // 57: gitrim.svc.ExportedRepoSync
+	(*ExportedStatChunk)(nil),               // 58: gitrim.svc.ExportedStatChunk
+	(*ImportRequest)(nil),                   // 59: gitrim.svc.ImportRequest
+	(*ImportResponse)(nil),                  // 60: gitrim.svc.ImportResponse
+	nil,                                     // 61: gitrim.svc.SyncStat.FromToToEntry
+	nil,                                     // 62: gitrim.svc.SyncStat.ToToFromEntry
+	(*ExportedRepoSync_PreviousSecret)(nil), // 63: gitrim.svc.ExportedRepoSync.PreviousSecret
+	(*ExportedStatChunk_Mapping)(nil),       // 64: gitrim.svc.ExportedStatChunk.Mapping
+}
+var file_svc_proto_depIdxs = []int32{
+	7,  // 0: gitrim.svc.RepoSync.from_repo:type_name -> gitrim.svc.GitRepoIdentifier
+	7,  // 1: gitrim.svc.RepoSync.to_repo:type_name -> gitrim.svc.GitRepoIdentifier
+	8,  // 2: gitrim.svc.RepoSync.filter:type_name -> gitrim.svc.Filter
+	61, // 3: gitrim.svc.SyncStat.from_to_to:type_name -> gitrim.svc.SyncStat.FromToToEntry
+	62, // 4: gitrim.svc.SyncStat.to_to_from:type_name -> gitrim.svc.SyncStat.ToToFromEntry
+	8,  // 5: gitrim.svc.FilterRevision.filter:type_name -> gitrim.svc.Filter
+	10, // 6: gitrim.svc.FilterRevision.sync_stat:type_name -> gitrim.svc.SyncStat
+	7,  // 7: gitrim.svc.InitRepoSyncRequest.from_repo:type_name -> gitrim.svc.GitRepoIdentifier
+	7,  // 8: gitrim.svc.InitRepoSyncRequest.to_repo:type_name -> gitrim.svc.GitRepoIdentifier
+	7,  // 9: gitrim.svc.InitRepoSyncResponse.webhook_repos:type_name -> gitrim.svc.GitRepoIdentifier
+	1,  // 10: gitrim.svc.CommitsFromSubRepoResponse.result:type_name -> gitrim.svc.SubRepoCommitsCheck.Status
+	0,  // 11: gitrim.svc.CommitsFromSubRepoResponse.from_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	0,  // 12: gitrim.svc.CommitsFromSubRepoResponse.to_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	0,  // 13: gitrim.svc.CheckRepoSyncUpToDateResponse.from_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	0,  // 14: gitrim.svc.CheckRepoSyncUpToDateResponse.to_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	1,  // 15: gitrim.svc.CheckCommitsFromSubRepoResponse.result:type_name -> gitrim.svc.SubRepoCommitsCheck.Status
+	0,  // 16: gitrim.svc.CheckCommitsFromSubRepoResponse.from_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	0,  // 17: gitrim.svc.CheckCommitsFromSubRepoResponse.to_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	9,  // 18: gitrim.svc.GetRepoSyncResponse.repo_sync:type_name -> gitrim.svc.RepoSync
+	10, // 19: gitrim.svc.GetRepoSyncResponse.sync_stat:type_name -> gitrim.svc.SyncStat
+	11, // 20: gitrim.svc.GetRepoSyncResponse.previous_filters:type_name -> gitrim.svc.FilterRevision
+	26, // 21: gitrim.svc.GetRepoSyncResponse.poll_state:type_name -> gitrim.svc.PollState
+	2,  // 22: gitrim.svc.PollState.last_result:type_name -> gitrim.svc.PollState.Result
+	0,  // 23: gitrim.svc.PollState.last_from_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	0,  // 24: gitrim.svc.PollState.last_to_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	1,  // 25: gitrim.svc.CommitsFromPatchesResponse.result:type_name -> gitrim.svc.SubRepoCommitsCheck.Status
+	0,  // 26: gitrim.svc.CommitsFromPatchesResponse.from_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	0,  // 27: gitrim.svc.CommitsFromPatchesResponse.to_repo_status:type_name -> gitrim.svc.LastSyncCommitStatus.Enum
+	9,  // 28: gitrim.svc.ListRepoSyncsResponse.repo_syncs:type_name -> gitrim.svc.RepoSync
+	7,  // 29: gitrim.svc.UpdateRepoSyncRequest.from_repo:type_name -> gitrim.svc.GitRepoIdentifier
+	7,  // 30: gitrim.svc.UpdateRepoSyncRequest.to_repo:type_name -> gitrim.svc.GitRepoIdentifier
+	9,  // 31: gitrim.svc.UpdateRepoSyncResponse.repo_sync:type_name -> gitrim.svc.RepoSync
+	7,  // 32: gitrim.svc.UpdateRepoSyncResponse.webhook_repos:type_name -> gitrim.svc.GitRepoIdentifier
+	9,  // 33: gitrim.svc.DeleteRepoSyncResponse.repo_sync:type_name -> gitrim.svc.RepoSync
+	8,  // 34: gitrim.svc.UpdateRepoSyncFilterResponse.old_filter:type_name -> gitrim.svc.Filter
+	8,  // 35: gitrim.svc.UpdateRepoSyncFilterResponse.new_filter:type_name -> gitrim.svc.Filter
+	9,  // 36: gitrim.svc.UpdateRepoSyncFilterResponse.repo_sync:type_name -> gitrim.svc.RepoSync
+	7,  // 37: gitrim.svc.UpdateRepoSyncFilterResponse.webhook_repos:type_name -> gitrim.svc.GitRepoIdentifier
+	3,  // 38: gitrim.svc.Job.state:type_name -> gitrim.svc.Job.State
+	16, // 39: gitrim.svc.Job.sync_to_sub_repo:type_name -> gitrim.svc.SyncToSubRepoRequest
+	18, // 40: gitrim.svc.Job.commits_from_sub_repo:type_name -> gitrim.svc.CommitsFromSubRepoRequest
+	17, // 41: gitrim.svc.Job.sync_to_sub_repo_result:type_name -> gitrim.svc.SyncToSubRepoResponse
+	19, // 42: gitrim.svc.Job.commits_from_sub_repo_result:type_name -> gitrim.svc.CommitsFromSubRepoResponse
+	16, // 43: gitrim.svc.EnqueueJobRequest.sync_to_sub_repo:type_name -> gitrim.svc.SyncToSubRepoRequest
+	18, // 44: gitrim.svc.EnqueueJobRequest.commits_from_sub_repo:type_name -> gitrim.svc.CommitsFromSubRepoRequest
+	39, // 45: gitrim.svc.EnqueueJobResponse.job:type_name -> gitrim.svc.Job
+	39, // 46: gitrim.svc.GetJobResponse.job:type_name -> gitrim.svc.Job
+	3,  // 47: gitrim.svc.ListJobsRequest.states:type_name -> gitrim.svc.Job.State
+	39, // 48: gitrim.svc.ListJobsResponse.jobs:type_name -> gitrim.svc.Job
+	4,  // 49: gitrim.svc.SyncEvent.type:type_name -> gitrim.svc.SyncEvent.Type
+	7,  // 50: gitrim.svc.SyncEvent.repo:type_name -> gitrim.svc.GitRepoIdentifier
+	5,  // 51: gitrim.svc.AuditEvent.operation:type_name -> gitrim.svc.AuditEvent.Operation
+	7,  // 52: gitrim.svc.AuditEvent.repo:type_name -> gitrim.svc.GitRepoIdentifier
+	47, // 53: gitrim.svc.ListAuditEventsResponse.events:type_name -> gitrim.svc.AuditEvent
+	7,  // 54: gitrim.svc.RotateSecretResponse.webhook_repos:type_name -> gitrim.svc.GitRepoIdentifier
+	57, // 55: gitrim.svc.ExportedRecord.repo_sync:type_name -> gitrim.svc.ExportedRepoSync
+	58, // 56: gitrim.svc.ExportedRecord.stat_chunk:type_name -> gitrim.svc.ExportedStatChunk
+	9,  // 57: gitrim.svc.ExportedRepoSync.sync_data:type_name -> gitrim.svc.RepoSync
+	10, // 58: gitrim.svc.ExportedRepoSync.stat:type_name -> gitrim.svc.SyncStat
+	11, // 59: gitrim.svc.ExportedRepoSync.previous_filters:type_name -> gitrim.svc.FilterRevision
+	63, // 60: gitrim.svc.ExportedRepoSync.previous_secrets:type_name -> gitrim.svc.ExportedRepoSync.PreviousSecret
+	64, // 61: gitrim.svc.ExportedStatChunk.from_to_to:type_name -> gitrim.svc.ExportedStatChunk.Mapping
+	64, // 62: gitrim.svc.ExportedStatChunk.to_to_from:type_name -> gitrim.svc.ExportedStatChunk.Mapping
+	6,  // 63: gitrim.svc.ImportRequest.on_conflict:type_name -> gitrim.svc.ImportRequest.OnConflict
+	56, // 64: gitrim.svc.ImportRequest.record:type_name -> gitrim.svc.ExportedRecord
+	14, // 65: gitrim.svc.GiTrim.InitRepoSync:input_type -> gitrim.svc.InitRepoSyncRequest
+	16, // 66: gitrim.svc.GiTrim.SyncToSubRepo:input_type -> gitrim.svc.SyncToSubRepoRequest
+	18, // 67: gitrim.svc.GiTrim.CommitsFromSubRepo:input_type -> gitrim.svc.CommitsFromSubRepoRequest
+	20, // 68: gitrim.svc.GiTrim.CheckRepoSyncUpToDate:input_type -> gitrim.svc.CheckRepoSyncUpToDateRequest
+	22, // 69: gitrim.svc.GiTrim.CheckCommitsFromSubRepo:input_type -> gitrim.svc.CheckCommitsFromSubRepoRequest
+	24, // 70: gitrim.svc.GiTrim.GetRepoSync:input_type -> gitrim.svc.GetRepoSyncRequest
+	27, // 71: gitrim.svc.GiTrim.CommitsFromPatches:input_type -> gitrim.svc.CommitsFromPatchesRequest
+	29, // 72: gitrim.svc.GiTrim.SyncToSubRepoBundle:input_type -> gitrim.svc.SyncToSubRepoBundleRequest
+	31, // 73: gitrim.svc.GiTrim.ListRepoSyncs:input_type -> gitrim.svc.ListRepoSyncsRequest
+	33, // 74: gitrim.svc.GiTrim.UpdateRepoSync:input_type -> gitrim.svc.UpdateRepoSyncRequest
+	35, // 75: gitrim.svc.GiTrim.DeleteRepoSync:input_type -> gitrim.svc.DeleteRepoSyncRequest
+	37, // 76: gitrim.svc.GiTrim.UpdateRepoSyncFilter:input_type -> gitrim.svc.UpdateRepoSyncFilterRequest
+	40, // 77: gitrim.svc.GiTrim.EnqueueJob:input_type -> gitrim.svc.EnqueueJobRequest
+	42, // 78: gitrim.svc.GiTrim.GetJob:input_type -> gitrim.svc.GetJobRequest
+	44, // 79: gitrim.svc.GiTrim.ListJobs:input_type -> gitrim.svc.ListJobsRequest
+	50, // 80: gitrim.svc.GiTrim.WatchSyncEvents:input_type -> gitrim.svc.WatchSyncEventsRequest
+	48, // 81: gitrim.svc.GiTrim.ListAuditEvents:input_type -> gitrim.svc.ListAuditEventsRequest
+	51, // 82: gitrim.svc.GiTrim.RotateSecret:input_type -> gitrim.svc.RotateSecretRequest
+	53, // 83: gitrim.svc.GiTrim.Backup:input_type -> gitrim.svc.BackupRequest
+	55, // 84: gitrim.svc.GiTrim.Export:input_type -> gitrim.svc.ExportRequest
+	59, // 85: gitrim.svc.GiTrim.Import:input_type -> gitrim.svc.ImportRequest
+	15, // 86: gitrim.svc.GiTrim.InitRepoSync:output_type -> gitrim.svc.InitRepoSyncResponse
+	17, // 87: gitrim.svc.GiTrim.SyncToSubRepo:output_type -> gitrim.svc.SyncToSubRepoResponse
+	19, // 88: gitrim.svc.GiTrim.CommitsFromSubRepo:output_type -> gitrim.svc.CommitsFromSubRepoResponse
+	21, // 89: gitrim.svc.GiTrim.CheckRepoSyncUpToDate:output_type -> gitrim.svc.CheckRepoSyncUpToDateResponse
+	23, // 90: gitrim.svc.GiTrim.CheckCommitsFromSubRepo:output_type -> gitrim.svc.CheckCommitsFromSubRepoResponse
+	25, // 91: gitrim.svc.GiTrim.GetRepoSync:output_type -> gitrim.svc.GetRepoSyncResponse
+	28, // 92: gitrim.svc.GiTrim.CommitsFromPatches:output_type -> gitrim.svc.CommitsFromPatchesResponse
+	30, // 93: gitrim.svc.GiTrim.SyncToSubRepoBundle:output_type -> gitrim.svc.SyncToSubRepoBundleResponse
+	32, // 94: gitrim.svc.GiTrim.ListRepoSyncs:output_type -> gitrim.svc.ListRepoSyncsResponse
+	34, // 95: gitrim.svc.GiTrim.UpdateRepoSync:output_type -> gitrim.svc.UpdateRepoSyncResponse
+	36, // 96: gitrim.svc.GiTrim.DeleteRepoSync:output_type -> gitrim.svc.DeleteRepoSyncResponse
+	38, // 97: gitrim.svc.GiTrim.UpdateRepoSyncFilter:output_type -> gitrim.svc.UpdateRepoSyncFilterResponse
+	41, // 98: gitrim.svc.GiTrim.EnqueueJob:output_type -> gitrim.svc.EnqueueJobResponse
+	43, // 99: gitrim.svc.GiTrim.GetJob:output_type -> gitrim.svc.GetJobResponse
+	45, // 100: gitrim.svc.GiTrim.ListJobs:output_type -> gitrim.svc.ListJobsResponse
+	46, // 101: gitrim.svc.GiTrim.WatchSyncEvents:output_type -> gitrim.svc.SyncEvent
+	49, // 102: gitrim.svc.GiTrim.ListAuditEvents:output_type -> gitrim.svc.ListAuditEventsResponse
+	52, // 103: gitrim.svc.GiTrim.RotateSecret:output_type -> gitrim.svc.RotateSecretResponse
+	54, // 104: gitrim.svc.GiTrim.Backup:output_type -> gitrim.svc.BackupResponse
+	56, // 105: gitrim.svc.GiTrim.Export:output_type -> gitrim.svc.ExportedRecord
+	60, // 106: gitrim.svc.GiTrim.Import:output_type -> gitrim.svc.ImportResponse
+	86, // [86:107] is the sub-list for method output_type
+	65, // [65:86] is the sub-list for method input_type
+	65, // [65:65] is the sub-list for extension type_name
+	65, // [65:65] is the sub-list for extension extendee
+	0,  // [0:65] is the sub-list for field type_name
+}
+
+func init() { file_svc_proto_init() }
+func file_svc_proto_init() {
+	if File_svc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_svc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GitRepoIdentifier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Filter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoSync); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncStat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterRevision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LastSyncCommitStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubRepoCommitsCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InitRepoSyncRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InitRepoSyncResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncToSubRepoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncToSubRepoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitsFromSubRepoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitsFromSubRepoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckRepoSyncUpToDateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckRepoSyncUpToDateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckCommitsFromSubRepoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckCommitsFromSubRepoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRepoSyncRequest); i {
+			case 0:
+				return &v.state
 			case 1:
 				return &v.sizeCache
 			case 2:
@@ -1738,8 +5953,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RepoSync); i {
+		file_svc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRepoSyncResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1750,8 +5965,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncStat); i {
+		file_svc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PollState); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1762,8 +5977,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LastSyncCommitStatus); i {
+		file_svc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitsFromPatchesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1774,8 +5989,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubRepoCommitsCheck); i {
+		file_svc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitsFromPatchesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1786,8 +6001,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitRepoSyncRequest); i {
+		file_svc_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncToSubRepoBundleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1798,8 +6013,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitRepoSyncResponse); i {
+		file_svc_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncToSubRepoBundleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1810,8 +6025,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncToSubRepoRequest); i {
+		file_svc_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRepoSyncsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1822,8 +6037,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncToSubRepoResponse); i {
+		file_svc_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRepoSyncsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1834,8 +6049,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommitsFromSubRepoRequest); i {
+		file_svc_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRepoSyncRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1846,8 +6061,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommitsFromSubRepoResponse); i {
+		file_svc_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRepoSyncResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1858,8 +6073,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckRepoSyncUpToDateRequest); i {
+		file_svc_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRepoSyncRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1870,8 +6085,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckRepoSyncUpToDateResponse); i {
+		file_svc_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRepoSyncResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1882,8 +6097,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckCommitsFromSubRepoRequest); i {
+		file_svc_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRepoSyncFilterRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1894,8 +6109,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckCommitsFromSubRepoResponse); i {
+		file_svc_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRepoSyncFilterResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1906,8 +6121,8 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRepoSyncRequest); i {
+		file_svc_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Job); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1918,8 +6133,272 @@ func file_svc_proto_init() {
 				return nil
 			}
 		}
-		file_svc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRepoSyncResponse); i {
+		file_svc_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnqueueJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnqueueJobResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJobsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAuditEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAuditEventsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchSyncEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotateSecretRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotateSecretResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedRepoSync); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedStatChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedRepoSync_PreviousSecret); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_svc_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedStatChunk_Mapping); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1931,13 +6410,27 @@ func file_svc_proto_init() {
 			}
 		}
 	}
+	file_svc_proto_msgTypes[32].OneofWrappers = []interface{}{
+		(*Job_SyncToSubRepo)(nil),
+		(*Job_CommitsFromSubRepo)(nil),
+		(*Job_SyncToSubRepoResult)(nil),
+		(*Job_CommitsFromSubRepoResult)(nil),
+	}
+	file_svc_proto_msgTypes[33].OneofWrappers = []interface{}{
+		(*EnqueueJobRequest_SyncToSubRepo)(nil),
+		(*EnqueueJobRequest_CommitsFromSubRepo)(nil),
+	}
+	file_svc_proto_msgTypes[49].OneofWrappers = []interface{}{
+		(*ExportedRecord_RepoSync)(nil),
+		(*ExportedRecord_StatChunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_svc_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   20,
+			NumEnums:      7,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
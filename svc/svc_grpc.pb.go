@@ -25,6 +25,21 @@ const (
 	GiTrim_CheckRepoSyncUpToDate_FullMethodName   = "/gitrim.svc.GiTrim/CheckRepoSyncUpToDate"
 	GiTrim_CheckCommitsFromSubRepo_FullMethodName = "/gitrim.svc.GiTrim/CheckCommitsFromSubRepo"
 	GiTrim_GetRepoSync_FullMethodName             = "/gitrim.svc.GiTrim/GetRepoSync"
+	GiTrim_CommitsFromPatches_FullMethodName      = "/gitrim.svc.GiTrim/CommitsFromPatches"
+	GiTrim_SyncToSubRepoBundle_FullMethodName     = "/gitrim.svc.GiTrim/SyncToSubRepoBundle"
+	GiTrim_ListRepoSyncs_FullMethodName           = "/gitrim.svc.GiTrim/ListRepoSyncs"
+	GiTrim_UpdateRepoSync_FullMethodName          = "/gitrim.svc.GiTrim/UpdateRepoSync"
+	GiTrim_DeleteRepoSync_FullMethodName          = "/gitrim.svc.GiTrim/DeleteRepoSync"
+	GiTrim_UpdateRepoSyncFilter_FullMethodName    = "/gitrim.svc.GiTrim/UpdateRepoSyncFilter"
+	GiTrim_EnqueueJob_FullMethodName              = "/gitrim.svc.GiTrim/EnqueueJob"
+	GiTrim_GetJob_FullMethodName                  = "/gitrim.svc.GiTrim/GetJob"
+	GiTrim_ListJobs_FullMethodName                = "/gitrim.svc.GiTrim/ListJobs"
+	GiTrim_WatchSyncEvents_FullMethodName         = "/gitrim.svc.GiTrim/WatchSyncEvents"
+	GiTrim_ListAuditEvents_FullMethodName         = "/gitrim.svc.GiTrim/ListAuditEvents"
+	GiTrim_RotateSecret_FullMethodName            = "/gitrim.svc.GiTrim/RotateSecret"
+	GiTrim_Backup_FullMethodName                  = "/gitrim.svc.GiTrim/Backup"
+	GiTrim_Export_FullMethodName                  = "/gitrim.svc.GiTrim/Export"
+	GiTrim_Import_FullMethodName                  = "/gitrim.svc.GiTrim/Import"
 )
 
 // GiTrimClient is the client API for GiTrim service.
@@ -69,6 +84,108 @@ type GiTrimClient interface {
 	CheckCommitsFromSubRepo(ctx context.Context, in *CheckCommitsFromSubRepoRequest, opts ...grpc.CallOption) (*CheckCommitsFromSubRepoResponse, error)
 	// GetRepoSync obtain the sync relation by the id.
 	GetRepoSync(ctx context.Context, in *GetRepoSyncRequest, opts ...grpc.CallOption) (*GetRepoSyncResponse, error)
+	// CommitsFromPatches applies patches generated by "git format-patch"
+	// against the sub repo to the original repo.
+	//
+	// The patches are applied on top of the head of the sub repo, and the
+	// patches will be rejected if:
+	//   - the from repo or the sub repo is not in sync.
+	//   - the modification contained is rejected by the filter.
+	//
+	// The generated commits are pushed to the original repo, then to the sub
+	// repo. If the push to the sub repo fails, the error tells the commits
+	// pushed to the original repo, and SyncToSubRepo syncs them to the sub repo.
+	CommitsFromPatches(ctx context.Context, in *CommitsFromPatchesRequest, opts ...grpc.CallOption) (*CommitsFromPatchesResponse, error)
+	// SyncToSubRepoBundle filters the history of the original repo, and streams
+	// the filtered history as a git bundle instead of pushing to the sub repo.
+	// Like SyncToSubRepo, only the commits after the last sync are filtered on
+	// top of the synced commits, unless the sub repo is not in sync.
+	//
+	// If incremental is set, the head of the last bundle will be the
+	// prerequisite of the bundle, and only the commits after it are included.
+	// The prerequisite is not the head of SyncStat: SyncStat is the last push
+	// to the sub repo, and the receiver of the bundles may not have it, while it
+	// has the last bundle.
+	//
+	// Unless the branches are overridden, the head of the bundle is saved as
+	// last_bundle_to_commit once it is streamed, so the next incremental bundle
+	// starts from it. SyncStat is not changed, since the sub repo is not pushed.
+	SyncToSubRepoBundle(ctx context.Context, in *SyncToSubRepoBundleRequest, opts ...grpc.CallOption) (GiTrim_SyncToSubRepoBundleClient, error)
+	// ListRepoSyncs lists the repo syncs ordered by id.
+	//
+	// If any of remote_name, owner, or repo is set, only the repo syncs with the
+	// from repo or the to repo matching all the set fields are listed.
+	ListRepoSyncs(ctx context.Context, in *ListRepoSyncsRequest, opts ...grpc.CallOption) (*ListRepoSyncsResponse, error)
+	// UpdateRepoSync updates the repos, the branches, or the filter of a repo
+	// sync, SyncStat is kept.
+	//
+	// Since the id is generated from the repos and the branches, updating them
+	// moves the repo sync to a new id with a new secret. The filter can only be
+	// updated if the canonical filters are unchanged, use UpdateRepoSyncFilter
+	// to change the canonical filters.
+	UpdateRepoSync(ctx context.Context, in *UpdateRepoSyncRequest, opts ...grpc.CallOption) (*UpdateRepoSyncResponse, error)
+	// DeleteRepoSync deletes the repo sync and its secret. The repos are not
+	// touched.
+	DeleteRepoSync(ctx context.Context, in *DeleteRepoSyncRequest, opts ...grpc.CallOption) (*DeleteRepoSyncResponse, error)
+	// UpdateRepoSyncFilter changes the canonical filters of a repo sync, which
+	// rewrites the whole history of the sub repo.
+	//
+	// Without do_push, it previews the paths in the history of the original repo
+	// that appear in or disappear from the sub repo with the new filter.
+	//
+	// With do_push, the history of the sub repo is rebuilt with the new filter,
+	// and pushed to either new_to_branch, which must be empty unless force is
+	// set, or the to_branch with force. The old filter and SyncStat are kept in
+	// previous_filters of the repo sync. Since the id is generated from the
+	// to_branch, a new_to_branch moves the repo sync to a new id with a new
+	// secret.
+	UpdateRepoSyncFilter(ctx context.Context, in *UpdateRepoSyncFilterRequest, opts ...grpc.CallOption) (*UpdateRepoSyncFilterResponse, error)
+	// EnqueueJob queues a SyncToSubRepo or a CommitsFromSubRepo to run in the
+	// background, and returns the job without waiting for it.
+	//
+	// The job is kept in the db until it finishes, so it runs after the server
+	// restarts. Failures to fetch from or push to the remotes are retried with
+	// backoff. If an identical job is pending, the pending job is returned
+	// instead of queuing a new one.
+	EnqueueJob(ctx context.Context, in *EnqueueJobRequest, opts ...grpc.CallOption) (*EnqueueJobResponse, error)
+	// GetJob returns the state of a job, and its result once it finishes.
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
+	// ListJobs lists the jobs, the most recent first. Finished jobs are kept
+	// for job_retention_secs.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// WatchSyncEvents streams the events of the repo syncs as they happen: the
+	// commits filtered or expanded, the branches pushed, and the commits from
+	// the sub repo rejected.
+	//
+	// The events are kept in the db with an increasing sequence, and
+	// after_sequence resumes the stream after the last event seen. The oldest
+	// events are dropped once there are more than max_sync_events, and a gap in
+	// the sequence means the events are lost. The stream ends with
+	// RESOURCE_EXHAUSTED if the client falls too far behind, and can be resumed.
+	WatchSyncEvents(ctx context.Context, in *WatchSyncEventsRequest, opts ...grpc.CallOption) (GiTrim_WatchSyncEventsClient, error)
+	// ListAuditEvents lists the audit events in the order they are recorded.
+	//
+	// An audit event is recorded for each push to the from repo or the to repo,
+	// with who triggered it. Unlike the sync events, the audit events are never
+	// dropped or changed, and they are kept after the repo sync is deleted.
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	// RotateSecret issues a new secret for the repo sync, sealed with the
+	// active AES key, and updates the webhooks registered on the forges.
+	//
+	// The previous secrets are still accepted by the webhooks for the grace
+	// period, so the deliveries signed with the old secret are not rejected
+	// while the webhooks not managed by the forges are updated.
+	RotateSecret(ctx context.Context, in *RotateSecretRequest, opts ...grpc.CallOption) (*RotateSecretResponse, error)
+	// Backup streams a consistent snapshot of the db while the service keeps
+	// running. The snapshot can be used as the db_path of a new service.
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (GiTrim_BackupClient, error)
+	// Export streams the repo syncs, their stats, and their secrets sealed with
+	// the key in the request, in a format independent of the db layout.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (GiTrim_ExportClient, error)
+	// Import adds the records streamed by Export to the db, in one transaction.
+	// The secrets are imported as they are, so the webhooks on the forges keep
+	// working with them.
+	Import(ctx context.Context, opts ...grpc.CallOption) (GiTrim_ImportClient, error)
 }
 
 type giTrimClient struct {
@@ -133,6 +250,258 @@ func (c *giTrimClient) GetRepoSync(ctx context.Context, in *GetRepoSyncRequest,
 	return out, nil
 }
 
+func (c *giTrimClient) CommitsFromPatches(ctx context.Context, in *CommitsFromPatchesRequest, opts ...grpc.CallOption) (*CommitsFromPatchesResponse, error) {
+	out := new(CommitsFromPatchesResponse)
+	err := c.cc.Invoke(ctx, GiTrim_CommitsFromPatches_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) SyncToSubRepoBundle(ctx context.Context, in *SyncToSubRepoBundleRequest, opts ...grpc.CallOption) (GiTrim_SyncToSubRepoBundleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GiTrim_ServiceDesc.Streams[0], GiTrim_SyncToSubRepoBundle_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &giTrimSyncToSubRepoBundleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GiTrim_SyncToSubRepoBundleClient interface {
+	Recv() (*SyncToSubRepoBundleResponse, error)
+	grpc.ClientStream
+}
+
+type giTrimSyncToSubRepoBundleClient struct {
+	grpc.ClientStream
+}
+
+func (x *giTrimSyncToSubRepoBundleClient) Recv() (*SyncToSubRepoBundleResponse, error) {
+	m := new(SyncToSubRepoBundleResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *giTrimClient) ListRepoSyncs(ctx context.Context, in *ListRepoSyncsRequest, opts ...grpc.CallOption) (*ListRepoSyncsResponse, error) {
+	out := new(ListRepoSyncsResponse)
+	err := c.cc.Invoke(ctx, GiTrim_ListRepoSyncs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) UpdateRepoSync(ctx context.Context, in *UpdateRepoSyncRequest, opts ...grpc.CallOption) (*UpdateRepoSyncResponse, error) {
+	out := new(UpdateRepoSyncResponse)
+	err := c.cc.Invoke(ctx, GiTrim_UpdateRepoSync_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) DeleteRepoSync(ctx context.Context, in *DeleteRepoSyncRequest, opts ...grpc.CallOption) (*DeleteRepoSyncResponse, error) {
+	out := new(DeleteRepoSyncResponse)
+	err := c.cc.Invoke(ctx, GiTrim_DeleteRepoSync_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) UpdateRepoSyncFilter(ctx context.Context, in *UpdateRepoSyncFilterRequest, opts ...grpc.CallOption) (*UpdateRepoSyncFilterResponse, error) {
+	out := new(UpdateRepoSyncFilterResponse)
+	err := c.cc.Invoke(ctx, GiTrim_UpdateRepoSyncFilter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) EnqueueJob(ctx context.Context, in *EnqueueJobRequest, opts ...grpc.CallOption) (*EnqueueJobResponse, error) {
+	out := new(EnqueueJobResponse)
+	err := c.cc.Invoke(ctx, GiTrim_EnqueueJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error) {
+	out := new(GetJobResponse)
+	err := c.cc.Invoke(ctx, GiTrim_GetJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, GiTrim_ListJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) WatchSyncEvents(ctx context.Context, in *WatchSyncEventsRequest, opts ...grpc.CallOption) (GiTrim_WatchSyncEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GiTrim_ServiceDesc.Streams[1], GiTrim_WatchSyncEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &giTrimWatchSyncEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GiTrim_WatchSyncEventsClient interface {
+	Recv() (*SyncEvent, error)
+	grpc.ClientStream
+}
+
+type giTrimWatchSyncEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *giTrimWatchSyncEventsClient) Recv() (*SyncEvent, error) {
+	m := new(SyncEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *giTrimClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, GiTrim_ListAuditEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) RotateSecret(ctx context.Context, in *RotateSecretRequest, opts ...grpc.CallOption) (*RotateSecretResponse, error) {
+	out := new(RotateSecretResponse)
+	err := c.cc.Invoke(ctx, GiTrim_RotateSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *giTrimClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (GiTrim_BackupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GiTrim_ServiceDesc.Streams[2], GiTrim_Backup_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &giTrimBackupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GiTrim_BackupClient interface {
+	Recv() (*BackupResponse, error)
+	grpc.ClientStream
+}
+
+type giTrimBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *giTrimBackupClient) Recv() (*BackupResponse, error) {
+	m := new(BackupResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *giTrimClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (GiTrim_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GiTrim_ServiceDesc.Streams[3], GiTrim_Export_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &giTrimExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GiTrim_ExportClient interface {
+	Recv() (*ExportedRecord, error)
+	grpc.ClientStream
+}
+
+type giTrimExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *giTrimExportClient) Recv() (*ExportedRecord, error) {
+	m := new(ExportedRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *giTrimClient) Import(ctx context.Context, opts ...grpc.CallOption) (GiTrim_ImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GiTrim_ServiceDesc.Streams[4], GiTrim_Import_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &giTrimImportClient{stream}
+	return x, nil
+}
+
+type GiTrim_ImportClient interface {
+	Send(*ImportRequest) error
+	CloseAndRecv() (*ImportResponse, error)
+	grpc.ClientStream
+}
+
+type giTrimImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *giTrimImportClient) Send(m *ImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *giTrimImportClient) CloseAndRecv() (*ImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // GiTrimServer is the server API for GiTrim service.
 // All implementations must embed UnimplementedGiTrimServer
 // for forward compatibility
@@ -175,6 +544,108 @@ type GiTrimServer interface {
 	CheckCommitsFromSubRepo(context.Context, *CheckCommitsFromSubRepoRequest) (*CheckCommitsFromSubRepoResponse, error)
 	// GetRepoSync obtain the sync relation by the id.
 	GetRepoSync(context.Context, *GetRepoSyncRequest) (*GetRepoSyncResponse, error)
+	// CommitsFromPatches applies patches generated by "git format-patch"
+	// against the sub repo to the original repo.
+	//
+	// The patches are applied on top of the head of the sub repo, and the
+	// patches will be rejected if:
+	//   - the from repo or the sub repo is not in sync.
+	//   - the modification contained is rejected by the filter.
+	//
+	// The generated commits are pushed to the original repo, then to the sub
+	// repo. If the push to the sub repo fails, the error tells the commits
+	// pushed to the original repo, and SyncToSubRepo syncs them to the sub repo.
+	CommitsFromPatches(context.Context, *CommitsFromPatchesRequest) (*CommitsFromPatchesResponse, error)
+	// SyncToSubRepoBundle filters the history of the original repo, and streams
+	// the filtered history as a git bundle instead of pushing to the sub repo.
+	// Like SyncToSubRepo, only the commits after the last sync are filtered on
+	// top of the synced commits, unless the sub repo is not in sync.
+	//
+	// If incremental is set, the head of the last bundle will be the
+	// prerequisite of the bundle, and only the commits after it are included.
+	// The prerequisite is not the head of SyncStat: SyncStat is the last push
+	// to the sub repo, and the receiver of the bundles may not have it, while it
+	// has the last bundle.
+	//
+	// Unless the branches are overridden, the head of the bundle is saved as
+	// last_bundle_to_commit once it is streamed, so the next incremental bundle
+	// starts from it. SyncStat is not changed, since the sub repo is not pushed.
+	SyncToSubRepoBundle(*SyncToSubRepoBundleRequest, GiTrim_SyncToSubRepoBundleServer) error
+	// ListRepoSyncs lists the repo syncs ordered by id.
+	//
+	// If any of remote_name, owner, or repo is set, only the repo syncs with the
+	// from repo or the to repo matching all the set fields are listed.
+	ListRepoSyncs(context.Context, *ListRepoSyncsRequest) (*ListRepoSyncsResponse, error)
+	// UpdateRepoSync updates the repos, the branches, or the filter of a repo
+	// sync, SyncStat is kept.
+	//
+	// Since the id is generated from the repos and the branches, updating them
+	// moves the repo sync to a new id with a new secret. The filter can only be
+	// updated if the canonical filters are unchanged, use UpdateRepoSyncFilter
+	// to change the canonical filters.
+	UpdateRepoSync(context.Context, *UpdateRepoSyncRequest) (*UpdateRepoSyncResponse, error)
+	// DeleteRepoSync deletes the repo sync and its secret. The repos are not
+	// touched.
+	DeleteRepoSync(context.Context, *DeleteRepoSyncRequest) (*DeleteRepoSyncResponse, error)
+	// UpdateRepoSyncFilter changes the canonical filters of a repo sync, which
+	// rewrites the whole history of the sub repo.
+	//
+	// Without do_push, it previews the paths in the history of the original repo
+	// that appear in or disappear from the sub repo with the new filter.
+	//
+	// With do_push, the history of the sub repo is rebuilt with the new filter,
+	// and pushed to either new_to_branch, which must be empty unless force is
+	// set, or the to_branch with force. The old filter and SyncStat are kept in
+	// previous_filters of the repo sync. Since the id is generated from the
+	// to_branch, a new_to_branch moves the repo sync to a new id with a new
+	// secret.
+	UpdateRepoSyncFilter(context.Context, *UpdateRepoSyncFilterRequest) (*UpdateRepoSyncFilterResponse, error)
+	// EnqueueJob queues a SyncToSubRepo or a CommitsFromSubRepo to run in the
+	// background, and returns the job without waiting for it.
+	//
+	// The job is kept in the db until it finishes, so it runs after the server
+	// restarts. Failures to fetch from or push to the remotes are retried with
+	// backoff. If an identical job is pending, the pending job is returned
+	// instead of queuing a new one.
+	EnqueueJob(context.Context, *EnqueueJobRequest) (*EnqueueJobResponse, error)
+	// GetJob returns the state of a job, and its result once it finishes.
+	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
+	// ListJobs lists the jobs, the most recent first. Finished jobs are kept
+	// for job_retention_secs.
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// WatchSyncEvents streams the events of the repo syncs as they happen: the
+	// commits filtered or expanded, the branches pushed, and the commits from
+	// the sub repo rejected.
+	//
+	// The events are kept in the db with an increasing sequence, and
+	// after_sequence resumes the stream after the last event seen. The oldest
+	// events are dropped once there are more than max_sync_events, and a gap in
+	// the sequence means the events are lost. The stream ends with
+	// RESOURCE_EXHAUSTED if the client falls too far behind, and can be resumed.
+	WatchSyncEvents(*WatchSyncEventsRequest, GiTrim_WatchSyncEventsServer) error
+	// ListAuditEvents lists the audit events in the order they are recorded.
+	//
+	// An audit event is recorded for each push to the from repo or the to repo,
+	// with who triggered it. Unlike the sync events, the audit events are never
+	// dropped or changed, and they are kept after the repo sync is deleted.
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	// RotateSecret issues a new secret for the repo sync, sealed with the
+	// active AES key, and updates the webhooks registered on the forges.
+	//
+	// The previous secrets are still accepted by the webhooks for the grace
+	// period, so the deliveries signed with the old secret are not rejected
+	// while the webhooks not managed by the forges are updated.
+	RotateSecret(context.Context, *RotateSecretRequest) (*RotateSecretResponse, error)
+	// Backup streams a consistent snapshot of the db while the service keeps
+	// running. The snapshot can be used as the db_path of a new service.
+	Backup(*BackupRequest, GiTrim_BackupServer) error
+	// Export streams the repo syncs, their stats, and their secrets sealed with
+	// the key in the request, in a format independent of the db layout.
+	Export(*ExportRequest, GiTrim_ExportServer) error
+	// Import adds the records streamed by Export to the db, in one transaction.
+	// The secrets are imported as they are, so the webhooks on the forges keep
+	// working with them.
+	Import(GiTrim_ImportServer) error
 	mustEmbedUnimplementedGiTrimServer()
 }
 
@@ -200,6 +671,51 @@ func (UnimplementedGiTrimServer) CheckCommitsFromSubRepo(context.Context, *Check
 func (UnimplementedGiTrimServer) GetRepoSync(context.Context, *GetRepoSyncRequest) (*GetRepoSyncResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRepoSync not implemented")
 }
+func (UnimplementedGiTrimServer) CommitsFromPatches(context.Context, *CommitsFromPatchesRequest) (*CommitsFromPatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitsFromPatches not implemented")
+}
+func (UnimplementedGiTrimServer) SyncToSubRepoBundle(*SyncToSubRepoBundleRequest, GiTrim_SyncToSubRepoBundleServer) error {
+	return status.Errorf(codes.Unimplemented, "method SyncToSubRepoBundle not implemented")
+}
+func (UnimplementedGiTrimServer) ListRepoSyncs(context.Context, *ListRepoSyncsRequest) (*ListRepoSyncsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRepoSyncs not implemented")
+}
+func (UnimplementedGiTrimServer) UpdateRepoSync(context.Context, *UpdateRepoSyncRequest) (*UpdateRepoSyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRepoSync not implemented")
+}
+func (UnimplementedGiTrimServer) DeleteRepoSync(context.Context, *DeleteRepoSyncRequest) (*DeleteRepoSyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRepoSync not implemented")
+}
+func (UnimplementedGiTrimServer) UpdateRepoSyncFilter(context.Context, *UpdateRepoSyncFilterRequest) (*UpdateRepoSyncFilterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRepoSyncFilter not implemented")
+}
+func (UnimplementedGiTrimServer) EnqueueJob(context.Context, *EnqueueJobRequest) (*EnqueueJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnqueueJob not implemented")
+}
+func (UnimplementedGiTrimServer) GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedGiTrimServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedGiTrimServer) WatchSyncEvents(*WatchSyncEventsRequest, GiTrim_WatchSyncEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSyncEvents not implemented")
+}
+func (UnimplementedGiTrimServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedGiTrimServer) RotateSecret(context.Context, *RotateSecretRequest) (*RotateSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSecret not implemented")
+}
+func (UnimplementedGiTrimServer) Backup(*BackupRequest, GiTrim_BackupServer) error {
+	return status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (UnimplementedGiTrimServer) Export(*ExportRequest, GiTrim_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedGiTrimServer) Import(GiTrim_ImportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
 func (UnimplementedGiTrimServer) mustEmbedUnimplementedGiTrimServer() {}
 
 // UnsafeGiTrimServer may be embedded to opt out of forward compatibility for this service.
@@ -321,6 +837,296 @@ func _GiTrim_GetRepoSync_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GiTrim_CommitsFromPatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitsFromPatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).CommitsFromPatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_CommitsFromPatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).CommitsFromPatches(ctx, req.(*CommitsFromPatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_SyncToSubRepoBundle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SyncToSubRepoBundleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GiTrimServer).SyncToSubRepoBundle(m, &giTrimSyncToSubRepoBundleServer{stream})
+}
+
+type GiTrim_SyncToSubRepoBundleServer interface {
+	Send(*SyncToSubRepoBundleResponse) error
+	grpc.ServerStream
+}
+
+type giTrimSyncToSubRepoBundleServer struct {
+	grpc.ServerStream
+}
+
+func (x *giTrimSyncToSubRepoBundleServer) Send(m *SyncToSubRepoBundleResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GiTrim_ListRepoSyncs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRepoSyncsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).ListRepoSyncs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_ListRepoSyncs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).ListRepoSyncs(ctx, req.(*ListRepoSyncsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_UpdateRepoSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRepoSyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).UpdateRepoSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_UpdateRepoSync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).UpdateRepoSync(ctx, req.(*UpdateRepoSyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_DeleteRepoSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRepoSyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).DeleteRepoSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_DeleteRepoSync_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).DeleteRepoSync(ctx, req.(*DeleteRepoSyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_UpdateRepoSyncFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRepoSyncFilterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).UpdateRepoSyncFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_UpdateRepoSyncFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).UpdateRepoSyncFilter(ctx, req.(*UpdateRepoSyncFilterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_EnqueueJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).EnqueueJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_EnqueueJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).EnqueueJob(ctx, req.(*EnqueueJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_WatchSyncEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSyncEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GiTrimServer).WatchSyncEvents(m, &giTrimWatchSyncEventsServer{stream})
+}
+
+type GiTrim_WatchSyncEventsServer interface {
+	Send(*SyncEvent) error
+	grpc.ServerStream
+}
+
+type giTrimWatchSyncEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *giTrimWatchSyncEventsServer) Send(m *SyncEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GiTrim_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_RotateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GiTrimServer).RotateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GiTrim_RotateSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GiTrimServer).RotateSecret(ctx, req.(*RotateSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GiTrim_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GiTrimServer).Backup(m, &giTrimBackupServer{stream})
+}
+
+type GiTrim_BackupServer interface {
+	Send(*BackupResponse) error
+	grpc.ServerStream
+}
+
+type giTrimBackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *giTrimBackupServer) Send(m *BackupResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GiTrim_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GiTrimServer).Export(m, &giTrimExportServer{stream})
+}
+
+type GiTrim_ExportServer interface {
+	Send(*ExportedRecord) error
+	grpc.ServerStream
+}
+
+type giTrimExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *giTrimExportServer) Send(m *ExportedRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GiTrim_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GiTrimServer).Import(&giTrimImportServer{stream})
+}
+
+type GiTrim_ImportServer interface {
+	SendAndClose(*ImportResponse) error
+	Recv() (*ImportRequest, error)
+	grpc.ServerStream
+}
+
+type giTrimImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *giTrimImportServer) SendAndClose(m *ImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *giTrimImportServer) Recv() (*ImportRequest, error) {
+	m := new(ImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // GiTrim_ServiceDesc is the grpc.ServiceDesc for GiTrim service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -352,7 +1158,73 @@ var GiTrim_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRepoSync",
 			Handler:    _GiTrim_GetRepoSync_Handler,
 		},
+		{
+			MethodName: "CommitsFromPatches",
+			Handler:    _GiTrim_CommitsFromPatches_Handler,
+		},
+		{
+			MethodName: "ListRepoSyncs",
+			Handler:    _GiTrim_ListRepoSyncs_Handler,
+		},
+		{
+			MethodName: "UpdateRepoSync",
+			Handler:    _GiTrim_UpdateRepoSync_Handler,
+		},
+		{
+			MethodName: "DeleteRepoSync",
+			Handler:    _GiTrim_DeleteRepoSync_Handler,
+		},
+		{
+			MethodName: "UpdateRepoSyncFilter",
+			Handler:    _GiTrim_UpdateRepoSyncFilter_Handler,
+		},
+		{
+			MethodName: "EnqueueJob",
+			Handler:    _GiTrim_EnqueueJob_Handler,
+		},
+		{
+			MethodName: "GetJob",
+			Handler:    _GiTrim_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _GiTrim_ListJobs_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _GiTrim_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "RotateSecret",
+			Handler:    _GiTrim_RotateSecret_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SyncToSubRepoBundle",
+			Handler:       _GiTrim_SyncToSubRepoBundle_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchSyncEvents",
+			Handler:       _GiTrim_WatchSyncEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Backup",
+			Handler:       _GiTrim_Backup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _GiTrim_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Import",
+			Handler:       _GiTrim_Import_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "svc.proto",
 }
@@ -0,0 +1,87 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"google.golang.org/grpc/status"
+
+	"github.com/fardream/gitrim"
+)
+
+// checkSubHistoryForPatches checks if the patches can be applied, the patches are created
+// against the head of the sub repo, so both repos must be in sync.
+func checkSubHistoryForPatches(fromstatus, tostatus LastSyncCommitStatus_Enum) SubRepoCommitsCheck_Status {
+	switch {
+	case fromstatus != LastSyncCommitStatus_INSYNC:
+		return SubRepoCommitsCheck_FROM_NOT_IN_SYNC
+	case tostatus != LastSyncCommitStatus_INSYNC:
+		return SubRepoCommitsCheck_TO_DIVERGED
+	default:
+		return SubRepoCommitsCheck_CHECK_PASSED
+	}
+}
+
+// errToPushAfterFromPush is returned when the new commits are pushed to the from repo, but not to the to repo. The
+// stat is not saved, so the next SyncToSubRepo filters the pushed commits into the to repo.
+func errToPushAfterFromPush(sw *syncWorkspace, fromcommits []*object.Commit, err error) error {
+	from, to := sw.db.SyncData.FromRepo, sw.db.SyncData.ToRepo
+	return status.Errorf(statusCodeForRemoteError(err),
+		"pushed %d new commits to %s/%s/%s branch %s with new head %s, but failed to push %s/%s/%s branch %s, run SyncToSubRepo to sync the to repo: %s",
+		len(fromcommits), from.RemoteName, from.Owner, from.Repo, sw.fromWksp.branch, getHashStringPossibleNil(sw.fromWksp.branchhead),
+		to.RemoteName, to.Owner, to.Repo, sw.toWksp.branch, err.Error())
+}
+
+// syncPatchesToFrom applies the patches on top of the sub repo, and expands the generated commits to
+// the from repo. It returns the new commits in from repo and to repo.
+func (sw *syncWorkspace) syncPatchesToFrom(ctx context.Context, patches []*gitrim.MailPatch, dopush bool) ([]*object.Commit, []*object.Commit, error) {
+	status := checkSubHistoryForPatches(sw.fromStatus, sw.toStatus)
+	if status != SubRepoCommitsCheck_CHECK_PASSED {
+		return nil, nil, fmt.Errorf("repos are not in good status to apply patches: from repo status %s, to repo status %s", sw.fromStatus.String(), sw.toStatus.String())
+	}
+
+	fromhead, _, err := sw.db.Stat.Heads()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get from head from stat: %w", err)
+	}
+
+	filtereddfs, err := sw.getFilteredDFS()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get local status for commits: %w", err)
+	}
+
+	tocommits, fromcommits, err := filtereddfs.ApplyMailPatches(ctx, fromhead, patches, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply patches: %w", err)
+	}
+
+	fromc, toc, err := filtereddfs.LastCommits()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the last commits after applying patches: %w", err)
+	}
+
+	oldfromhead, oldtohead := sw.fromWksp.branchhead, sw.toWksp.branchhead
+	if err := sw.fromWksp.updateBranchHead(fromc); err != nil {
+		return nil, nil, fmt.Errorf("failed to update from branch head after expanding: %w", err)
+	}
+	if err := sw.toWksp.updateBranchHead(toc); err != nil {
+		return nil, nil, fmt.Errorf("failed to update to branch head after applying patches: %w", err)
+	}
+
+	if dopush {
+		// the from repo is pushed first, since the to repo can be synced from it again.
+		if err := sw.fromWksp.pushToRemote(ctx, false); err != nil {
+			return nil, nil, fmt.Errorf("failed to update from repo, nothing is pushed: %w", err)
+		}
+		sw.publish(sw.pushed(ctx, sw.db.SyncData.FromRepo, sw.fromWksp, oldfromhead, fromcommits, false))
+		if err := sw.toWksp.pushToRemote(ctx, false); err != nil {
+			return nil, nil, errToPushAfterFromPush(sw, fromcommits, err)
+		}
+		sw.publish(sw.pushed(ctx, sw.db.SyncData.ToRepo, sw.toWksp, oldtohead, tocommits, false))
+	}
+
+	sw.updateStat(filtereddfs, fromc, toc)
+
+	return fromcommits, tocommits, nil
+}
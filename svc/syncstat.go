@@ -14,7 +14,9 @@ func (s *SyncStat) SetToEmpty() {
 	s.Reset()
 }
 
-func (s *SyncStat) Hashes() (fromhead plumbing.Hash, frompastcommits gitrim.HashSet, tohead plumbing.Hash, topastcommits gitrim.HashSet, err error) {
+// Heads returns the last synced commits of the from repo and the to repo, the synced commits before them are kept
+// in SYNC_STAT_BUCKET.
+func (s *SyncStat) Heads() (fromhead plumbing.Hash, tohead plumbing.Hash, err error) {
 	if s.IsEmpty() {
 		return
 	}
@@ -23,18 +25,10 @@ func (s *SyncStat) Hashes() (fromhead plumbing.Hash, frompastcommits gitrim.Hash
 	if err != nil {
 		return
 	}
-	frompastcommits, err = gitrim.NewHashSetFromStrings(s.FromDfs...)
-	if err != nil {
-		return
-	}
 	tohead, err = gitrim.DecodeHashHex(s.LastSyncToCommit)
 	if err != nil {
 		return
 	}
-	topastcommits, err = gitrim.NewHashSetFromStrings(s.ToDfs...)
-	if err != nil {
-		return
-	}
 
 	return
 }
@@ -0,0 +1,625 @@
+package svc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/fardream/gitrim"
+)
+
+// The synced commits and their mappings of a repo sync are kept in a nested bucket of SYNC_STAT_BUCKET keyed by the
+// id of the repo sync, so a sync only writes the commits it adds. The stat in DbRepoSync only keeps the heads.
+//
+//   - from-dfs and to-dfs: the commits in the order they are added, keyed by a big endian sequence.
+//   - from-to-to and to-to-from: the raw hashes of the commits mapped to each other, a zero hash is a commit
+//     dropped by the filter.
+//   - previous-filters: the maps of the filters replaced by UpdateRepoSyncFilter, kept for auditing. Each is a
+//     nested bucket with the four buckets above, keyed by the big endian index of the filter in
+//     DbRepoSync.PreviousFilters.
+var (
+	fromDfsBucket         = []byte("from-dfs")
+	toDfsBucket           = []byte("to-dfs")
+	fromToToBucket        = []byte("from-to-to")
+	toToFromBucket        = []byte("to-to-from")
+	previousFiltersBucket = []byte("previous-filters")
+
+	syncStatMapBuckets = [][]byte{fromDfsBucket, toDfsBucket, fromToToBucket, toToFromBucket}
+)
+
+// syncStatMaps are the synced commits and their mappings of a repo sync. They are loaded straight into the
+// [gitrim.FilteredDFS] of the sync, so they are only kept in memory once.
+type syncStatMaps struct {
+	dfs *gitrim.FilteredDFS
+	// fromBase and toBase are the numbers of the commits loaded, the ones after them are added by the sync.
+	fromBase int
+	toBase   int
+
+	// replace is set if the maps are not loaded from the db, and must replace the stored ones.
+	replace bool
+}
+
+func newSyncStatMaps(fromstorage storer.Storer, tostorage storer.Storer, filter gitrim.Filter) *syncStatMaps {
+	return &syncStatMaps{dfs: gitrim.NewEmptyFilteredDFS(fromstorage, tostorage, filter)}
+}
+
+// pastCommits returns the synced commits of the from repo and the to repo loaded from the db.
+func (m *syncStatMaps) pastCommits() (gitrim.HashSet, gitrim.HashSet) {
+	return lazyCommitHashSet(m.dfs.FromDFS.Path[:m.fromBase]), lazyCommitHashSet(m.dfs.ToDFS.Path[:m.toBase])
+}
+
+func lazyCommitHashSet(commits []*gitrim.LazyCommit) gitrim.HashSet {
+	r := make(gitrim.HashSet, len(commits))
+	for _, c := range commits {
+		r[c.Hash] = struct{}{}
+	}
+
+	return r
+}
+
+func decodeRawHash(v []byte) (plumbing.Hash, error) {
+	if len(v) != len(plumbing.ZeroHash) {
+		return plumbing.ZeroHash, fmt.Errorf("invalid length %d of hash", len(v))
+	}
+
+	return plumbing.Hash(v), nil
+}
+
+func getSyncStatHashes(b *bbolt.Bucket, path *gitrim.KeyedDFSPath) error {
+	if b == nil {
+		return nil
+	}
+
+	return b.ForEach(func(_, v []byte) error {
+		h, err := decodeRawHash(v)
+		if err != nil {
+			return err
+		}
+		path.AddHash(h)
+		return nil
+	})
+}
+
+func getSyncStatMappings(b *bbolt.Bucket, m map[plumbing.Hash]plumbing.Hash) error {
+	if b == nil {
+		return nil
+	}
+
+	return b.ForEach(func(k, v []byte) error {
+		hk, err := decodeRawHash(k)
+		if err != nil {
+			return err
+		}
+		hv, err := decodeRawHash(v)
+		if err != nil {
+			return err
+		}
+		m[hk] = hv
+		return nil
+	})
+}
+
+// getSyncStatMaps loads the synced commits and their mappings of the repo sync, the maps are empty if nothing is
+// synced yet.
+func getSyncStatMaps(tx *bbolt.Tx, id []byte) (*syncStatMaps, error) {
+	m := newSyncStatMaps(nil, nil, nil)
+	if err := m.load(getSyncStatBucket(tx, id)); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// getPreviousSyncStatMaps loads the maps of the previous filter at index of the repo sync, nil if they are not kept.
+func getPreviousSyncStatMaps(tx *bbolt.Tx, id []byte, index int) (*syncStatMaps, error) {
+	b := getPreviousSyncStatBucket(tx, id, index)
+	if b == nil {
+		return nil, nil
+	}
+	m := newSyncStatMaps(nil, nil, nil)
+	if err := m.load(b); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func getSyncStatBucket(tx *bbolt.Tx, id []byte) *bbolt.Bucket {
+	statbucket := tx.Bucket([]byte(SYNC_STAT_BUCKET))
+	if statbucket == nil {
+		return nil
+	}
+
+	return statbucket.Bucket(id)
+}
+
+func previousFilterKey(index int) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(index))
+}
+
+func getPreviousSyncStatBucket(tx *bbolt.Tx, id []byte, index int) *bbolt.Bucket {
+	b := getSyncStatBucket(tx, id)
+	if b == nil {
+		return nil
+	}
+	previous := b.Bucket(previousFiltersBucket)
+	if previous == nil {
+		return nil
+	}
+
+	return previous.Bucket(previousFilterKey(index))
+}
+
+// load adds the synced commits and their mappings in b to the maps.
+func (m *syncStatMaps) load(b *bbolt.Bucket) error {
+	if b == nil {
+		return nil
+	}
+
+	if err := getSyncStatHashes(b.Bucket(fromDfsBucket), &m.dfs.FromDFS); err != nil {
+		return fmt.Errorf("from dfs: %w", err)
+	}
+	if err := getSyncStatHashes(b.Bucket(toDfsBucket), &m.dfs.ToDFS); err != nil {
+		return fmt.Errorf("to dfs: %w", err)
+	}
+	if err := getSyncStatMappings(b.Bucket(fromToToBucket), m.dfs.FromToTo); err != nil {
+		return fmt.Errorf("from to to: %w", err)
+	}
+	if err := getSyncStatMappings(b.Bucket(toToFromBucket), m.dfs.ToToFrom); err != nil {
+		return fmt.Errorf("to to from: %w", err)
+	}
+	m.fromBase, m.toBase = len(m.dfs.FromDFS.Path), len(m.dfs.ToDFS.Path)
+
+	return nil
+}
+
+// getSyncStatMapsFromDb loads the synced commits and their mappings of the repo sync into a [gitrim.FilteredDFS] with
+// the storages and the filter.
+func getSyncStatMapsFromDb(db *bbolt.DB, id []byte, fromstorage storer.Storer, tostorage storer.Storer, filter gitrim.Filter) (*syncStatMaps, error) {
+	m := newSyncStatMaps(fromstorage, tostorage, filter)
+	if err := db.View(func(tx *bbolt.Tx) error {
+		return m.load(getSyncStatBucket(tx, id))
+	}); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *syncStatMaps) fill(stat *SyncStat) {
+	stat.FromDfs, stat.ToDfs, stat.FromToTo, stat.ToToFrom = m.dfs.DumpStat()
+}
+
+// fillStatMaps fills the synced commits and their mappings in the stat of the repo sync and in the stats of its
+// previous filters.
+func fillStatMaps(tx *bbolt.Tx, id []byte, reposync *DbRepoSync) error {
+	if reposync.Stat != nil {
+		m, err := getSyncStatMaps(tx, id)
+		if err != nil {
+			return err
+		}
+		m.fill(reposync.Stat)
+	}
+	for i, f := range reposync.PreviousFilters {
+		m, err := getPreviousSyncStatMaps(tx, id, i)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			continue
+		}
+		if f.SyncStat == nil {
+			f.SyncStat = &SyncStat{}
+		}
+		m.fill(f.SyncStat)
+	}
+
+	return nil
+}
+
+// deleteSyncStatFunc deletes the synced commits and their mappings of the repo sync, including the ones of its previous
+// filters.
+func deleteSyncStatFunc(id []byte) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		statbucket := tx.Bucket([]byte(SYNC_STAT_BUCKET))
+		if statbucket == nil || statbucket.Bucket(id) == nil {
+			return nil
+		}
+
+		return statbucket.DeleteBucket(id)
+	}
+}
+
+func createSyncStatMapBuckets(b *bbolt.Bucket) error {
+	for _, name := range syncStatMapBuckets {
+		if _, err := b.CreateBucketIfNotExists(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clearSyncStatMapBuckets deletes the synced commits and their mappings in b, and leaves the other buckets in b.
+func clearSyncStatMapBuckets(b *bbolt.Bucket) error {
+	for _, name := range syncStatMapBuckets {
+		if b.Bucket(name) == nil {
+			continue
+		}
+		if err := b.DeleteBucket(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clearSyncStatFunc deletes the synced commits and their mappings of the repo sync, and keeps the ones of its previous
+// filters.
+func clearSyncStatFunc(id []byte) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		b := getSyncStatBucket(tx, id)
+		if b == nil {
+			return nil
+		}
+
+		return clearSyncStatMapBuckets(b)
+	}
+}
+
+// createSyncStatBucket returns the nested bucket of the repo sync, and creates it with its sub buckets if missing.
+func createSyncStatBucket(tx *bbolt.Tx, id []byte) (*bbolt.Bucket, error) {
+	statbucket, err := tx.CreateBucketIfNotExists([]byte(SYNC_STAT_BUCKET))
+	if err != nil {
+		return nil, err
+	}
+	b, err := statbucket.CreateBucketIfNotExists(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := createSyncStatMapBuckets(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// createPreviousSyncStatBucket returns the bucket of the previous filter at index of the repo sync, and creates it
+// with its sub buckets if missing.
+func createPreviousSyncStatBucket(tx *bbolt.Tx, id []byte, index int) (*bbolt.Bucket, error) {
+	statbucket, err := tx.CreateBucketIfNotExists([]byte(SYNC_STAT_BUCKET))
+	if err != nil {
+		return nil, err
+	}
+	b, err := statbucket.CreateBucketIfNotExists(id)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := b.CreateBucketIfNotExists(previousFiltersBucket)
+	if err != nil {
+		return nil, err
+	}
+	r, err := previous.CreateBucketIfNotExists(previousFilterKey(index))
+	if err != nil {
+		return nil, err
+	}
+	if err := createSyncStatMapBuckets(r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// archiveSyncStatFunc moves the synced commits and their mappings of the repo sync to the bucket of the previous
+// filter at index, when the filter is replaced. The current maps are empty afterwards.
+func archiveSyncStatFunc(id []byte, index int) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		src := getSyncStatBucket(tx, id)
+		if src == nil {
+			return nil
+		}
+		dst, err := createPreviousSyncStatBucket(tx, id, index)
+		if err != nil {
+			return err
+		}
+		for _, name := range syncStatMapBuckets {
+			if src.Bucket(name) == nil {
+				continue
+			}
+			if err := dst.DeleteBucket(name); err != nil {
+				return err
+			}
+			if err := src.MoveBucket(name, dst); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// copyBucket copies the keys, the nested buckets and the sequences of src to dst.
+func copyBucket(src *bbolt.Bucket, dst *bbolt.Bucket) error {
+	if err := dst.SetSequence(src.Sequence()); err != nil {
+		return err
+	}
+
+	return src.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return dst.Put(k, v)
+		}
+		sub, err := dst.CreateBucketIfNotExists(k)
+		if err != nil {
+			return err
+		}
+		return copyBucket(src.Bucket(k), sub)
+	})
+}
+
+// copySyncStatFunc copies the synced commits and their mappings of the repo sync under id to newid, including the ones
+// of its previous filters.
+func copySyncStatFunc(id []byte, newid []byte) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		if bytes.Equal(id, newid) {
+			return nil
+		}
+		src := getSyncStatBucket(tx, id)
+		if src == nil {
+			return nil
+		}
+		if err := deleteSyncStatFunc(newid)(tx); err != nil {
+			return err
+		}
+		dst, err := tx.Bucket([]byte(SYNC_STAT_BUCKET)).CreateBucket(newid)
+		if err != nil {
+			return err
+		}
+
+		return copyBucket(src, dst)
+	}
+}
+
+func appendRawHash(b *bbolt.Bucket, h plumbing.Hash) error {
+	seq, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	return b.Put(binary.BigEndian.AppendUint64(nil, seq), h[:])
+}
+
+func putRawMapping(b *bbolt.Bucket, k plumbing.Hash, v plumbing.Hash) error {
+	return b.Put(k[:], v[:])
+}
+
+// syncStatUpdate is the change of the synced commits and their mappings after a sync.
+type syncStatUpdate struct {
+	dfs *gitrim.FilteredDFS
+	// the number of the commits in the from and to paths of dfs already saved, the commits after them are added.
+	fromBase int
+	toBase   int
+	// replace drops the saved maps, and saves all of dfs.
+	replace bool
+}
+
+// putFunc saves the change of the maps of the repo sync.
+func (u *syncStatUpdate) putFunc(id []byte) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		b, err := createSyncStatBucket(tx, id)
+		if err != nil {
+			return err
+		}
+		return u.put(b)
+	}
+}
+
+// put saves the change of the maps in b, which has the sub buckets of the maps.
+//
+// The mappings added by a sync are all about the added commits: an added from commit is mapped to a to commit, which
+// may be an existing one mapped back to the added from commit, and an added to commit is mapped to its from commit.
+func (u *syncStatUpdate) put(b *bbolt.Bucket) error {
+	if u.replace {
+		if err := clearSyncStatMapBuckets(b); err != nil {
+			return err
+		}
+		if err := createSyncStatMapBuckets(b); err != nil {
+			return err
+		}
+	}
+	fromdfs, todfs := b.Bucket(fromDfsBucket), b.Bucket(toDfsBucket)
+	fromtoto, totofrom := b.Bucket(fromToToBucket), b.Bucket(toToFromBucket)
+
+	if u.replace {
+		for k, v := range u.dfs.FromToTo {
+			if err := putRawMapping(fromtoto, k, v); err != nil {
+				return err
+			}
+		}
+		for k, v := range u.dfs.ToToFrom {
+			if err := putRawMapping(totofrom, k, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, c := range u.dfs.FromDFS.Path[u.fromBase:] {
+		if err := appendRawHash(fromdfs, c.Hash); err != nil {
+			return err
+		}
+		if u.replace {
+			continue
+		}
+		to, found := u.dfs.FromToTo[c.Hash]
+		if !found {
+			continue
+		}
+		if err := putRawMapping(fromtoto, c.Hash, to); err != nil {
+			return err
+		}
+		if from, found := u.dfs.ToToFrom[to]; found && !to.IsZero() {
+			if err := putRawMapping(totofrom, to, from); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, c := range u.dfs.ToDFS.Path[u.toBase:] {
+		if err := appendRawHash(todfs, c.Hash); err != nil {
+			return err
+		}
+		if u.replace {
+			continue
+		}
+		if from, found := u.dfs.ToToFrom[c.Hash]; found {
+			if err := putRawMapping(totofrom, c.Hash, from); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasStatMaps checks if the stat still has the synced commits or their mappings, which are moved to
+// SYNC_STAT_BUCKET.
+func hasStatMaps(stat *SyncStat) bool {
+	return len(stat.GetFromDfs()) > 0 || len(stat.GetToDfs()) > 0 || len(stat.GetFromToTo()) > 0 || len(stat.GetToToFrom()) > 0
+}
+
+// compactStat drops everything but the heads from the stat.
+func compactStat(stat *SyncStat) {
+	if stat == nil {
+		return
+	}
+	stat.FromDfs, stat.ToDfs = nil, nil
+	stat.FromToTo, stat.ToToFrom = nil, nil
+}
+
+// putLegacyStat moves the synced commits and their mappings in stat to b.
+func putLegacyStat(b *bbolt.Bucket, stat *SyncStat) error {
+	dfs, err := gitrim.NewFilteredDFSWithStat(stat.FromDfs, stat.ToDfs, stat.FromToTo, stat.ToToFrom, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	if err := (&syncStatUpdate{dfs: dfs, replace: true}).put(b); err != nil {
+		return err
+	}
+	compactStat(stat)
+
+	return nil
+}
+
+// migrateSyncStatsFunc moves the synced commits and their mappings kept in the stats of the repo syncs to
+// SYNC_STAT_BUCKET. The stats of the previous filters only keep their heads. The repo syncs already moved are skipped.
+func migrateSyncStatsFunc(tx *bbolt.Tx) error {
+	reposyncbucket := tx.Bucket([]byte(REPO_SYNC_BUCKET))
+	if reposyncbucket == nil {
+		return nil
+	}
+
+	// the bucket cannot be changed while iterating.
+	type legacy struct {
+		id       []byte
+		reposync *DbRepoSync
+	}
+	var legacies []legacy
+	if err := reposyncbucket.ForEach(func(k, v []byte) error {
+		reposync := &DbRepoSync{}
+		if err := proto.Unmarshal(v, reposync); err != nil {
+			return fmt.Errorf("failed to unmarshal repo sync %x: %w", k, err)
+		}
+		needed := hasStatMaps(reposync.Stat)
+		for _, f := range reposync.PreviousFilters {
+			needed = needed || hasStatMaps(f.SyncStat)
+		}
+		if needed {
+			legacies = append(legacies, legacy{id: append([]byte(nil), k...), reposync: reposync})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, l := range legacies {
+		if stat := l.reposync.Stat; hasStatMaps(stat) {
+			dfs, err := gitrim.NewFilteredDFSWithStat(stat.FromDfs, stat.ToDfs, stat.FromToTo, stat.ToToFrom, nil, nil, nil)
+			if err != nil {
+				return fmt.Errorf("failed to decode stat of repo sync %x: %w", l.id, err)
+			}
+			if err := (&syncStatUpdate{dfs: dfs, replace: true}).putFunc(l.id)(tx); err != nil {
+				return err
+			}
+			compactStat(stat)
+		}
+		for _, f := range l.reposync.PreviousFilters {
+			compactStat(f.SyncStat)
+		}
+		if err := putRepoSyncFunc(l.id, l.reposync)(tx); err != nil {
+			return err
+		}
+	}
+
+	if len(legacies) > 0 {
+		logger.Info("moved sync stats to buckets", "count", len(legacies))
+	}
+
+	return nil
+}
+
+// migratePreviousSyncStatsFunc moves the synced commits and their mappings still kept in the stats of the previous
+// filters to their buckets under SYNC_STAT_BUCKET. The repo syncs without them are skipped.
+func migratePreviousSyncStatsFunc(tx *bbolt.Tx) error {
+	reposyncbucket := tx.Bucket([]byte(REPO_SYNC_BUCKET))
+	if reposyncbucket == nil {
+		return nil
+	}
+
+	// the bucket cannot be changed while iterating.
+	type legacy struct {
+		id       []byte
+		reposync *DbRepoSync
+	}
+	var legacies []legacy
+	if err := reposyncbucket.ForEach(func(k, v []byte) error {
+		reposync := &DbRepoSync{}
+		if err := proto.Unmarshal(v, reposync); err != nil {
+			return fmt.Errorf("failed to unmarshal repo sync %x: %w", k, err)
+		}
+		if slices.ContainsFunc(reposync.PreviousFilters, func(f *FilterRevision) bool { return hasStatMaps(f.SyncStat) }) {
+			legacies = append(legacies, legacy{id: append([]byte(nil), k...), reposync: reposync})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, l := range legacies {
+		for i, f := range l.reposync.PreviousFilters {
+			if !hasStatMaps(f.SyncStat) {
+				continue
+			}
+			b, err := createPreviousSyncStatBucket(tx, l.id, i)
+			if err != nil {
+				return err
+			}
+			if err := putLegacyStat(b, f.SyncStat); err != nil {
+				return fmt.Errorf("failed to move stat of previous filter %d of repo sync %x: %w", i, l.id, err)
+			}
+		}
+		if err := putRepoSyncFunc(l.id, l.reposync)(tx); err != nil {
+			return err
+		}
+	}
+
+	if len(legacies) > 0 {
+		logger.Info("moved sync stats of previous filters to buckets", "count", len(legacies))
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package svc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// hasTestSyncStat checks if the stat of the repo sync is in SYNC_STAT_BUCKET.
+func hasTestSyncStat(t *testing.T, s *Svc, idhex string) bool {
+	t.Helper()
+
+	id, err := hex.DecodeString(idhex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(SYNC_STAT_BUCKET))
+		found = b != nil && b.Bucket(id) != nil
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return found
+}
+
+func getTestSyncStatMaps(t *testing.T, s *Svc, idhex string) *syncStatMaps {
+	t.Helper()
+
+	id, err := hex.DecodeString(idhex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := getSyncStatMapsFromDb(s.db, id, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func equalSyncStatMaps(a *syncStatMaps, b *syncStatMaps) bool {
+	afrom, ato, afromtoto, atotofrom := a.dfs.DumpStat()
+	bfrom, bto, bfromtoto, btotofrom := b.dfs.DumpStat()
+	return slices.Equal(afrom, bfrom) && slices.Equal(ato, bto) && maps.Equal(afromtoto, bfromtoto) && maps.Equal(atotofrom, btotofrom)
+}
+
+func TestSvc_syncStatIncremental(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(root, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+	newLocalRepo(t, filepath.Join(root, "org", "to"), true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"local": {RemoteName: "local", RemoteUrl: root},
+		},
+	})
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second := commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	// third only changes the files outside of the filter, and is mapped to the commit of second.
+	third := commitLocalFiles(t, fromwork, map[string]string{"b/y.txt": "y2\n"}, "third")
+	pushLocal(t, fromwork)
+
+	syncresp, err := s.SyncToSubRepo(ctx, &SyncToSubRepoRequest{Id: initresp.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	incremental := getTestSyncStatMaps(t, s, initresp.Id)
+	if incremental.fromBase != 3 || incremental.toBase != 2 {
+		t.Fatalf("want 3 from commits and 2 to commits, got %d and %d", incremental.fromBase, incremental.toBase)
+	}
+	tohead := plumbing.NewHash(syncresp.NewHead)
+	if dfs := incremental.dfs; dfs.FromToTo[second] != tohead || dfs.FromToTo[third] != tohead || dfs.ToToFrom[tohead] != third {
+		t.Errorf("unexpected mappings: %v, %v", dfs.FromToTo, dfs.ToToFrom)
+	}
+
+	got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: initresp.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasStatMaps(got.SyncStat) || got.SyncStat.LastSyncFromCommit != third.String() || got.SyncStat.LastSyncToCommit != syncresp.NewHead {
+		t.Errorf("want only heads in stat, got %v", got.SyncStat)
+	}
+	got, err = s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: initresp.Id, WithStatMaps: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.SyncStat.FromDfs) != 3 || got.SyncStat.ToToFrom[syncresp.NewHead] != third.String() {
+		t.Errorf("unexpected stat with maps: %v", got.SyncStat)
+	}
+
+	// the forced sync filters the history from scratch, and replaces the stat.
+	if _, err := s.SyncToSubRepo(ctx, &SyncToSubRepoRequest{Id: initresp.Id, Force: true}); err != nil {
+		t.Fatal(err)
+	}
+	if full := getTestSyncStatMaps(t, s, initresp.Id); !equalSyncStatMaps(incremental, full) {
+		t.Errorf("incremental stat %v is different from full stat %v", incremental, full)
+	}
+
+	t.Run("move", func(t *testing.T) {
+		moved, err := s.UpdateRepoSync(ctx, &UpdateRepoSyncRequest{Id: initresp.Id, ToBranch: "v2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hasTestSyncStat(t, s, initresp.Id) {
+			t.Errorf("stat is left under old id")
+		}
+		if got := getTestSyncStatMaps(t, s, moved.RepoSync.Id); !equalSyncStatMaps(incremental, got) {
+			t.Errorf("want moved stat %v, got %v", incremental, got)
+		}
+
+		if _, err := s.DeleteRepoSync(ctx, &DeleteRepoSyncRequest{Id: moved.RepoSync.Id}); err != nil {
+			t.Fatal(err)
+		}
+		if hasTestSyncStat(t, s, moved.RepoSync.Id) {
+			t.Errorf("stat is left after delete")
+		}
+	})
+}
+
+func TestNew_migrateSyncStats(t *testing.T) {
+	ctx := context.Background()
+	dbpath := filepath.Join(t.TempDir(), "gitrim.db")
+
+	s := newTestSvc(t, &GiTrimConfig{DbPath: dbpath})
+	id := putTestRepoSync(t, s,
+		&GitRepoIdentifier{RemoteName: "github", Owner: "org", Repo: "repo"},
+		&GitRepoIdentifier{RemoteName: "gitea", Owner: "org", Repo: "sub"},
+		"a/")
+
+	hash := func(i int) string { return fmt.Sprintf("%040x", i) }
+	legacy := &SyncStat{
+		LastSyncFromCommit: hash(4),
+		FromDfs:            []string{hash(1), hash(2), hash(3), hash(4)},
+		LastSyncToCommit:   hash(12),
+		ToDfs:              []string{hash(11), hash(12)},
+		FromToTo:           map[string]string{hash(1): plumbing.ZeroHash.String(), hash(2): hash(11), hash(3): hash(12), hash(4): hash(12)},
+		ToToFrom:           map[string]string{hash(11): hash(2), hash(12): hash(4)},
+	}
+
+	rawid, err := hex.DecodeString(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reposync, _, err := getRepoSync(s.db, id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reposync.Stat = proto.Clone(legacy).(*SyncStat)
+	reposync.PreviousFilters = []*FilterRevision{{ToBranch: "old", SyncStat: proto.Clone(legacy).(*SyncStat)}}
+	if err := s.db.Update(func(tx *bbolt.Tx) error {
+		if err := putRepoSyncFunc(rawid, reposync)(tx); err != nil {
+			return err
+		}
+		// the db is from before the schema version.
+		return tx.DeleteBucket([]byte(META_BUCKET))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s = newTestSvc(t, &GiTrimConfig{DbPath: dbpath})
+
+	got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasStatMaps(got.SyncStat) || got.SyncStat.LastSyncFromCommit != hash(4) || got.SyncStat.LastSyncToCommit != hash(12) {
+		t.Errorf("stat is not compacted: %v", got.SyncStat)
+	}
+	if previous := got.PreviousFilters[0].SyncStat; hasStatMaps(previous) || previous.LastSyncToCommit != hash(12) {
+		t.Errorf("stat of previous filter is not compacted: %v", previous)
+	}
+
+	check := func(t *testing.T) {
+		t.Helper()
+
+		got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id, WithStatMaps: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(got.SyncStat, legacy) {
+			t.Errorf("want migrated stat %v, got %v", legacy, got.SyncStat)
+		}
+	}
+	check(t)
+
+	t.Run("again", func(t *testing.T) {
+		if err := s.db.Update(migrateSyncStatsFunc); err != nil {
+			t.Fatal(err)
+		}
+		check(t)
+	})
+}
+
+func TestNew_migratePreviousSyncStats(t *testing.T) {
+	ctx := context.Background()
+	dbpath := filepath.Join(t.TempDir(), "gitrim.db")
+
+	s := newTestSvc(t, &GiTrimConfig{DbPath: dbpath})
+	id := putTestRepoSync(t, s,
+		&GitRepoIdentifier{RemoteName: "github", Owner: "org", Repo: "repo"},
+		&GitRepoIdentifier{RemoteName: "gitea", Owner: "org", Repo: "sub"},
+		"a/")
+
+	hash := func(i int) string { return fmt.Sprintf("%040x", i) }
+	legacy := &SyncStat{
+		LastSyncFromCommit: hash(4),
+		FromDfs:            []string{hash(1), hash(2), hash(3), hash(4)},
+		LastSyncToCommit:   hash(12),
+		ToDfs:              []string{hash(11), hash(12)},
+		FromToTo:           map[string]string{hash(1): plumbing.ZeroHash.String(), hash(2): hash(11), hash(3): hash(12), hash(4): hash(12)},
+		ToToFrom:           map[string]string{hash(11): hash(2), hash(12): hash(4)},
+	}
+
+	rawid, err := hex.DecodeString(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reposync, _, err := getRepoSync(s.db, id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reposync.PreviousFilters = []*FilterRevision{
+		{ToBranch: "compacted", SyncStat: &SyncStat{LastSyncToCommit: hash(12)}},
+		{ToBranch: "old", SyncStat: proto.Clone(legacy).(*SyncStat)},
+	}
+	if err := s.db.Update(func(tx *bbolt.Tx) error {
+		if err := putRepoSyncFunc(rawid, reposync)(tx); err != nil {
+			return err
+		}
+		// the db is migrated by the first version.
+		return putSchemaVersionFunc(1)(tx)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s = newTestSvc(t, &GiTrimConfig{DbPath: dbpath})
+
+	got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous := got.PreviousFilters[1].SyncStat; hasStatMaps(previous) || previous.LastSyncToCommit != hash(12) {
+		t.Errorf("stat of previous filter is not compacted: %v", previous)
+	}
+
+	check := func(t *testing.T) {
+		t.Helper()
+
+		got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id, WithStatMaps: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if previous := got.PreviousFilters[0].SyncStat; hasStatMaps(previous) {
+			t.Errorf("want compacted stat of previous filter kept, got %v", previous)
+		}
+		if previous := got.PreviousFilters[1].SyncStat; !proto.Equal(previous, legacy) {
+			t.Errorf("want migrated stat of previous filter %v, got %v", legacy, previous)
+		}
+	}
+	check(t)
+
+	t.Run("again", func(t *testing.T) {
+		if err := s.db.Update(migratePreviousSyncStatsFunc); err != nil {
+			t.Fatal(err)
+		}
+		check(t)
+	})
+}
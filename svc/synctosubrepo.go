@@ -15,10 +15,27 @@ var (
 )
 
 func (s *Svc) SyncToSubRepo(ctx context.Context, request *SyncToSubRepoRequest) (*SyncToSubRepoResponse, error) {
-	ws, err := loadSyncWorkspaceFroReq(ctx, s.config.Remotes, s.db, request, true)
+	resp, err := s.syncToSubRepo(ctx, request)
+
+	outcome := syncOutcomeSynced
+	switch {
+	case err != nil:
+		outcome = syncOutcomeFailed
+	case resp.NumberOfNewCommits == 0:
+		outcome = syncOutcomeUpToDate
+	}
+	observeSync(request.Id, syncOperationToSubRepo, outcome)
+
+	return resp, err
+}
+
+func (s *Svc) syncToSubRepo(ctx context.Context, request *SyncToSubRepoRequest) (*SyncToSubRepoResponse, error) {
+	ws, err := loadSyncWorkspaceFroReq(ctx, s.config.Remotes, s.objectCache, s.db, request, true)
 	if err != nil {
 		return nil, err
 	}
+	defer ws.close()
+	s.observeSyncWorkspace(ws, AuditEvent_SYNC_TO_SUB_REPO)
 
 	originalhead := ws.db.Stat.LastSyncToCommit
 	id, err := hex.DecodeString(request.Id)
@@ -42,7 +59,7 @@ func (s *Svc) SyncToSubRepo(ctx context.Context, request *SyncToSubRepoRequest)
 
 	if !HasOverrides(request) {
 		if err := s.db.Update(func(tx *bbolt.Tx) error {
-			return putRepoSyncFunc(id, ws.db)(tx)
+			return ws.putFunc(id)(tx)
 		}); err != nil {
 			return nil, err
 		}
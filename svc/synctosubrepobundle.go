@@ -0,0 +1,207 @@
+package svc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/fardream/gitrim"
+)
+
+func (s *Svc) SyncToSubRepoBundle(req *SyncToSubRepoBundleRequest, stream GiTrim_SyncToSubRepoBundleServer) error {
+	resp, err := s.syncToSubRepoBundle(req, stream)
+
+	outcome := syncOutcomeSynced
+	switch {
+	case err != nil:
+		outcome = syncOutcomeFailed
+	case resp.NumberOfNewCommits == 0:
+		outcome = syncOutcomeUpToDate
+	}
+	observeSync(req.Id, syncOperationToSubRepoBundle, outcome)
+
+	return err
+}
+
+// syncToSubRepoBundle streams the bundle, and returns the first response with the heads and the number of new
+// commits.
+func (s *Svc) syncToSubRepoBundle(req *SyncToSubRepoBundleRequest, stream GiTrim_SyncToSubRepoBundleServer) (*SyncToSubRepoBundleResponse, error) {
+	ctx := stream.Context()
+
+	version := int(req.BundleVersion)
+	if version == 0 {
+		version = gitrim.BundleV2
+	}
+	if version != gitrim.BundleV2 && version != gitrim.BundleV3 {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported bundle version: %d", version)
+	}
+
+	// we need to lock the id if the head of the bundle will be saved.
+	if !HasOverrides(req) {
+		idwaiter, err := s.lockId(ctx, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		defer s.unlockId(req.Id, idwaiter)
+	}
+
+	sw, err := loadSyncWorkspaceFroReq(ctx, s.config.Remotes, s.objectCache, s.db, req, true)
+	if err != nil {
+		return nil, err
+	}
+	defer sw.close()
+	if sw.fromWksp.isempty {
+		return nil, ErrStatusEmptyFromRepo
+	}
+	reposync := sw.db
+
+	filtereddfs, err := sw.filterNewCommits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter commits: %w", err)
+	}
+
+	fromc, _, err := filtereddfs.LastCommits()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the last commits after filtering: %w", err)
+	}
+	newhead := filtereddfs.FromToTo[fromc.Hash]
+	if newhead.IsZero() {
+		return nil, status.Error(codes.FailedPrecondition, "head of from repo is empty after filtering")
+	}
+
+	var prerequisites []plumbing.Hash
+	numnewcommits := len(filtereddfs.ToDFS.Path)
+	if req.Incremental && reposync.LastBundleToCommit != "" {
+		lastbundle, err := gitrim.DecodeHashHex(reposync.LastBundleToCommit)
+		if err != nil {
+			return nil, err
+		}
+		if filtereddfs.ToDFS.HasCommit(lastbundle) {
+			prerequisites = append(prerequisites, lastbundle)
+			numnewcommits = countCommitsAfter(filtereddfs.ToDFS.Path, lastbundle)
+		} else {
+			logger.Warn("head of last bundle is not in the filtered history, generating full bundle", "id", req.Id, "last-bundle", lastbundle)
+		}
+	}
+
+	resp := &SyncToSubRepoBundleResponse{
+		NumberOfNewCommits: int32(numnewcommits),
+		NewHead:            newhead.String(),
+	}
+	if len(prerequisites) > 0 {
+		resp.Prerequisite = prerequisites[0].String()
+	}
+
+	if len(prerequisites) > 0 && prerequisites[0] == newhead {
+		logger.Info("no new commits for bundle", "id", req.Id)
+		resp.NumberOfNewCommits = 0
+		return resp, stream.Send(resp)
+	}
+
+	if err := stream.Send(resp); err != nil {
+		return nil, err
+	}
+	w := newChunkWriter(func(data []byte) error {
+		return stream.Send(&SyncToSubRepoBundleResponse{Bundle: data})
+	})
+	ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName(reposync.SyncData.ToBranch), newhead)
+	if err := gitrim.WriteBundle(w, sw.toWksp.storage, []*plumbing.Reference{ref}, prerequisites, version); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to send bundle: %w", err)
+	}
+
+	commitsFilteredTotal.WithLabelValues(req.Id).Add(float64(len(sw.fromNewcommits)))
+
+	if HasOverrides(req) {
+		logger.Info("not updating due to override", "id", req.Id)
+		return resp, nil
+	}
+
+	// the bundle is streamed, so the next incremental bundle starts from its head.
+	id, err := hex.DecodeString(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id %s: %s", req.Id, err.Error())
+	}
+	if err := s.db.Update(putLastBundleToCommitFunc(id, newhead)); err != nil {
+		logger.Error("failed to save head of bundle", "id", req.Id, "err", err)
+		return nil, ErrStatusDBFailure
+	}
+	if err := s.db.Sync(); err != nil {
+		return nil, ErrStatusDBFailure
+	}
+
+	return resp, nil
+}
+
+// filterNewCommits filters the commits of the from repo after the last sync on top of the synced commits, like
+// syncToTo but without pushing the to repo, so the stat is not changed. The history is filtered from scratch if the
+// synced commits cannot be reused.
+func (sw *syncWorkspace) filterNewCommits(ctx context.Context) (*gitrim.FilteredDFS, error) {
+	if sw.toStatus != LastSyncCommitStatus_INSYNC || (sw.fromStatus != LastSyncCommitStatus_INSYNC && sw.fromStatus != LastSyncCommitStatus_ADVANCED) {
+		logger.Info("reset stat", "from-status", sw.fromStatus, "to-status", sw.toStatus)
+		sw.resetStat()
+		sw.fromNewcommits = nil
+	}
+
+	filtereddfs, err := sw.getFilteredDFS()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sw.fromNewcommits) == 0 {
+		fromhead, _, err := sw.db.Stat.Heads()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get from head from stat: %w", err)
+		}
+		frompast, _ := sw.stat.pastCommits()
+		sw.fromNewcommits, err = sw.fromWksp.getNewCommits(ctx, fromhead, gitrim.CombineHashSets(frompast, sw.roots), false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain new commits for from repo: %w", err)
+		}
+	}
+
+	if _, err := filtereddfs.AppendCommits(ctx, sw.fromNewcommits); err != nil {
+		return nil, err
+	}
+
+	return filtereddfs, nil
+}
+
+// putLastBundleToCommitFunc saves the head of the last bundle of the repo sync, the rest of the repo sync is read in
+// the transaction, so the changes made while the bundle is streamed are kept.
+func putLastBundleToCommitFunc(id []byte, head plumbing.Hash) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		var data []byte
+		if b := tx.Bucket([]byte(REPO_SYNC_BUCKET)); b != nil {
+			data = b.Get(id)
+		}
+		if data == nil {
+			return ErrStatusNotFound
+		}
+		reposync := &DbRepoSync{}
+		if err := proto.Unmarshal(data, reposync); err != nil {
+			return err
+		}
+		reposync.LastBundleToCommit = head.String()
+
+		return putRepoSyncFunc(id, reposync)(tx)
+	}
+}
+
+// countCommitsAfter counts the commits after h in the dfs path.
+func countCommitsAfter(path []*gitrim.LazyCommit, h plumbing.Hash) int {
+	for i, c := range path {
+		if c.Hash == h {
+			return len(path) - i - 1
+		}
+	}
+	return len(path)
+}
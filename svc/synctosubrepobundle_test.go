@@ -0,0 +1,213 @@
+package svc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"google.golang.org/protobuf/proto"
+)
+
+// recvTestBundle receives the first response and the bundle from the stream.
+func recvTestBundle(t *testing.T, stream GiTrim_SyncToSubRepoBundleClient) (*SyncToSubRepoBundleResponse, []byte) {
+	t.Helper()
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bundle []byte
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return resp, bundle
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundle = append(bundle, chunk.Bundle...)
+	}
+}
+
+// readTestBundle reads the header lines of the bundle, and loads the packfile into a new storage.
+func readTestBundle(t *testing.T, data []byte) ([]string, *memory.Storage) {
+	t.Helper()
+
+	r := bufio.NewReader(bytes.NewReader(data))
+	var header []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read bundle header: %s", err)
+		}
+		if line == "\n" {
+			break
+		}
+		header = append(header, strings.TrimSpace(line))
+	}
+
+	s := memory.NewStorage()
+	if err := packfile.UpdateObjectStorage(s, r); err != nil {
+		t.Fatalf("failed to read packfile: %s", err)
+	}
+
+	return header, s
+}
+
+func TestSvc_SyncToSubRepoBundle(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(root, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+	newLocalRepo(t, filepath.Join(root, "org", "to"), true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"local": {RemoteName: "local", RemoteUrl: root},
+		},
+	})
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := initresp.Id
+	initial, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tohead := initial.SyncStat.LastSyncToCommit
+
+	conn, _, _ := serveTestSvc(t, s)
+	client := NewGiTrimClient(conn)
+	bundle := func(t *testing.T, incremental bool) (*SyncToSubRepoBundleResponse, []byte) {
+		t.Helper()
+
+		stream, err := client.SyncToSubRepoBundle(ctx, &SyncToSubRepoBundleRequest{Id: id, Incremental: incremental})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return recvTestBundle(t, stream)
+	}
+	// checkSaved checks the head of the last bundle is saved, and the stat is not changed by the bundles.
+	checkSaved := func(t *testing.T, lastbundle string) {
+		t.Helper()
+
+		got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.LastBundleToCommit != lastbundle {
+			t.Errorf("want head of last bundle %s, got %s", lastbundle, got.LastBundleToCommit)
+		}
+		if !proto.Equal(got.SyncStat, initial.SyncStat) {
+			t.Errorf("want stat %v unchanged, got %v", initial.SyncStat, got.SyncStat)
+		}
+	}
+
+	t.Run("full", func(t *testing.T) {
+		resp, data := bundle(t, true)
+		if resp.Prerequisite != "" || resp.NewHead != tohead || resp.NumberOfNewCommits != 1 || len(resp.Bundle) != 0 {
+			t.Errorf("want a full bundle of %s, got %v", tohead, resp)
+		}
+		header, storage := readTestBundle(t, data)
+		if len(header) != 2 || header[1] != tohead+" refs/heads/main" {
+			t.Errorf("unexpected header %q", header)
+		}
+		if _, err := object.GetCommit(storage, plumbing.NewHash(tohead)); err != nil {
+			t.Errorf("head is not in the bundle: %v", err)
+		}
+		checkSaved(t, tohead)
+	})
+
+	t.Run("no new commits", func(t *testing.T) {
+		resp, data := bundle(t, true)
+		if resp.Prerequisite != tohead || resp.NewHead != tohead || resp.NumberOfNewCommits != 0 || len(data) != 0 {
+			t.Errorf("want no new commits, got %v with %d bytes", resp, len(data))
+		}
+		checkSaved(t, tohead)
+	})
+
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	pushLocal(t, fromwork)
+
+	var newhead string
+	t.Run("incremental", func(t *testing.T) {
+		filtered := getTestCounter(t, commitsFilteredTotal.WithLabelValues(id))
+		resp, data := bundle(t, true)
+		if got := getTestCounter(t, commitsFilteredTotal.WithLabelValues(id)); got <= filtered {
+			t.Errorf("want new commits filtered, got %v after %v", got, filtered)
+		}
+		if resp.Prerequisite != tohead || resp.NumberOfNewCommits != 1 || resp.NewHead == tohead {
+			t.Fatalf("want one commit after %s, got %v", tohead, resp)
+		}
+		newhead = resp.NewHead
+		header, storage := readTestBundle(t, data)
+		if len(header) != 3 || !strings.HasPrefix(header[1], "-"+tohead) || header[2] != newhead+" refs/heads/main" {
+			t.Errorf("unexpected header %q", header)
+		}
+		if _, err := object.GetCommit(storage, plumbing.NewHash(newhead)); err != nil {
+			t.Errorf("new head is not in the bundle: %v", err)
+		}
+		if _, err := object.GetCommit(storage, plumbing.NewHash(tohead)); err == nil {
+			t.Error("prerequisite is in the bundle")
+		}
+		checkSaved(t, newhead)
+	})
+
+	t.Run("sync after bundles", func(t *testing.T) {
+		resp, err := s.SyncToSubRepo(ctx, &SyncToSubRepoRequest{Id: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the filtering is deterministic, so the pushed commit is the one in the bundle.
+		if resp.NumberOfNewCommits != 1 || resp.OriginalHead != tohead || resp.NewHead != newhead {
+			t.Errorf("want %s pushed on top of %s, got %v", newhead, tohead, resp)
+		}
+	})
+	t.Run("diverged sub repo", func(t *testing.T) {
+		towork, err := git.PlainClone(filepath.Join(t.TempDir(), "towork"), false, &git.CloneOptions{URL: filepath.Join(root, "org", "to")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitLocalFiles(t, towork, map[string]string{"a/z.txt": "z\n"}, "not synced")
+		pushLocal(t, towork)
+
+		// the synced commits cannot be reused, and the history is filtered again.
+		resp, data := bundle(t, false)
+		if resp.Prerequisite != "" || resp.NewHead != newhead || resp.NumberOfNewCommits != 2 {
+			t.Errorf("want a full bundle of %s, got %v", newhead, resp)
+		}
+		if _, storage := readTestBundle(t, data); !hasTestCommit(storage, tohead) || !hasTestCommit(storage, newhead) {
+			t.Error("filtered commits are not in the bundle")
+		}
+	})
+
+	for outcome, want := range map[string]float64{syncOutcomeSynced: 3, syncOutcomeUpToDate: 1} {
+		if got := getTestCounter(t, syncsTotal.WithLabelValues(id, syncOperationToSubRepoBundle, outcome)); got != want {
+			t.Errorf("want %v bundles %s, got %v", want, outcome, got)
+		}
+	}
+}
+
+func hasTestCommit(s *memory.Storage, h string) bool {
+	_, err := object.GetCommit(s, plumbing.NewHash(h))
+	return err == nil
+}
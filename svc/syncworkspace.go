@@ -8,7 +8,6 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"go.etcd.io/bbolt"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/fardream/gitrim"
@@ -16,6 +15,10 @@ import (
 
 type syncWorkspace struct {
 	db *DbRepoSync
+	// stat has the synced commits and their mappings, the heads are in db.
+	stat *syncStatMaps
+	// statUpdate is the change of stat after a sync, nil if nothing is synced.
+	statUpdate *syncStatUpdate
 
 	filter gitrim.Filter
 	roots  gitrim.HashSet
@@ -27,18 +30,51 @@ type syncWorkspace struct {
 	toWksp       *workspace
 	toNewcommits []*object.Commit
 	toStatus     LastSyncCommitStatus_Enum
+
+	// events is nil if the events are not published.
+	events *eventBus
+	// audit is nil if the pushes are not recorded, operation is recorded in the audit events.
+	audit     *auditLog
+	operation AuditEvent_Operation
+}
+
+// publish sets the id of the repo sync on the events, and publishes them.
+func (sw *syncWorkspace) publish(events ...*SyncEvent) {
+	for _, e := range events {
+		e.RepoSyncId = sw.db.SyncData.Id
+	}
+	sw.events.publish(events...)
+}
+
+// pushedEvent returns the event for the push of the branch of the workspace from oldhead.
+func pushedEvent(repo *GitRepoIdentifier, wksp *workspace, oldhead *object.Commit, force bool) *SyncEvent {
+	e := &SyncEvent{
+		Type:    SyncEvent_PUSHED,
+		Repo:    repo,
+		Branch:  wksp.branch,
+		NewHead: getHashStringPossibleNil(wksp.branchhead),
+		Force:   force,
+	}
+	if oldhead != nil {
+		e.OldHead = oldhead.Hash.String()
+	}
+
+	return e
 }
 
-func loadSyncWorkspaceFromDb(ctx context.Context, remoeConfig map[string]*RemoteConfig, idhex string, db *bbolt.DB, requireexist bool) (*syncWorkspace, error) {
-	reposync, _, err := getRepoSync(db, idhex, requireexist)
+func loadSyncWorkspaceFromDb(ctx context.Context, remoeConfig map[string]*RemoteConfig, objcache *objectCache, idhex string, db *bbolt.DB, requireexist bool) (*syncWorkspace, error) {
+	reposync, id, err := getRepoSync(db, idhex, requireexist)
 	if err != nil {
 		return nil, err
 	}
 
-	return newSyncWorkspace(ctx, remoeConfig, reposync)
+	return newSyncWorkspace(ctx, remoeConfig, objcache, reposync, db, id)
 }
 
-func newSyncWorkspace(ctx context.Context, remoteConfig map[string]*RemoteConfig, reposync *DbRepoSync) (*syncWorkspace, error) {
+// newSyncWorkspace creates the workspaces of the from and to repos, and checks their status against the stat.
+// The synced commits are loaded from statdb under id, and the sync starts from scratch if statdb is nil. The returned
+// workspace must be closed.
+func newSyncWorkspace(ctx context.Context, remoteConfig map[string]*RemoteConfig, objcache *objectCache, reposync *DbRepoSync, statdb *bbolt.DB, id []byte) (*syncWorkspace, error) {
 	filter, err := gitrim.NewOrFilterForPatterns(reposync.SyncData.Filter.CanonicalFilters...)
 	if err != nil {
 		return nil, err
@@ -49,45 +85,74 @@ func newSyncWorkspace(ctx context.Context, remoteConfig map[string]*RemoteConfig
 		return nil, err
 	}
 
-	fromwksp, err := newWorkspace(ctx, remoteConfig, reposync.SyncData.FromRepo, reposync.SyncData.FromBranch)
+	fromwksp, err := newWorkspace(ctx, remoteConfig, objcache, reposync.SyncData.FromRepo, reposync.SyncData.FromBranch)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to obtain from repo: %s", err.Error())
+		return nil, status.Errorf(statusCodeForRemoteError(err), "failed to obtain from repo: %s", err.Error())
 	}
-	towksp, err := newWorkspace(ctx, remoteConfig, reposync.SyncData.ToRepo, reposync.SyncData.ToBranch)
+	towksp, err := newWorkspace(ctx, remoteConfig, objcache, reposync.SyncData.ToRepo, reposync.SyncData.ToBranch)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to obtain to repo: %s", err.Error())
+		fromwksp.close()
+		return nil, status.Errorf(statusCodeForRemoteError(err), "failed to obtain to repo: %s", err.Error())
+	}
+
+	sw := &syncWorkspace{
+		db: reposync,
+
+		filter: filter,
+		roots:  roots,
+
+		fromWksp: fromwksp,
+		toWksp:   towksp,
 	}
+
+	if statdb == nil {
+		sw.stat = newSyncStatMaps(fromwksp.storage, towksp.storage, filter)
+		sw.stat.replace = true
+	} else {
+		sw.stat, err = getSyncStatMapsFromDb(statdb, id, fromwksp.storage, towksp.storage, filter)
+		if err != nil {
+			sw.close()
+			return nil, err
+		}
+	}
+
+	if err := sw.checkStatus(ctx); err != nil {
+		sw.close()
+		return nil, err
+	}
+
+	return sw, nil
+}
+
+// close releases the cached repos used by the workspaces, and adds the stats of the filter cache to the metrics.
+func (sw *syncWorkspace) close() {
+	observeFilterCache(sw.filter)
+	sw.fromWksp.close()
+	sw.toWksp.close()
+}
+
+// checkStatus checks the status of the from and to repos against the stat, and collects the new commits.
+func (sw *syncWorkspace) checkStatus(ctx context.Context) error {
+	reposync := sw.db
 	if reposync.Stat == nil {
 		reposync.Stat = EmptySyncStat()
 	}
 
-	fromhead, frompast, tohead, topast, err := reposync.Stat.Hashes()
+	fromhead, tohead, err := reposync.Stat.Heads()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	fromstatus, fromcommits, err := getLastSyncCommitStatus(ctx, fromhead, gitrim.CombineHashSets(roots, frompast), false, fromwksp)
+	frompast, topast := sw.stat.pastCommits()
+	sw.fromStatus, sw.fromNewcommits, err = getLastSyncCommitStatus(ctx, fromhead, gitrim.CombineHashSets(sw.roots, frompast), false, sw.fromWksp)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	tostatus, tocommits, err := getLastSyncCommitStatus(ctx, tohead, topast, true, towksp)
+	sw.toStatus, sw.toNewcommits, err = getLastSyncCommitStatus(ctx, tohead, topast, true, sw.toWksp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &syncWorkspace{
-		db: reposync,
-
-		filter: filter,
-		roots:  roots,
-
-		fromWksp:       fromwksp,
-		fromNewcommits: fromcommits,
-		fromStatus:     fromstatus,
-
-		toWksp:       towksp,
-		toNewcommits: tocommits,
-		toStatus:     tostatus,
-	}, nil
+	return nil
 }
 
 var ErrZeroRoots = errors.New("zero roots found for DFS path")
@@ -136,11 +201,12 @@ func getLastSyncCommitStatus(
 func loadSyncWorkspaceFroReq(
 	ctx context.Context,
 	remoteConfig map[string]*RemoteConfig,
+	objcache *objectCache,
 	db *bbolt.DB,
 	req RequestWithPossibleOverride,
 	mustExist bool,
 ) (*syncWorkspace, error) {
-	reposync, _, err := getRepoSync(db, req.GetId(), true)
+	reposync, id, err := getRepoSync(db, req.GetId(), true)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +218,7 @@ func loadSyncWorkspaceFroReq(
 		reposync.SyncData.FromBranch = req.GetOverrideFromBranch()
 	}
 
-	return newSyncWorkspace(ctx, remoteConfig, reposync)
+	return newSyncWorkspace(ctx, remoteConfig, objcache, reposync, db, id)
 }
 
 var ErrToNotInSync = errors.New("to branch not in sync")
@@ -164,9 +230,54 @@ func getHashStringPossibleNil(c *object.Commit) string {
 	return c.Hash.String()
 }
 
+// getFilteredDFS returns the [gitrim.FilteredDFS] with the synced commits, the commits added to it are saved by
+// updateStat.
 func (sw *syncWorkspace) getFilteredDFS() (*gitrim.FilteredDFS, error) {
-	stat := sw.db.Stat
-	return gitrim.NewFilteredDFSWithStat(stat.FromDfs, stat.ToDfs, stat.FromToTo, stat.ToToFrom, sw.fromWksp.storage, sw.toWksp.storage, sw.filter)
+	return sw.stat.dfs, nil
+}
+
+// resetStat drops the stat, so the history is filtered again from scratch.
+func (sw *syncWorkspace) resetStat() {
+	sw.db.Stat.SetToEmpty()
+	sw.stat = newSyncStatMaps(sw.fromWksp.storage, sw.toWksp.storage, sw.filter)
+	sw.stat.replace = true
+}
+
+// updateStat sets the heads of the stat to the last commits, and records the commits added to dfs since it is created
+// from the stat.
+func (sw *syncWorkspace) updateStat(dfs *gitrim.FilteredDFS, fromc *object.Commit, toc *object.Commit) {
+	sw.db.Stat.LastSyncFromCommit = fromc.Hash.String()
+	sw.db.Stat.LastSyncToCommit = toc.Hash.String()
+	sw.statUpdate = &syncStatUpdate{
+		dfs:      dfs,
+		fromBase: sw.stat.fromBase,
+		toBase:   sw.stat.toBase,
+		replace:  sw.stat.replace,
+	}
+}
+
+// putStatFunc saves the change of the synced commits and their mappings under id.
+func (sw *syncWorkspace) putStatFunc(id []byte) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		switch {
+		case sw.statUpdate != nil:
+			return sw.statUpdate.putFunc(id)(tx)
+		case sw.stat.replace:
+			return clearSyncStatFunc(id)(tx)
+		default:
+			return nil
+		}
+	}
+}
+
+// putFunc saves the repo sync and the change of its stat under id.
+func (sw *syncWorkspace) putFunc(id []byte) func(tx *bbolt.Tx) error {
+	return func(tx *bbolt.Tx) error {
+		if err := sw.putStatFunc(id)(tx); err != nil {
+			return err
+		}
+		return putRepoSyncFunc(id, sw.db)(tx)
+	}
 }
 
 func (sw *syncWorkspace) syncToTo(ctx context.Context, force bool) ([]*object.Commit, error) {
@@ -183,11 +294,9 @@ func (sw *syncWorkspace) syncToTo(ctx context.Context, force bool) ([]*object.Co
 		logger.Info("already in sync, force update", "from", sw.fromWksp.branch, "to", sw.toWksp.branch)
 	}
 
-	stat := sw.db.Stat
-
 	if sw.toStatus != LastSyncCommitStatus_INSYNC || sw.fromStatus != LastSyncCommitStatus_ADVANCED {
 		logger.Info("reset stat", "from-status", sw.fromStatus, "to-status", sw.toStatus)
-		stat.SetToEmpty()
+		sw.resetStat()
 	}
 
 	filtereddfs, err := sw.getFilteredDFS()
@@ -195,10 +304,11 @@ func (sw *syncWorkspace) syncToTo(ctx context.Context, force bool) ([]*object.Co
 		return nil, fmt.Errorf("failed to get local status for commits: %w", err)
 	}
 
-	fromhead, fromcommits, _, _, err := stat.Hashes()
+	fromhead, _, err := sw.db.Stat.Heads()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get from head and from commits from stat: %w", err)
+		return nil, fmt.Errorf("failed to get from head from stat: %w", err)
 	}
+	fromcommits, _ := sw.stat.pastCommits()
 	if len(sw.fromNewcommits) == 0 {
 		if sw.toStatus == LastSyncCommitStatus_INSYNC {
 			logger.Info("from-in-sync-add-fromcommits")
@@ -233,6 +343,7 @@ func (sw *syncWorkspace) syncToTo(ctx context.Context, force bool) ([]*object.Co
 
 	sw.toWksp.isempty = false
 
+	oldhead := sw.toWksp.branchhead
 	err = sw.toWksp.updateBranchHead(toc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update branch head after filtering: %w", err)
@@ -242,9 +353,14 @@ func (sw *syncWorkspace) syncToTo(ctx context.Context, force bool) ([]*object.Co
 		return nil, fmt.Errorf("failed to push: %w", err)
 	}
 
-	stat.FromDfs, stat.ToDfs, stat.FromToTo, stat.ToToFrom = filtereddfs.DumpStat()
-	stat.LastSyncFromCommit = fromc.Hash.String()
-	stat.LastSyncToCommit = toc.Hash.String()
+	commitsFilteredTotal.WithLabelValues(sw.db.SyncData.Id).Add(float64(len(sw.fromNewcommits)))
+
+	filtered := &SyncEvent{Type: SyncEvent_COMMITS_FILTERED}
+	filtered.FromCommits, filtered.NumberOfFromCommits = commitHashes(sw.fromNewcommits)
+	filtered.ToCommits, filtered.NumberOfToCommits = commitHashes(newcommits)
+	sw.publish(filtered, sw.pushed(ctx, sw.db.SyncData.ToRepo, sw.toWksp, oldhead, newcommits, force))
+
+	sw.updateStat(filtereddfs, fromc, toc)
 
 	return newcommits, nil
 }
@@ -267,14 +383,20 @@ var (
 	ErrSubCommitCannotHavePGPSignature = errors.New("sub commit cannot have PGP signature")
 )
 
-func (sw *syncWorkspace) checkCommits(ctx context.Context) ([]*gitrim.FilePatchCheckResult, bool, error) {
+// checkCommits checks the new commits of the to repo against the filter, and publishes an event if the commits
+// are rejected, either for the files outside of the filter, or for the pgp signatures unless allowpgp is set.
+func (sw *syncWorkspace) checkCommits(ctx context.Context, allowpgp bool) ([]*gitrim.FilePatchCheckResult, bool, error) {
 	status := checkSubHistoryForSyncToFrom(sw.fromStatus, sw.toStatus)
 	if status != SubRepoCommitsCheck_CHECK_PASSED {
 		return nil, false, fmt.Errorf("repos are not in good status to sync: from repo status %s, to repo status %s", sw.fromStatus.String(), sw.toStatus.String())
 	}
 
 	if len(sw.toNewcommits) == 0 {
-		_, _, toheadhash, topastcommits, err := sw.db.Stat.Hashes()
+		_, toheadhash, err := sw.db.Stat.Heads()
+		if err != nil {
+			return nil, false, err
+		}
+		_, topastcommits := sw.stat.pastCommits()
 		tonew, err := sw.toWksp.getNewCommits(ctx, toheadhash, topastcommits, true)
 		if err != nil {
 			return nil, false, fmt.Errorf("failed to get new commits for to repo: %w", err)
@@ -304,6 +426,16 @@ func (sw *syncWorkspace) checkCommits(ctx context.Context) ([]*gitrim.FilePatchC
 		return nil, false, err
 	}
 
+	if rejectedfiles := getRejectedFiles(checkresults); len(rejectedfiles) > 0 || (hasgpg && !allowpgp) {
+		rejected := &SyncEvent{
+			Type:             SyncEvent_CONTRIBUTION_REJECTED,
+			RejectedFiles:    rejectedfiles,
+			HasGpgSignatures: hasgpg,
+		}
+		rejected.RejectedCommits, _ = commitHashes(sw.toNewcommits)
+		sw.publish(rejected)
+	}
+
 	return checkresults, hasgpg, nil
 }
 
@@ -314,7 +446,11 @@ func (sw *syncWorkspace) syncToFrom(ctx context.Context, dopush bool, allowpgp b
 	}
 
 	if len(sw.toNewcommits) == 0 {
-		_, _, toheadhash, topastcommits, err := sw.db.Stat.Hashes()
+		_, toheadhash, err := sw.db.Stat.Heads()
+		if err != nil {
+			return nil, err
+		}
+		_, topastcommits := sw.stat.pastCommits()
 		tonew, err := sw.toWksp.getNewCommits(ctx, toheadhash, topastcommits, true)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get new commits for to repo: %w", err)
@@ -333,8 +469,6 @@ func (sw *syncWorkspace) syncToFrom(ctx context.Context, dopush bool, allowpgp b
 			}
 		}
 	}
-	stat := sw.db.Stat
-
 	filtereddfs, err := sw.getFilteredDFS()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get local status for commits: %w", err)
@@ -350,6 +484,7 @@ func (sw *syncWorkspace) syncToFrom(ctx context.Context, dopush bool, allowpgp b
 		return nil, fmt.Errorf("failed to get the last commits after filtering: %w", err)
 	}
 
+	oldhead := sw.fromWksp.branchhead
 	err = sw.fromWksp.updateBranchHead(fromc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update branch head after expanding: %w", err)
@@ -360,11 +495,13 @@ func (sw *syncWorkspace) syncToFrom(ctx context.Context, dopush bool, allowpgp b
 			return nil, fmt.Errorf("failed to update from repo: %w", err)
 		}
 
+		expanded := &SyncEvent{Type: SyncEvent_COMMITS_EXPANDED}
+		expanded.FromCommits, expanded.NumberOfFromCommits = commitHashes(newcommits)
+		expanded.ToCommits, expanded.NumberOfToCommits = commitHashes(sw.toNewcommits)
+		sw.publish(expanded, sw.pushed(ctx, sw.db.SyncData.FromRepo, sw.fromWksp, oldhead, newcommits, false))
 	}
 
-	stat.FromDfs, stat.ToDfs, stat.FromToTo, stat.ToToFrom = filtereddfs.DumpStat()
-	stat.LastSyncFromCommit = fromc.Hash.String()
-	stat.LastSyncToCommit = toc.Hash.String()
+	sw.updateStat(filtereddfs, fromc, toc)
 
 	return newcommits, nil
 }
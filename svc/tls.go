@@ -0,0 +1,184 @@
+package svc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrInvalidTlsConfig = errors.New("invalid tls config")
+
+// tlsVersions are the allowed values of min_version.
+var tlsVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// fileStamp identifies the version of a file by its modification time and size.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// certReloader keeps the certificate and the client CAs of a listener, and reloads them when the files change.
+// The files are checked on each handshake, which is rare since the connections are long lived.
+type certReloader struct {
+	config     *TlsConfig
+	minVersion uint16
+	nextProtos []string
+
+	mu        sync.Mutex
+	stamps    [3]fileStamp
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+func newCertReloader(config *TlsConfig, nextProtos []string) (*certReloader, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, fmt.Errorf("%w: cert_file and key_file are required", ErrInvalidTlsConfig)
+	}
+	if config.RequireClientCert && config.ClientCaFile == "" {
+		return nil, fmt.Errorf("%w: require_client_cert needs client_ca_file", ErrInvalidTlsConfig)
+	}
+	minversion, ok := tlsVersions[config.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported min_version %q", ErrInvalidTlsConfig, config.MinVersion)
+	}
+
+	r := &certReloader{config: config, minVersion: minversion, nextProtos: nextProtos}
+	// the files must be valid at start up, later failures keep the loaded ones.
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *certReloader) files() [3]string {
+	return [3]string{r.config.CertFile, r.config.KeyFile, r.config.ClientCaFile}
+}
+
+// reload loads the files if any of them changed since the last load.
+func (r *certReloader) reload() error {
+	var stamps [3]fileStamp
+	for i, path := range r.files() {
+		if path == "" {
+			continue
+		}
+		stamp, err := statFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		stamps[i] = stamp
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cert != nil && stamps == r.stamps {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate %s: %w", r.config.CertFile, err)
+	}
+
+	var clientcas *x509.CertPool
+	if r.config.ClientCaFile != "" {
+		pem, err := os.ReadFile(r.config.ClientCaFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client ca %s: %w", r.config.ClientCaFile, err)
+		}
+		clientcas = x509.NewCertPool()
+		if !clientcas.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("%w: no certificates in client ca %s", ErrInvalidTlsConfig, r.config.ClientCaFile)
+		}
+	}
+
+	if r.cert != nil {
+		logger.Info("reloaded tls certificate", "cert-file", r.config.CertFile)
+	}
+	r.stamps = stamps
+	r.cert = &cert
+	r.clientCAs = clientcas
+
+	return nil
+}
+
+// getConfigForClient returns the config with the latest certificate for a handshake.
+func (r *certReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	if err := r.reload(); err != nil {
+		// the files may be in the middle of an update, try again on the next handshake.
+		logger.Warn("failed to reload tls certificate, keep the loaded one", "cert-file", r.config.CertFile, "err", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{*r.cert},
+		MinVersion:   r.minVersion,
+		NextProtos:   r.nextProtos,
+	}
+	if r.clientCAs != nil {
+		config.ClientCAs = r.clientCAs
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if r.config.RequireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	return config, nil
+}
+
+// tlsConfig returns the server config of the listener.
+func (r *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         r.minVersion,
+		NextProtos:         r.nextProtos,
+		GetConfigForClient: r.getConfigForClient,
+	}
+}
+
+// newServerTLSConfig creates the server config for the tls config of a listener, nil if config is nil.
+func newServerTLSConfig(config *TlsConfig, nextProtos []string) (*tls.Config, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	r, err := newCertReloader(config, nextProtos)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.tlsConfig(), nil
+}
+
+// setupTLS loads the certificates of the gRPC and the webhook listeners.
+func (s *Svc) setupTLS() error {
+	var err error
+
+	if s.adminTLS, err = newServerTLSConfig(s.config.AdminTls, []string{"h2"}); err != nil {
+		return fmt.Errorf("admin_tls: %w", err)
+	}
+	if s.webhookTLS, err = newServerTLSConfig(s.config.WebhookTls, []string{"http/1.1"}); err != nil {
+		return fmt.Errorf("webhook_tls: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package svc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// writeTestCert writes the certificate and the key of c as PEM files in dir, and returns their paths. The modification
+// time is set to modtime so the rewritten files are always seen as changed.
+func writeTestCert(t *testing.T, dir string, name string, c *testCert, modtime time.Time) (string, string) {
+	t.Helper()
+
+	keyder, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certfile := filepath.Join(dir, name+".crt")
+	keyfile := filepath.Join(dir, name+".key")
+	for path, block := range map[string]*pem.Block{
+		certfile: {Type: "CERTIFICATE", Bytes: c.cert.Raw},
+		keyfile:  {Type: "EC PRIVATE KEY", Bytes: keyder},
+	} {
+		if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modtime, modtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return certfile, keyfile
+}
+
+func newTestCertPool(certs ...*testCert) *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, c := range certs {
+		pool.AddCert(c.cert)
+	}
+
+	return pool
+}
+
+func TestSvc_AdminTls(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	ca := newTestCA(t)
+	certfile, keyfile := writeTestCert(t, dir, "server", newTestServerCert(t, ca, "gitrim.test"), time.Now().Add(-time.Hour))
+	cafile, _ := writeTestCert(t, dir, "ca", ca, time.Now().Add(-time.Hour))
+
+	s := newTestSvc(t, &GiTrimConfig{
+		AdminTls: &TlsConfig{CertFile: certfile, KeyFile: keyfile, ClientCaFile: cafile, RequireClientCert: true},
+	})
+
+	lis := bufconn.Listen(1 << 20)
+	servectx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+	go s.ServeListener(servectx, lis)
+
+	check := func(t *testing.T, roots *x509.CertPool, cert *testCert) error {
+		t.Helper()
+
+		clienttls := &tls.Config{RootCAs: roots, ServerName: "gitrim.test"}
+		if cert != nil {
+			clienttls.Certificates = []tls.Certificate{cert.tlsCertificate()}
+		}
+		conn, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(credentials.NewTLS(clienttls)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		checkctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_, err = healthpb.NewHealthClient(conn).Check(checkctx, &healthpb.HealthCheckRequest{})
+
+		return err
+	}
+
+	clientcert := newTestClientCert(t, ca, "ci.gitrim.test")
+	if err := check(t, newTestCertPool(ca), clientcert); err != nil {
+		t.Fatalf("check with client certificate: %v", err)
+	}
+	if err := check(t, newTestCertPool(ca), nil); err == nil {
+		t.Errorf("want failure without client certificate")
+	}
+
+	newca := newTestCA(t)
+	t.Run("reload", func(t *testing.T) {
+		writeTestCert(t, dir, "server", newTestServerCert(t, newca, "gitrim.test"), time.Now())
+
+		if err := check(t, newTestCertPool(newca), clientcert); err != nil {
+			t.Errorf("check with renewed certificate: %v", err)
+		}
+		if err := check(t, newTestCertPool(ca), clientcert); err == nil {
+			t.Errorf("want failure with the replaced certificate")
+		}
+	})
+
+	t.Run("keep loaded on bad files", func(t *testing.T) {
+		if err := os.WriteFile(certfile, []byte("garbage"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(certfile, time.Now().Add(time.Hour), time.Now().Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := check(t, newTestCertPool(newca), clientcert); err != nil {
+			t.Errorf("check with the loaded certificate: %v", err)
+		}
+	})
+}
+
+func TestSvc_WebhookTls(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newTestCA(t)
+	certfile, keyfile := writeTestCert(t, dir, "server", newTestServerCert(t, ca, "gitrim.test"), time.Now())
+
+	s := newTestSvc(t, &GiTrimConfig{
+		WebhookTls: &TlsConfig{CertFile: certfile, KeyFile: keyfile, MinVersion: "1.3"},
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go s.ServeWebhookListener(ctx, lis)
+
+	get := func(clienttls *tls.Config) (*http.Response, error) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: clienttls}, Timeout: 5 * time.Second}
+		t.Cleanup(client.CloseIdleConnections)
+		return client.Get("https://" + lis.Addr().String() + "/webhook/unknown")
+	}
+
+	resp, err := get(&tls.Config{RootCAs: newTestCertPool(ca), ServerName: "gitrim.test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.TLS == nil || resp.TLS.Version != tls.VersionTLS13 {
+		t.Errorf("want tls 1.3, got %v", resp.TLS)
+	}
+
+	if _, err := get(&tls.Config{RootCAs: newTestCertPool(ca), ServerName: "gitrim.test", MaxVersion: tls.VersionTLS12}); err == nil {
+		t.Errorf("want failure with tls 1.2")
+	}
+}
+
+func TestNew_InvalidTls(t *testing.T) {
+	dir := t.TempDir()
+	certfile, keyfile := writeTestCert(t, dir, "server", newTestServerCert(t, newTestCA(t), "gitrim.test"), time.Now())
+
+	for name, cfg := range map[string]*GiTrimConfig{
+		"missing key":       {AdminTls: &TlsConfig{CertFile: certfile}},
+		"missing file":      {WebhookTls: &TlsConfig{CertFile: certfile, KeyFile: filepath.Join(dir, "missing.key")}},
+		"bad min version":   {AdminTls: &TlsConfig{CertFile: certfile, KeyFile: keyfile, MinVersion: "1.0"}},
+		"require no ca":     {AdminTls: &TlsConfig{CertFile: certfile, KeyFile: keyfile, RequireClientCert: true}},
+		"ca without certs":  {AdminTls: &TlsConfig{CertFile: certfile, KeyFile: keyfile, ClientCaFile: keyfile}},
+		"swapped cert, key": {AdminTls: &TlsConfig{CertFile: keyfile, KeyFile: certfile}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg.DbPath = filepath.Join(t.TempDir(), "gitrim.db")
+			if _, err := New(cfg); err == nil {
+				t.Errorf("want error for %v", cfg)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"slices"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// updateGitRepoIdentifier returns a copy of repo with the non-empty fields replaced by the ones in update.
+func updateGitRepoIdentifier(repo *GitRepoIdentifier, update *GitRepoIdentifier) *GitRepoIdentifier {
+	r := proto.Clone(repo).(*GitRepoIdentifier)
+	if update.GetRemoteName() != "" {
+		r.RemoteName = update.RemoteName
+	}
+	if update.GetOwner() != "" {
+		r.Owner = update.Owner
+	}
+	if update.GetRepo() != "" {
+		r.Repo = update.Repo
+	}
+
+	return r
+}
+
+// lockIds locks the distinct ids in order, so concurrent operations locking the same ids don't dead lock.
+// The returned function unlocks the ids.
+func (s *Svc) lockIds(ctx context.Context, ids ...string) (func(), error) {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	var unlocks []func()
+	unlock := func() {
+		for _, f := range slices.Backward(unlocks) {
+			f()
+		}
+	}
+	for _, id := range ids {
+		idwaiter, err := s.lockId(ctx, id)
+		if err != nil {
+			unlock()
+			return nil, err
+		}
+		unlocks = append(unlocks, func() { s.unlockId(id, idwaiter) })
+	}
+
+	return unlock, nil
+}
+
+// putOrMoveRepoSync saves the repo sync under newid, and returns the secret of the repo sync.
+// If newid is different from id, the repo sync and the secret under id are deleted, and a new secret is generated.
+// putstat saves the stat under the given id, and the stat under id is kept if putstat is nil. The ids must be locked.
+func (s *Svc) putOrMoveRepoSync(id []byte, newid []byte, reposync *DbRepoSync, putstat func(id []byte) func(tx *bbolt.Tx) error) ([]byte, error) {
+	if putstat == nil {
+		putstat = func(dst []byte) func(tx *bbolt.Tx) error { return copySyncStatFunc(id, dst) }
+	}
+
+	if bytes.Equal(id, newid) {
+		secret, err := getSecretForId(s.db, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.Update(func(tx *bbolt.Tx) error {
+			if err := putstat(id)(tx); err != nil {
+				return err
+			}
+			return putRepoSyncFunc(id, reposync)(tx)
+		}); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}
+
+	existing, err := getRepoSyncFromDb(s.db, newid)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "repo sync %x already exists", newid)
+	}
+
+	// the secret encrypts the id, so a new one is needed.
+	secret, err := s.keyring.newSecret(newid)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate secret: %s", err.Error())
+	}
+
+	if err := s.db.Update(func(tx *bbolt.Tx) error {
+		// the stat is saved before the one under id is deleted, since it may be copied from there.
+		if err := putstat(newid)(tx); err != nil {
+			return err
+		}
+		if err := deleteRepoSyncFunc(id)(tx); err != nil {
+			return err
+		}
+		if err := putSecretFunc(newid, secret)(tx); err != nil {
+			return err
+		}
+		return putRepoSyncFunc(newid, reposync)(tx)
+	}); err != nil {
+		return nil, err
+	}
+
+	logger.Info("moved repo sync", "from", hex.EncodeToString(id), "to", hex.EncodeToString(newid))
+
+	return secret, nil
+}
+
+// lockRepoSyncUpdate locks the repo sync idhex and the id of the sync data update builds from it, and returns
+// the repo sync read after the lock with the sync data built from it. The ids are locked again if a concurrent
+// update changes the new id before the lock is obtained. The returned function unlocks the ids.
+func (s *Svc) lockRepoSyncUpdate(
+	ctx context.Context,
+	idhex string,
+	update func(reposync *DbRepoSync) (*RepoSync, error),
+) (*DbRepoSync, []byte, *RepoSync, func(), error) {
+	reposync, _, err := getRepoSync(s.db, idhex, true)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	syncdata, err := update(reposync)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	for {
+		unlock, err := s.lockIds(ctx, idhex, syncdata.Id)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		// reload the repo sync since it may be updated before the lock is obtained.
+		reposync, id, err := getRepoSync(s.db, idhex, true)
+		if err != nil {
+			unlock()
+			return nil, nil, nil, nil, err
+		}
+		newsyncdata, err := update(reposync)
+		if err != nil {
+			unlock()
+			return nil, nil, nil, nil, err
+		}
+		if newsyncdata.Id == syncdata.Id {
+			return reposync, id, newsyncdata, unlock, nil
+		}
+
+		unlock()
+		syncdata = newsyncdata
+	}
+}
+
+// updateRepoSyncData returns a copy of syncdata with the fields in req applied.
+func (s *Svc) updateRepoSyncData(syncdata *RepoSync, req *UpdateRepoSyncRequest) (*RepoSync, error) {
+	syncdata = proto.Clone(syncdata).(*RepoSync)
+	syncdata.FromRepo = updateGitRepoIdentifier(syncdata.FromRepo, req.FromRepo)
+	syncdata.ToRepo = updateGitRepoIdentifier(syncdata.ToRepo, req.ToRepo)
+	if req.FromBranch != "" {
+		syncdata.FromBranch = req.FromBranch
+	}
+	if req.ToBranch != "" {
+		syncdata.ToBranch = req.ToBranch
+	}
+	if err := s.verifyInitRepoSyncRequest(&InitRepoSyncRequest{
+		FromRepo:   syncdata.FromRepo,
+		FromBranch: syncdata.FromBranch,
+		ToRepo:     syncdata.ToRepo,
+		ToBranch:   syncdata.ToBranch,
+	}); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.Filter != "" {
+		filter, err := NewCanonicalFilter(req.Filter)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if !slices.Equal(filter.CanonicalFilters, syncdata.Filter.GetCanonicalFilters()) {
+			return nil, status.Error(codes.FailedPrecondition, "canonical filters are changed, use UpdateRepoSyncFilter instead")
+		}
+		syncdata.Filter = filter
+	}
+
+	syncdata.Id = hex.EncodeToString(NewRepoSyncId(syncdata.FromRepo, syncdata.FromBranch, syncdata.ToRepo, syncdata.ToBranch))
+
+	return syncdata, nil
+}
+
+func (s *Svc) UpdateRepoSync(ctx context.Context, req *UpdateRepoSyncRequest) (*UpdateRepoSyncResponse, error) {
+	// the sync data is built from the repo sync read under the lock, so a concurrent update is not reverted.
+	reposync, id, syncdata, unlock, err := s.lockRepoSyncUpdate(ctx, req.Id, func(reposync *DbRepoSync) (*RepoSync, error) {
+		return s.updateRepoSyncData(reposync.SyncData, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	newid := NewRepoSyncId(syncdata.FromRepo, syncdata.FromBranch, syncdata.ToRepo, syncdata.ToBranch)
+	reposync.SyncData = syncdata
+
+	secret, err := s.putOrMoveRepoSync(id, newid, reposync, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Sync(); err != nil {
+		return nil, ErrStatusDBFailure
+	}
+
+	resp := &UpdateRepoSyncResponse{
+		RepoSync: syncdata,
+		Secret:   hex.EncodeToString(secret),
+	}
+
+	// the webhook url contains the id, so it is registered again with the new secret.
+	if syncdata.Id != req.Id {
+		resp.WebhookRepos, err = s.registerWebhooks(ctx, syncdata, resp.Secret)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "moved repo sync, but failed to update webhooks: %s", err.Error())
+		}
+	}
+
+	return resp, nil
+}
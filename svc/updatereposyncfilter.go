@@ -0,0 +1,247 @@
+package svc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/fardream/gitrim"
+)
+
+// maxPreviewPaths is the max number of paths returned in the preview of a filter change.
+const maxPreviewPaths = 1000
+
+// collectHistoryPaths collects the paths of all the files in the trees of the commits.
+func collectHistoryPaths(ctx context.Context, s storer.EncodedObjectStorer, commits []*object.Commit) (map[string]struct{}, error) {
+	paths := make(map[string]struct{})
+	// same tree at the same path only needs to be visited once.
+	type visitKey struct {
+		hash plumbing.Hash
+		dir  string
+	}
+	visited := make(map[visitKey]struct{})
+
+	var walk func(hash plumbing.Hash, dir string) error
+	walk = func(hash plumbing.Hash, dir string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		key := visitKey{hash: hash, dir: dir}
+		if _, found := visited[key]; found {
+			return nil
+		}
+		visited[key] = struct{}{}
+
+		tree, err := object.GetTree(s, hash)
+		if err != nil {
+			return err
+		}
+		for _, entry := range tree.Entries {
+			fullpath := path.Join(dir, entry.Name)
+			if entry.Mode == filemode.Dir {
+				if err := walk(entry.Hash, fullpath); err != nil {
+					return err
+				}
+				continue
+			}
+			paths[fullpath] = struct{}{}
+		}
+
+		return nil
+	}
+
+	for _, c := range commits {
+		if err := walk(c.TreeHash, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
+
+// diffFilterPaths returns the sorted paths only in the new filter, and the sorted paths only in the old filter.
+func diffFilterPaths(paths map[string]struct{}, oldfilter gitrim.Filter, newfilter gitrim.Filter) (added []string, removed []string) {
+	for p := range paths {
+		inold := gitrim.FilterPath(oldfilter, p, false).IsIn()
+		innew := gitrim.FilterPath(newfilter, p, false).IsIn()
+		switch {
+		case innew && !inold:
+			added = append(added, p)
+		case inold && !innew:
+			removed = append(removed, p)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+
+	return added, removed
+}
+
+func (s *Svc) UpdateRepoSyncFilter(ctx context.Context, req *UpdateRepoSyncFilterRequest) (*UpdateRepoSyncFilterResponse, error) {
+	newfilter, err := NewCanonicalFilter(req.Filter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(newfilter.CanonicalFilters) == 0 {
+		return nil, status.Error(codes.InvalidArgument, ErrEmptyFilter.Error())
+	}
+
+	update := func(reposync *DbRepoSync) (*RepoSync, error) {
+		if slices.Equal(newfilter.CanonicalFilters, reposync.SyncData.Filter.GetCanonicalFilters()) {
+			return nil, status.Error(codes.FailedPrecondition, "canonical filters are unchanged, use UpdateRepoSync instead")
+		}
+		syncdata := proto.Clone(reposync.SyncData).(*RepoSync)
+		syncdata.Filter = newfilter
+		if req.NewToBranch != "" {
+			syncdata.ToBranch = req.NewToBranch
+		}
+		syncdata.Id = hex.EncodeToString(NewRepoSyncId(syncdata.FromRepo, syncdata.FromBranch, syncdata.ToRepo, syncdata.ToBranch))
+		return syncdata, nil
+	}
+
+	var reposync *DbRepoSync
+	var id []byte
+	var syncdata *RepoSync
+	if req.DoPush {
+		// the sync data is built from the repo sync read under the lock, so a concurrent update is not reverted.
+		var unlock func()
+		reposync, id, syncdata, unlock, err = s.lockRepoSyncUpdate(ctx, req.Id, update)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	} else {
+		reposync, id, err = getRepoSync(s.db, req.Id, true)
+		if err != nil {
+			return nil, err
+		}
+		syncdata, err = update(reposync)
+		if err != nil {
+			return nil, err
+		}
+	}
+	oldfilter := reposync.SyncData.Filter
+	newid := NewRepoSyncId(syncdata.FromRepo, syncdata.FromBranch, syncdata.ToRepo, syncdata.ToBranch)
+	newidhex := syncdata.Id
+
+	// the history is rebuilt from an empty stat.
+	ws, err := newSyncWorkspace(ctx, s.config.Remotes, s.objectCache, &DbRepoSync{SyncData: syncdata, Stat: EmptySyncStat()}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.close()
+	s.observeSyncWorkspace(ws, AuditEvent_UPDATE_REPO_SYNC_FILTER)
+	if ws.fromWksp.isempty {
+		return nil, ErrStatusEmptyFromRepo
+	}
+
+	ws.fromNewcommits, err = ws.fromWksp.getNewCommits(ctx, plumbing.ZeroHash, ws.roots, false)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to obtain commits for from repo: %s", err.Error())
+	}
+	paths, err := collectHistoryPaths(ctx, ws.fromWksp.storage, ws.fromNewcommits)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to collect paths in history: %s", err.Error())
+	}
+	oldfilterimpl, err := gitrim.NewOrFilterForPatterns(oldfilter.GetCanonicalFilters()...)
+	if err != nil {
+		return nil, err
+	}
+	added, removed := diffFilterPaths(paths, oldfilterimpl, ws.filter)
+
+	resp := &UpdateRepoSyncFilterResponse{
+		OldFilter:            oldfilter,
+		NewFilter:            newfilter,
+		AddedPaths:           added[:min(len(added), maxPreviewPaths)],
+		RemovedPaths:         removed[:min(len(removed), maxPreviewPaths)],
+		NumberOfAddedPaths:   int32(len(added)),
+		NumberOfRemovedPaths: int32(len(removed)),
+	}
+
+	if !req.DoPush {
+		logger.Info("not updating filter due to dry run", "id", req.Id)
+		return resp, nil
+	}
+
+	if ws.toWksp.branchhead != nil {
+		resp.OriginalHead = ws.toWksp.branchhead.Hash.String()
+	}
+
+	if newidhex != req.Id {
+		existing, err := getRepoSyncFromDb(s.db, newid)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, status.Errorf(codes.AlreadyExists, "repo sync %s already exists", newidhex)
+		}
+	}
+
+	newcommits, err := ws.syncToTo(ctx, req.Force)
+	if errors.Is(err, ErrToNotInSync) {
+		return nil, status.Errorf(codes.FailedPrecondition, "branch %s of to repo is not empty, set force or use a new branch", syncdata.ToBranch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	newreposync := &DbRepoSync{
+		SyncData: syncdata,
+		Stat:     ws.db.Stat,
+		PreviousFilters: append(reposync.PreviousFilters, &FilterRevision{
+			Filter:     reposync.SyncData.Filter,
+			ToBranch:   reposync.SyncData.ToBranch,
+			SyncStat:   reposync.Stat,
+			ReplacedAt: time.Now().Unix(),
+		}),
+	}
+
+	// the maps of the old filter are kept under its revision, and moved along with the repo sync.
+	revision := len(reposync.PreviousFilters)
+	putstat := func(dst []byte) func(tx *bbolt.Tx) error {
+		return func(tx *bbolt.Tx) error {
+			if err := archiveSyncStatFunc(id, revision)(tx); err != nil {
+				return err
+			}
+			if err := copySyncStatFunc(id, dst)(tx); err != nil {
+				return err
+			}
+			return ws.putStatFunc(dst)(tx)
+		}
+	}
+
+	secret, err := s.putOrMoveRepoSync(id, newid, newreposync, putstat)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Sync(); err != nil {
+		return nil, ErrStatusDBFailure
+	}
+
+	resp.NumberOfNewCommits = int32(len(newcommits))
+	resp.NewHead = newreposync.Stat.LastSyncToCommit
+	resp.RepoSync = syncdata
+	resp.Secret = hex.EncodeToString(secret)
+
+	// the webhook url contains the id, so it is registered again with the new secret.
+	if newidhex != req.Id {
+		resp.WebhookRepos, err = s.registerWebhooks(ctx, syncdata, resp.Secret)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "moved repo sync, but failed to update webhooks: %s", err.Error())
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,206 @@
+package svc
+
+import (
+	"context"
+	"encoding/hex"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSvc_UpdateRepoSyncFilter(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(root, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n", "c/z.txt": "z\n"}, "first")
+	pushLocal(t, fromwork)
+	todir := filepath.Join(root, "org", "to")
+	torepo := newLocalRepo(t, todir, true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"local": {RemoteName: "local", RemoteUrl: root},
+		},
+	})
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// files returns the files at the head of the branch of the to repo.
+	files := func(t *testing.T, branch string) []string {
+		t.Helper()
+
+		ref, err := torepo.Reference(plumbing.NewBranchReferenceName(branch), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := torepo.CommitObject(ref.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+		fileiter, err := c.Files()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var r []string
+		for f, err := fileiter.Next(); err == nil; f, err = fileiter.Next() {
+			r = append(r, f.Name)
+		}
+		slices.Sort(r)
+		return r
+	}
+
+	// getStat returns the stat of the repo sync with its maps.
+	getStat := func(t *testing.T, id string) *GetRepoSyncResponse {
+		t.Helper()
+
+		got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: id, WithStatMaps: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	initial := getStat(t, initresp.Id)
+
+	preview, err := s.UpdateRepoSyncFilter(ctx, &UpdateRepoSyncFilterRequest{Id: initresp.Id, Filter: "a/\nb/\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(preview.AddedPaths, []string{"b/y.txt"}) || len(preview.RemovedPaths) != 0 {
+		t.Errorf("unexpected preview: added %v, removed %v", preview.AddedPaths, preview.RemovedPaths)
+	}
+	if preview.RepoSync != nil {
+		t.Errorf("repo sync is updated in preview")
+	}
+
+	_, err = s.UpdateRepoSyncFilter(ctx, &UpdateRepoSyncFilterRequest{Id: initresp.Id, Filter: "a/\nb/\n", DoPush: true})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("want failed precondition without force, got %v", err)
+	}
+
+	moved, err := s.UpdateRepoSyncFilter(ctx, &UpdateRepoSyncFilterRequest{Id: initresp.Id, Filter: "a/\nb/\n", NewToBranch: "v2", DoPush: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.RepoSync.Id == initresp.Id || moved.Secret == initresp.Secret {
+		t.Errorf("repo sync is not moved to a new id: %v", moved.RepoSync)
+	}
+	if got := files(t, "v2"); !slices.Equal(got, []string{"a/x.txt", "b/y.txt"}) {
+		t.Errorf("unexpected files on new branch: %v", got)
+	}
+	if got := files(t, "main"); !slices.Equal(got, []string{"a/x.txt"}) {
+		t.Errorf("old branch is changed: %v", got)
+	}
+	if _, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: initresp.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("old id still exists: %v", err)
+	}
+	beforeforce := getStat(t, moved.RepoSync.Id)
+
+	forced, err := s.UpdateRepoSyncFilter(ctx, &UpdateRepoSyncFilterRequest{Id: moved.RepoSync.Id, Filter: "b/", Force: true, DoPush: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(forced.RemovedPaths, []string{"a/x.txt"}) || forced.RepoSync.Id != moved.RepoSync.Id || forced.Secret != moved.Secret {
+		t.Errorf("unexpected response: %v", forced)
+	}
+	if got := files(t, "v2"); !slices.Equal(got, []string{"b/y.txt"}) {
+		t.Errorf("unexpected files after force push: %v", got)
+	}
+
+	got, err := s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: moved.RepoSync.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.PreviousFilters) != 2 {
+		t.Fatalf("want 2 previous filters, got %d", len(got.PreviousFilters))
+	}
+	first, second := got.PreviousFilters[0], got.PreviousFilters[1]
+	if first.ToBranch != "main" || !slices.Equal(first.Filter.CanonicalFilters, []string{"a/"}) || first.SyncStat.LastSyncToCommit == "" {
+		t.Errorf("unexpected first previous filter: %v", first)
+	}
+	if second.ToBranch != "v2" || second.SyncStat.LastSyncToCommit != moved.NewHead {
+		t.Errorf("unexpected second previous filter: %v", second)
+	}
+	if got.SyncStat.LastSyncToCommit != forced.NewHead {
+		t.Errorf("want stat at %s, got %s", forced.NewHead, got.SyncStat.LastSyncToCommit)
+	}
+
+	// a concurrent update committed before the lock is obtained is kept.
+	fromhead, err := fromwork.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock, err := s.lockIds(ctx, moved.RepoSync.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		_, err := s.UpdateRepoSyncFilter(ctx, &UpdateRepoSyncFilterRequest{Id: moved.RepoSync.Id, Filter: "b/\nc/\n", Force: true, DoPush: true})
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	reposync, dbid, err := getRepoSync(s.db, moved.RepoSync.Id, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reposync.SyncData.RootCommits = []string{fromhead.Hash().String()}
+	if err := s.db.Update(putRepoSyncFunc(dbid, reposync)); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	got, err = s.GetRepoSync(ctx, &GetRepoSyncRequest{Id: moved.RepoSync.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.RepoSync.RootCommits, reposync.SyncData.RootCommits) {
+		t.Errorf("concurrent update is reverted: %v", got.RepoSync)
+	}
+
+	t.Run("previous maps", func(t *testing.T) {
+		got := getStat(t, moved.RepoSync.Id)
+		for i, want := range []*SyncStat{initial.SyncStat, beforeforce.SyncStat} {
+			if previous := got.PreviousFilters[i].SyncStat; len(previous.FromDfs) == 0 || !proto.Equal(previous, want) {
+				t.Errorf("want maps of previous filter %d kept as %v, got %v", i, want, previous)
+			}
+		}
+		if proto.Equal(got.SyncStat, beforeforce.SyncStat) || len(got.SyncStat.ToToFrom) == 0 {
+			t.Errorf("unexpected maps of new filter %v", got.SyncStat)
+		}
+
+		rawid, err := hex.DecodeString(moved.RepoSync.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.DeleteRepoSync(ctx, &DeleteRepoSyncRequest{Id: moved.RepoSync.Id}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.db.View(func(tx *bbolt.Tx) error {
+			if getSyncStatBucket(tx, rawid) != nil {
+				t.Error("maps are not deleted with the repo sync")
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package svc
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
 	ErrNilRepo         = errors.New("nil repo")
@@ -8,8 +11,17 @@ var (
 	ErrEmptyRepoName   = errors.New("empty repo name")
 	ErrEmptyBranchName = errors.New("empty branch name")
 	ErrEmptyRemoteName = errors.New("empty remote name")
+
+	ErrInvalidOwnerName = errors.New(`owner name cannot be . or .., or contain / or \`)
+	ErrInvalidRepoName  = errors.New(`repo name cannot be . or .., or contain / or \`)
 )
 
+// isPathElement checks if the owner or the repo name is a single path element, so it cannot escape the remote once
+// put into a path or an url.
+func isPathElement(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
 func verifyGitRepoIdentifier(repo *GitRepoIdentifier) error {
 	if repo == nil {
 		return ErrNilRepo
@@ -23,6 +35,12 @@ func verifyGitRepoIdentifier(repo *GitRepoIdentifier) error {
 	if repo.RemoteName == "" {
 		return ErrEmptyRemoteName
 	}
+	if !isPathElement(repo.Owner) {
+		return ErrInvalidOwnerName
+	}
+	if !isPathElement(repo.Repo) {
+		return ErrInvalidRepoName
+	}
 	return nil
 }
 
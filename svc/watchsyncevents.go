@@ -0,0 +1,48 @@
+package svc
+
+func (s *Svc) WatchSyncEvents(req *WatchSyncEventsRequest, stream GiTrim_WatchSyncEventsServer) error {
+	ctx := stream.Context()
+
+	// subscribe before reading the stored events, so no event is missed in between.
+	w := s.events.subscribe(req.Ids)
+	defer s.events.unsubscribe(w)
+
+	var last uint64
+	if req.Resume {
+		last = req.AfterSequence
+		for {
+			events, err := s.events.stored(last, req.Ids, syncEventReplayBatchSize)
+			if err != nil {
+				logger.Error("failed to read sync events", "err", err)
+				return ErrStatusDBFailure
+			}
+			for _, e := range events {
+				if err := stream.Send(e); err != nil {
+					return err
+				}
+				last = e.Sequence
+			}
+			if len(events) < syncEventReplayBatchSize {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-w.done:
+			return w.err
+		case e := <-w.c:
+			// already sent from the db.
+			if e.Sequence <= last {
+				continue
+			}
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+			last = e.Sequence
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package svc
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEventBus(t *testing.T) {
+	s := newTestSvc(t, nil)
+	bus := newEventBus(s.db, 3)
+
+	for _, id := range []string{"a", "b", "a", "b", "a"} {
+		bus.publish(&SyncEvent{RepoSyncId: id, Type: SyncEvent_PUSHED})
+	}
+
+	events, err := bus.stored(0, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seqs []uint64
+	for _, e := range events {
+		seqs = append(seqs, e.Sequence)
+	}
+	if !slices.Equal(seqs, []uint64{3, 4, 5}) {
+		t.Errorf("want the last 3 events kept, got %v", seqs)
+	}
+
+	events, err = bus.stored(3, []string{"a"}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Sequence != 5 {
+		t.Errorf("want event 5 of a, got %v", events)
+	}
+
+	t.Run("slow watcher", func(t *testing.T) {
+		w := bus.subscribe(nil)
+		defer bus.unsubscribe(w)
+
+		for range syncEventBufferSize + 1 {
+			bus.publish(&SyncEvent{RepoSyncId: "a"})
+		}
+
+		select {
+		case <-w.done:
+			if w.err != ErrStatusWatcherTooSlow {
+				t.Errorf("want too slow, got %v", w.err)
+			}
+		default:
+			t.Error("slow watcher is not dropped")
+		}
+	})
+}
+
+func recvSyncEvent(t *testing.T, stream GiTrim_WatchSyncEventsClient, typ SyncEvent_Type) *SyncEvent {
+	t.Helper()
+
+	e, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Type != typ {
+		t.Fatalf("want %s, got %v", typ, e)
+	}
+
+	return e
+}
+
+func TestSvc_WatchSyncEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	fromroot := t.TempDir()
+	fromwork := newLocalWorkRepo(t, filepath.Join(fromroot, "org", "from"))
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+
+	toroot := t.TempDir()
+	todir := filepath.Join(toroot, "org", "to.git")
+	newLocalRepo(t, todir, true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"from": {RemoteName: "from", RemoteType: RemoteConfig_LOCAL, RemoteUrl: fromroot},
+			"to":   {RemoteName: "to", RemoteType: RemoteConfig_LOCAL, RemoteUrl: toroot},
+		},
+		ShutdownWaitSecs: 5,
+	})
+	conn, stop, served := serveTestSvc(t, s)
+	client := NewGiTrimClient(conn)
+
+	// resume from the start, so the events before the stream is set up are not missed.
+	stream, err := client.WatchSyncEvents(ctx, &WatchSyncEventsRequest{Resume: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "from", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "to", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filtered := recvSyncEvent(t, stream, SyncEvent_COMMITS_FILTERED)
+	if filtered.RepoSyncId != initresp.Id || filtered.NumberOfFromCommits != 1 || len(filtered.ToCommits) != 1 {
+		t.Errorf("unexpected event: %v", filtered)
+	}
+	pushed := recvSyncEvent(t, stream, SyncEvent_PUSHED)
+	if pushed.Repo.Repo != "to" || pushed.Branch != "main" || pushed.OldHead != "" || pushed.NewHead != filtered.ToCommits[0] {
+		t.Errorf("unexpected event: %v", pushed)
+	}
+
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "second")
+	pushLocal(t, fromwork)
+	if _, err := s.SyncToSubRepo(ctx, &SyncToSubRepoRequest{Id: initresp.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	recvSyncEvent(t, stream, SyncEvent_COMMITS_FILTERED)
+	pushedagain := recvSyncEvent(t, stream, SyncEvent_PUSHED)
+	if pushedagain.OldHead != pushed.NewHead || pushedagain.Sequence != 4 {
+		t.Errorf("unexpected event: %v", pushedagain)
+	}
+
+	towork, err := git.PlainClone(filepath.Join(t.TempDir(), "towork"), false, &git.CloneOptions{URL: todir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitLocalFiles(t, towork, map[string]string{"b/z.txt": "z\n"}, "outside of filter")
+	pushLocal(t, towork)
+
+	resp, err := s.CommitsFromSubRepo(ctx, &CommitsFromSubRepoRequest{Id: initresp.Id, DoPush: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Result != SubRepoCommitsCheck_COMMITS_REJECTED {
+		t.Fatalf("want rejected, got %v", resp)
+	}
+	tohead, err := towork.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejected := recvSyncEvent(t, stream, SyncEvent_CONTRIBUTION_REJECTED)
+	if !slices.Contains(rejected.RejectedFiles, "b/z.txt") || !slices.Contains(rejected.RejectedCommits, tohead.Hash().String()) {
+		t.Errorf("unexpected event: %v", rejected)
+	}
+
+	t.Run("filter and resume", func(t *testing.T) {
+		local, err := NewLocalClient(s).WatchSyncEvents(ctx, &WatchSyncEventsRequest{Ids: []string{initresp.Id}, AfterSequence: 3, Resume: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e := recvSyncEvent(t, local, SyncEvent_PUSHED); e.Sequence != 4 {
+			t.Errorf("want resumed from 4, got %d", e.Sequence)
+		}
+		recvSyncEvent(t, local, SyncEvent_CONTRIBUTION_REJECTED)
+
+		otherctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		other, err := NewLocalClient(s).WatchSyncEvents(otherctx, &WatchSyncEventsRequest{Ids: []string{"other"}, Resume: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e, err := other.Recv(); err == nil {
+			t.Errorf("want no events of other repo syncs, got %v", e)
+		}
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		stop()
+		if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+			t.Errorf("want unavailable, got %v", err)
+		}
+		if err := <-served; err != nil {
+			t.Fatal(err)
+		}
+	})
+}
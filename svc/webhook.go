@@ -0,0 +1,382 @@
+package svc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Headers set by GitHub, Gitea, and GitLab on webhook deliveries.
+const (
+	githubEventHeader     = "X-GitHub-Event"
+	githubSignatureHeader = "X-Hub-Signature-256"
+	giteaEventHeader      = "X-Gitea-Event"
+	giteaSignatureHeader  = "X-Gitea-Signature"
+	gitlabEventHeader     = "X-Gitlab-Event"
+	gitlabTokenHeader     = "X-Gitlab-Token"
+	githubDeliveryHeader  = "X-GitHub-Delivery"
+	giteaDeliveryHeader   = "X-Gitea-Delivery"
+	gitlabDeliveryHeader  = "X-Gitlab-Event-UUID"
+
+	githubSignaturePrefix = "sha256="
+	gitlabPushEvent       = "Push Hook"
+)
+
+// webhookPath is the path the webhook listens on, the id of the repo sync is the last segment.
+const webhookPath = "/webhook/"
+
+// maxWebhookPayloadSize is the maximum size of the payload the webhook reads.
+const maxWebhookPayloadSize = 25 << 20
+
+var (
+	ErrEmptyWebhookAddress = errors.New("empty webhook address")
+	ErrMissingSignature    = errors.New("missing webhook signature")
+	ErrSignatureMismatch   = errors.New("webhook signature mismatch")
+)
+
+// pushPayload contains the fields of the push event payload used by the webhook.
+// GitHub and Gitea share the same layout for those fields, and GitLab has the full name of the repo in project.
+type pushPayload struct {
+	Ref     string `json:"ref"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+	Deleted bool   `json:"deleted"`
+
+	Repository struct {
+		Name     string `json:"name"`
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+}
+
+// fullName returns the full name of the repo pushed to.
+func (p *pushPayload) fullName() string {
+	if p.Repository.FullName != "" {
+		return p.Repository.FullName
+	}
+
+	return p.Project.PathWithNamespace
+}
+
+// webhookAction is the action a push event triggers.
+type webhookAction string
+
+const (
+	webhookActionIgnored            webhookAction = "ignored"
+	webhookActionSyncToSubRepo      webhookAction = "sync-to-sub-repo"
+	webhookActionCommitsFromSubRepo webhookAction = "commits-from-sub-repo"
+)
+
+// webhookResponse is the body of the response to a webhook delivery.
+type webhookResponse struct {
+	Action webhookAction `json:"action"`
+	Reason string        `json:"reason,omitempty"`
+	// JobId is the id of the job queued for the action, see [Svc.GetJob].
+	JobId uint64 `json:"job_id,omitempty"`
+}
+
+func (s *Svc) setupWebhook() {
+	s.webhookMutex = http.NewServeMux()
+	s.webhookMutex.HandleFunc("POST "+webhookPath+"{id}", s.handleWebhook)
+}
+
+// WebhookHandler returns the handler for the webhooks.
+func (s *Svc) WebhookHandler() http.Handler {
+	return s.webhookMutex
+}
+
+// ServeWebhook listens on webhook_address and serves the webhooks until ctx is done.
+// See [Svc.ServeWebhookListener].
+func (s *Svc) ServeWebhook(ctx context.Context) error {
+	if s.config.WebhookAddress == "" {
+		return ErrEmptyWebhookAddress
+	}
+
+	lis, err := net.Listen("tcp", s.config.WebhookAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.WebhookAddress, err)
+	}
+
+	return s.ServeWebhookListener(ctx, lis)
+}
+
+// ServeWebhookListener serves the webhooks on lis until ctx is done.
+//
+// The webhook for a repo sync is at /webhook/<id>, and the secret for the webhook is the secret of the repo sync.
+// A push to from_branch of the from repo queues a job for [Svc.SyncToSubRepo], and a push to to_branch of the to
+// repo queues a job for [Svc.CommitsFromSubRepo]. The delivery is acknowledged once the job is queued, and the job
+// runs on the workers of [Svc.RunJobWorkers].
+//
+// If webhook_tls is configured, the deliveries are served with TLS on lis.
+//
+// Once ctx is done, the server stops accepting new deliveries and waits shutdown_wait_secs for the pending ones
+// to finish.
+func (s *Svc) ServeWebhookListener(ctx context.Context, lis net.Listener) error {
+	if s.webhookTLS != nil {
+		lis = tls.NewListener(lis, s.webhookTLS)
+	}
+
+	server := &http.Server{
+		Handler:           s.webhookMutex,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	serveerr := make(chan error, 1)
+	go func() {
+		logger.Info("serving webhook", "address", lis.Addr().String())
+		serveerr <- server.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveerr:
+		return err
+	case <-ctx.Done():
+	}
+
+	waitsecs := s.config.GetProperShutdownWaitSecs()
+	logger.Info("shutting down webhook server", "wait-secs", waitsecs)
+
+	shutdownctx, cancel := context.WithTimeout(context.Background(), time.Duration(waitsecs)*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownctx); err != nil {
+		logger.Warn("webhook server failed to stop in time, force stopping", "wait-secs", waitsecs)
+		server.Close()
+	}
+
+	if err := <-serveerr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// verifyWebhookSecrets checks the signature against each of the secrets, and returns the error for the first secret
+// if none matches.
+func verifyWebhookSecrets(header http.Header, secrets [][]byte, body []byte) error {
+	var firsterr error
+	for _, secret := range secrets {
+		err := verifyWebhookSignature(header, []byte(hex.EncodeToString(secret)), body)
+		if err == nil {
+			return nil
+		}
+		if firsterr == nil {
+			firsterr = err
+		}
+	}
+
+	return firsterr
+}
+
+// verifyWebhookSignature checks the HMAC-SHA256 of the body against the signature in the headers.
+// GitHub sends the signature as "sha256=<hex>" in X-Hub-Signature-256, and Gitea sends the hex in X-Gitea-Signature.
+// GitLab doesn't sign the body, and sends the key as is in X-Gitlab-Token.
+func verifyWebhookSignature(header http.Header, key []byte, body []byte) error {
+	var signature string
+	if v := header.Get(gitlabTokenHeader); v != "" {
+		if !hmac.Equal([]byte(v), key) {
+			return ErrSignatureMismatch
+		}
+		return nil
+	} else if v := header.Get(githubSignatureHeader); v != "" {
+		if !strings.HasPrefix(v, githubSignaturePrefix) {
+			return fmt.Errorf("%w: unsupported signature %s", ErrSignatureMismatch, v)
+		}
+		signature = strings.TrimPrefix(v, githubSignaturePrefix)
+	} else if v := header.Get(giteaSignatureHeader); v != "" {
+		signature = v
+	} else {
+		return ErrMissingSignature
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
+
+// webhookEvent returns the event type of the delivery, GitLab push events are converted to push.
+func webhookEvent(header http.Header) string {
+	if v := header.Get(githubEventHeader); v != "" {
+		return v
+	}
+	if v := header.Get(gitlabEventHeader); v != "" {
+		if v == gitlabPushEvent {
+			return "push"
+		}
+		return v
+	}
+	return header.Get(giteaEventHeader)
+}
+
+// webhookDelivery returns the id of the delivery, or empty if the forge doesn't send one.
+func webhookDelivery(header http.Header) string {
+	for _, h := range []string{githubDeliveryHeader, giteaDeliveryHeader, gitlabDeliveryHeader} {
+		if v := header.Get(h); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// isSameRepo checks if the full name of the repo in the payload is the repo.
+// Payloads without the full name are considered matching.
+func isSameRepo(fullname string, repo *GitRepoIdentifier) bool {
+	if fullname == "" {
+		return true
+	}
+
+	return strings.EqualFold(fullname, repo.Owner+"/"+repo.Repo)
+}
+
+// webhookActionForPush decides the action for the push event.
+func webhookActionForPush(sync *RepoSync, push *pushPayload) (webhookAction, string) {
+	if push.Deleted || (push.After != "" && strings.Trim(push.After, "0") == "") {
+		return webhookActionIgnored, "branch deleted"
+	}
+
+	ref := plumbing.ReferenceName(push.Ref)
+	if !ref.IsBranch() {
+		return webhookActionIgnored, fmt.Sprintf("%s is not a branch", push.Ref)
+	}
+	branch := ref.Short()
+
+	switch {
+	case branch == sync.FromBranch && isSameRepo(push.fullName(), sync.FromRepo):
+		return webhookActionSyncToSubRepo, ""
+	case branch == sync.ToBranch && isSameRepo(push.fullName(), sync.ToRepo):
+		return webhookActionCommitsFromSubRepo, ""
+	default:
+		return webhookActionIgnored, fmt.Sprintf("push to %s of %s is not synced", branch, push.fullName())
+	}
+}
+
+func writeWebhookResponse(w http.ResponseWriter, code int, resp *webhookResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger.Warn("failed to write webhook response", "err", err)
+	}
+}
+
+// httpStatusForError converts the error returned by the gRPC methods into http status code.
+func httpStatusForError(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return http.StatusUnprocessableEntity
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Canceled, codes.DeadlineExceeded, codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (s *Svc) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	idhex := r.PathValue("id")
+
+	id, err := hex.DecodeString(idhex)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusNotFound)
+		return
+	}
+	reposync, err := getRepoSyncFromDb(s.db, id)
+	if err != nil {
+		logger.Error("failed to get repo sync for webhook", "id", idhex, "err", err)
+		http.Error(w, "failed to get repo sync", http.StatusInternalServerError)
+		return
+	}
+	if reposync == nil {
+		http.Error(w, "repo sync not found", http.StatusNotFound)
+		return
+	}
+	secrets, err := getWebhookSecretsForId(s.db, id)
+	if err != nil {
+		logger.Error("failed to get secret for webhook", "id", idhex, "err", err)
+		http.Error(w, "failed to get secret", http.StatusInternalServerError)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read payload: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	// the secret configured on the forge is the hex form of the secret, and the previous secrets are accepted until
+	// they expire.
+	if err := verifyWebhookSecrets(r.Header, secrets, body); err != nil {
+		logger.Warn("rejected webhook", "id", idhex, "err", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event := webhookEvent(r.Header)
+	if event != "push" {
+		writeWebhookResponse(w, http.StatusOK, &webhookResponse{
+			Action: webhookActionIgnored,
+			Reason: fmt.Sprintf("unsupported event: %s", event),
+		})
+		return
+	}
+
+	push := &pushPayload{}
+	if err := json.Unmarshal(body, push); err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse payload: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	action, reason := webhookActionForPush(reposync.SyncData, push)
+	logger.Info("webhook", "id", idhex, "ref", push.Ref, "repo", push.fullName(), "action", action, "reason", reason)
+
+	var request isJob_Request
+	switch action {
+	case webhookActionSyncToSubRepo:
+		request = &Job_SyncToSubRepo{SyncToSubRepo: &SyncToSubRepoRequest{Id: idhex}}
+	case webhookActionCommitsFromSubRepo:
+		request = &Job_CommitsFromSubRepo{CommitsFromSubRepo: &CommitsFromSubRepoRequest{Id: idhex, DoPush: true}}
+	default:
+		writeWebhookResponse(w, http.StatusOK, &webhookResponse{Action: action, Reason: reason})
+		return
+	}
+
+	// the pushes of the job are recorded as triggered by the delivery.
+	triggeredby := "webhook"
+	if delivery := webhookDelivery(r.Header); delivery != "" {
+		triggeredby += ":" + delivery
+	}
+	job, err := s.enqueueJob(withTriggeredBy(r.Context(), triggeredby), request)
+	if err != nil {
+		logger.Error("failed to queue webhook action", "id", idhex, "action", action, "err", err)
+		writeWebhookResponse(w, httpStatusForError(err), &webhookResponse{Action: action, Reason: err.Error()})
+		return
+	}
+
+	writeWebhookResponse(w, http.StatusAccepted, &webhookResponse{Action: action, JobId: job.Id})
+}
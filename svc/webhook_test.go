@@ -0,0 +1,327 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// newLocalRepo initializes a repo at dir with main as the default branch.
+func newLocalRepo(t *testing.T, dir string, bare bool) *git.Repository {
+	t.Helper()
+
+	repo, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+		Bare:        bare,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return repo
+}
+
+// newLocalWorkRepo initializes a bare repo at dir, and a work repo to push to it.
+func newLocalWorkRepo(t *testing.T, dir string) *git.Repository {
+	t.Helper()
+
+	newLocalRepo(t, dir, true)
+	work := newLocalRepo(t, t.TempDir(), false)
+	if _, err := work.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{dir}}); err != nil {
+		t.Fatal(err)
+	}
+
+	return work
+}
+
+// pushLocal pushes the main branch of the work repo to origin.
+func pushLocal(t *testing.T, work *git.Repository) {
+	t.Helper()
+
+	if err := work.Push(&git.PushOptions{RefSpecs: []config.RefSpec{"refs/heads/main:refs/heads/main"}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// commitLocalFiles writes the files into the work tree of repo and commits them.
+func commitLocalFiles(t *testing.T, repo *git.Repository, files map[string]string, msg string) plumbing.Hash {
+	t.Helper()
+
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := w.Filesystem.Root()
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h, err := w.Commit(msg, &git.CommitOptions{
+		Author: &object.Signature{Name: "A U Thor", Email: "author@example.com", When: time.Unix(1709604672, 0)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return h
+}
+
+func signGitHub(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func signGitea(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSvc_webhook(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	fromdir := filepath.Join(root, "org", "from")
+	fromwork := newLocalWorkRepo(t, fromdir)
+	commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x\n", "b/y.txt": "y\n"}, "first")
+	pushLocal(t, fromwork)
+	fromrepo, err := git.PlainOpen(fromdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	torepo := newLocalRepo(t, filepath.Join(root, "org", "to"), true)
+
+	s := newTestSvc(t, &GiTrimConfig{
+		Remotes: map[string]*RemoteConfig{
+			"local": {RemoteName: "local", RemoteUrl: root},
+		},
+	})
+
+	initresp, err := s.InitRepoSync(ctx, &InitRepoSyncRequest{
+		FromRepo:   &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "from"},
+		FromBranch: "main",
+		ToRepo:     &GitRepoIdentifier{RemoteName: "local", Owner: "org", Repo: "to"},
+		ToBranch:   "main",
+		Filter:     "a/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runTestJobWorkers(t, s)
+
+	server := httptest.NewServer(s.WebhookHandler())
+	defer server.Close()
+
+	githubpush, err := os.ReadFile("testdata/webhook/github-push.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	giteapush, err := os.ReadFile("testdata/webhook/gitea-push.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitlabpush, err := os.ReadFile("testdata/webhook/gitlab-push.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := func(t *testing.T, id string, body []byte, headers map[string]string) (int, *webhookResponse) {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/webhook/"+id, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		r := &webhookResponse{}
+		if resp.Header.Get("Content-Type") == "application/json" {
+			if err := json.NewDecoder(resp.Body).Decode(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return resp.StatusCode, r
+	}
+
+	t.Run("unknown id", func(t *testing.T) {
+		code, _ := post(t, hex.EncodeToString(make([]byte, 32)), githubpush, map[string]string{
+			githubEventHeader:     "push",
+			githubSignatureHeader: signGitHub(initresp.Secret, githubpush),
+		})
+		if code != http.StatusNotFound {
+			t.Errorf("want %d, got %d", http.StatusNotFound, code)
+		}
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		code, _ := post(t, initresp.Id, githubpush, map[string]string{githubEventHeader: "push"})
+		if code != http.StatusUnauthorized {
+			t.Errorf("want %d, got %d", http.StatusUnauthorized, code)
+		}
+	})
+
+	t.Run("wrong signature", func(t *testing.T) {
+		code, _ := post(t, initresp.Id, githubpush, map[string]string{
+			githubEventHeader:     "push",
+			githubSignatureHeader: signGitHub("wrong", githubpush),
+		})
+		if code != http.StatusUnauthorized {
+			t.Errorf("want %d, got %d", http.StatusUnauthorized, code)
+		}
+	})
+
+	t.Run("ping", func(t *testing.T) {
+		body := []byte(`{"zen":"Keep it logically awesome."}`)
+		code, resp := post(t, initresp.Id, body, map[string]string{
+			githubEventHeader:     "ping",
+			githubSignatureHeader: signGitHub(initresp.Secret, body),
+		})
+		if code != http.StatusOK || resp.Action != webhookActionIgnored {
+			t.Errorf("want ignored, got %d %v", code, resp)
+		}
+	})
+
+	t.Run("github push to from branch", func(t *testing.T) {
+		commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x2\n"}, "update x")
+		pushLocal(t, fromwork)
+
+		code, resp := post(t, initresp.Id, githubpush, map[string]string{
+			githubEventHeader:     "push",
+			githubSignatureHeader: signGitHub(initresp.Secret, githubpush),
+			githubDeliveryHeader:  "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		})
+		if code != http.StatusAccepted || resp.Action != webhookActionSyncToSubRepo {
+			t.Fatalf("want sync to sub repo, got %d %v", code, resp)
+		}
+		job := waitTestJob(t, s, resp.JobId)
+		if job.State != Job_SUCCEEDED {
+			t.Fatalf("job failed: %v", job)
+		}
+		audit, err := s.ListAuditEvents(ctx, &ListAuditEventsRequest{AfterSequence: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(audit.Events) != 1 || audit.Events[0].TriggeredBy != "webhook:72d3162e-cc78-11e3-81ab-4c9367dc0958" || audit.Events[0].JobId != job.Id {
+			t.Errorf("want the push recorded as triggered by the delivery, got %v", audit.Events)
+		}
+		result := job.GetSyncToSubRepoResult()
+		if result.NumberOfNewCommits != 1 {
+			t.Errorf("want 1 new commit, got %d", result.NumberOfNewCommits)
+		}
+
+		ref, err := torepo.Reference(plumbing.NewBranchReferenceName("main"), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ref.Hash().String() != result.NewHead {
+			t.Errorf("want to repo at %s, got %s", result.NewHead, ref.Hash())
+		}
+	})
+
+	t.Run("wrong gitlab token", func(t *testing.T) {
+		code, _ := post(t, initresp.Id, gitlabpush, map[string]string{
+			gitlabEventHeader: gitlabPushEvent,
+			gitlabTokenHeader: "wrong",
+		})
+		if code != http.StatusUnauthorized {
+			t.Errorf("want %d, got %d", http.StatusUnauthorized, code)
+		}
+	})
+
+	t.Run("gitlab push to from branch", func(t *testing.T) {
+		commitLocalFiles(t, fromwork, map[string]string{"a/x.txt": "x3\n"}, "update x again")
+		pushLocal(t, fromwork)
+
+		code, resp := post(t, initresp.Id, gitlabpush, map[string]string{
+			gitlabEventHeader: gitlabPushEvent,
+			gitlabTokenHeader: initresp.Secret,
+		})
+		if code != http.StatusAccepted || resp.Action != webhookActionSyncToSubRepo {
+			t.Fatalf("want sync to sub repo, got %d %v", code, resp)
+		}
+		if job := waitTestJob(t, s, resp.JobId); job.State != Job_SUCCEEDED {
+			t.Fatalf("job failed: %v", job)
+		}
+	})
+
+	t.Run("gitea push to to branch", func(t *testing.T) {
+		clone, err := git.PlainClone(t.TempDir(), false, &git.CloneOptions{URL: filepath.Join(root, "org", "to")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitLocalFiles(t, clone, map[string]string{"a/z.txt": "z\n"}, "add z")
+		pushLocal(t, clone)
+
+		code, resp := post(t, initresp.Id, giteapush, map[string]string{
+			giteaEventHeader:     "push",
+			giteaSignatureHeader: signGitea(initresp.Secret, giteapush),
+		})
+		if code != http.StatusAccepted || resp.Action != webhookActionCommitsFromSubRepo {
+			t.Fatalf("want commits from sub repo, got %d %v", code, resp)
+		}
+		job := waitTestJob(t, s, resp.JobId)
+		if job.State != Job_SUCCEEDED {
+			t.Fatalf("job failed: %v", job)
+		}
+		result := job.GetCommitsFromSubRepoResult()
+		if result.Result != SubRepoCommitsCheck_CHECK_PASSED {
+			t.Fatalf("want check passed, got %s", result.Result)
+		}
+
+		ref, err := fromrepo.Reference(plumbing.NewBranchReferenceName("main"), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := fromrepo.CommitObject(ref.Hash())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.File("a/z.txt"); err != nil {
+			t.Errorf("commit from sub repo is not in from repo: %s", err)
+		}
+	})
+
+	t.Run("push to other branch", func(t *testing.T) {
+		body := bytes.Replace(giteapush, []byte(`"refs/heads/main"`), []byte(`"refs/heads/dev"`), 1)
+		code, resp := post(t, initresp.Id, body, map[string]string{
+			giteaEventHeader:     "push",
+			giteaSignatureHeader: signGitea(initresp.Secret, body),
+		})
+		if code != http.StatusOK || resp.Action != webhookActionIgnored {
+			t.Errorf("want ignored, got %d %v", code, resp)
+		}
+	})
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage"
 	"github.com/go-git/go-git/v5/storage/memory"
 
 	"github.com/fardream/gitrim"
@@ -18,8 +19,8 @@ import (
 
 // workspace contains the repo, the branch, and the memory storage for one repo.
 type workspace struct {
-	// storage
-	storage *memory.Storage
+	// storage, which reads the objects from the object cache if the cache is configured.
+	storage storage.Storer
 	// repo id
 	repoId *GitRepoIdentifier
 	// branch
@@ -31,22 +32,10 @@ type workspace struct {
 
 	branchhead *object.Commit
 
-	auth *http.BasicAuth
-}
-
-func (c *RemoteConfig) auth() *http.BasicAuth {
-	if c.Username == "" {
-		return nil
-	}
-
-	return &http.BasicAuth{
-		Username: c.Username,
-		Password: c.Secret,
-	}
-}
+	auth transport.AuthMethod
 
-func constructPullUrl(cfg *RemoteConfig, owner string, repo string) (string, error) {
-	return fmt.Sprintf("%s/%s/%s", cfg.RemoteUrl, owner, repo), nil
+	// release the cached repo, nil if the cache is not used.
+	release func()
 }
 
 const (
@@ -66,9 +55,14 @@ var ErrEmptyRemoteConfig = errors.New("empty remote config")
 // fetch may fail if the remote is empty.
 // If the repo is not empty, a local branch will be set up with branch name
 // with the remote if the remote branch exists.
+//
+// If objcache is not nil, the branch is fetched into the cached repo instead,
+// and the workspace reads the objects from there. The workspace must be closed
+// to release the cached repo.
 func newWorkspace(
 	ctx context.Context,
 	configmap map[string]*RemoteConfig,
+	objcache *objectCache,
 	id *GitRepoIdentifier,
 	branch string,
 ) (*workspace, error) {
@@ -87,8 +81,34 @@ func newWorkspace(
 		return nil, fmt.Errorf("failed to construct url for remote repo: %w", err)
 	}
 
+	auth, err := remoteConfig.auth()
+	if err != nil {
+		return nil, err
+	}
+
 	// storage
-	storage := memory.NewStorage()
+	var storage storage.Storer = memory.NewStorage()
+	var cachedhead plumbing.Hash
+	var release func()
+	if objcache != nil {
+		start := time.Now()
+		base, head, r, err := objcache.fetch(ctx, url, branch, auth)
+		fetchDuration.WithLabelValues(id.RemoteName).Observe(time.Since(start).Seconds())
+		if err != nil {
+			return nil, err
+		}
+		storage = newOverlayStorage(base)
+		cachedhead = head
+		release = r
+	}
+
+	// release the cached repo if the workspace is not returned.
+	returned := false
+	defer func() {
+		if !returned && release != nil {
+			release()
+		}
+	}()
 
 	logger.Info("cloning repo", "remote", url, "branch", branch)
 
@@ -124,16 +144,45 @@ func newWorkspace(
 		repoId:  id,
 		branch:  branch,
 		repo:    repo,
-		auth:    remoteConfig.auth(),
+		auth:    auth,
+		release: release,
 	}
 
-	if err := w.fetch(ctx); err != nil {
+	if objcache != nil {
+		if err := w.setCachedHead(cachedhead); err != nil {
+			return nil, err
+		}
+	} else if err := w.fetch(ctx); err != nil {
 		return nil, err
 	}
 
+	returned = true
 	return w, nil
 }
 
+// close releases the cached repo used by the workspace.
+func (w *workspace) close() {
+	if w != nil && w.release != nil {
+		w.release()
+	}
+}
+
+// setCachedHead sets the remote branch to the head fetched into the cached repo, as if it is fetched.
+func (w *workspace) setCachedHead(head plumbing.Hash) error {
+	if head.IsZero() {
+		w.isempty = true
+	} else {
+		err := w.storage.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName(remotename, w.branch), head))
+		if err != nil {
+			return fmt.Errorf("failed to set remote branch: %w", err)
+		}
+	}
+
+	w.sethead()
+
+	return nil
+}
+
 // sethead set the head of the branch
 func (w *workspace) sethead() bool {
 	setemptyandreturnfalse := func() bool {
@@ -177,11 +226,13 @@ func isNoMatchingRef(err error) bool {
 
 // fetch the branch from remote and set the branch to
 func (w *workspace) fetch(ctx context.Context) error {
+	start := time.Now()
 	err := w.repo.FetchContext(ctx,
 		&git.FetchOptions{
 			Auth:       w.auth,
 			RemoteName: remotename,
 		})
+	fetchDuration.WithLabelValues(w.repoId.RemoteName).Observe(time.Since(start).Seconds())
 
 	// check if the remote is empty.
 	if err != nil && errors.Is(err, transport.ErrEmptyRemoteRepository) {
@@ -191,7 +242,7 @@ func (w *workspace) fetch(ctx context.Context) error {
 		logger.Warn("branch doesn't exist")
 		w.isempty = true
 	} else if err != nil {
-		return fmt.Errorf("failed to clone: %w", err)
+		return fmt.Errorf("failed to clone: %w", remoteError(err))
 	}
 
 	// if the repo is not empty, try set the branch, since no local branch yet.
@@ -203,6 +254,7 @@ func (w *workspace) fetch(ctx context.Context) error {
 // pushToRemote push the changes to the remote
 func (w *workspace) pushToRemote(ctx context.Context, forcePush bool) error {
 	refspec := config.RefSpec(fmt.Sprintf(refSpecSingleBranchPush, w.branch))
+	start := time.Now()
 	err := w.repo.PushContext(
 		ctx,
 		&git.PushOptions{
@@ -211,10 +263,11 @@ func (w *workspace) pushToRemote(ctx context.Context, forcePush bool) error {
 			Force:      forcePush,
 			RefSpecs:   []config.RefSpec{refspec},
 		})
+	pushDuration.WithLabelValues(w.repoId.RemoteName).Observe(time.Since(start).Seconds())
 	isuptodate := errors.Is(err, git.NoErrAlreadyUpToDate)
 	switch {
 	case err != nil && !isuptodate:
-		return fmt.Errorf("failed to update the remote: %w", err)
+		return fmt.Errorf("failed to update the remote: %w", remoteError(err))
 	case isuptodate:
 		logger.Warn("remote already updated")
 		fallthrough
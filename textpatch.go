@@ -0,0 +1,662 @@
+package gitrim
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+)
+
+// TextPatchFile is one side of a [TextFilePatch], it implements [diff.File].
+//
+// Hash is the abbreviated hash recorded in the "index" line of the patch,
+// and it is only set when the abbreviation is the full 40 hex digits.
+// Use AbbreviatedHash for the hash as recorded.
+type TextPatchFile struct {
+	path            string
+	mode            filemode.FileMode
+	AbbreviatedHash string
+}
+
+var _ diff.File = (*TextPatchFile)(nil)
+
+func (f *TextPatchFile) Hash() plumbing.Hash {
+	h, err := DecodeHashHex(f.AbbreviatedHash)
+	if err != nil {
+		return plumbing.ZeroHash
+	}
+	return h
+}
+
+func (f *TextPatchFile) Mode() filemode.FileMode {
+	return f.mode
+}
+
+func (f *TextPatchFile) Path() string {
+	return f.path
+}
+
+// TextPatchLine is a line in a [TextPatchHunk].
+type TextPatchLine struct {
+	Op diff.Operation
+	// Text of the line, the trailing new line is included unless the line is marked with
+	// "\ No newline at end of file".
+	Text string
+}
+
+// TextPatchHunk is a hunk of unified diff, starting with "@@ -a,b +c,d @@".
+type TextPatchHunk struct {
+	FromLine  int
+	FromCount int
+	ToLine    int
+	ToCount   int
+
+	Lines []TextPatchLine
+}
+
+type textChunk struct {
+	content string
+	op      diff.Operation
+}
+
+func (c *textChunk) Content() string {
+	return c.content
+}
+
+func (c *textChunk) Type() diff.Operation {
+	return c.op
+}
+
+// TextFilePatch is a file patch parsed from the text output of "git diff" or "git format-patch".
+// It implements [diff.FilePatch] so it can be checked by [CheckFilePatchAgainstFilter].
+//
+// Different from the file patches generated by go-git, the text patch only contains the hunks
+// instead of the whole content of the files, therefore [TextFilePatch.Chunks] only returns the lines
+// contained in the hunks.
+type TextFilePatch struct {
+	from *TextPatchFile
+	to   *TextPatchFile
+
+	isBinary bool
+	state    textPatchFileState
+
+	Hunks []*TextPatchHunk
+}
+
+var _ diff.FilePatch = (*TextFilePatch)(nil)
+
+func (p *TextFilePatch) IsBinary() bool {
+	return p.isBinary
+}
+
+func (p *TextFilePatch) Files() (from diff.File, to diff.File) {
+	// make sure nil pointers are returned as nil interfaces.
+	if p.from != nil {
+		from = p.from
+	}
+	if p.to != nil {
+		to = p.to
+	}
+	return
+}
+
+func (p *TextFilePatch) Chunks() []diff.Chunk {
+	var result []diff.Chunk
+	for _, h := range p.Hunks {
+		for _, l := range h.Lines {
+			result = append(result, &textChunk{content: l.Text, op: l.Op})
+		}
+	}
+	return result
+}
+
+// ErrBinaryPatch indicates the patch contains binary changes, which is unsupported.
+var ErrBinaryPatch = errors.New("binary patch is not supported")
+
+// ApplyToContent applies the hunks of the patch to the content of the from file.
+//
+// Each hunk is first tried at the line number it records, adjusted by the lines added or removed by previous hunks.
+// If the lines don't match there, the hunk is searched for in the neighbouring lines,
+// and the closest match is used. No fuzz is allowed for the context lines.
+func (p *TextFilePatch) ApplyToContent(content string) (string, error) {
+	if p.isBinary {
+		return "", ErrBinaryPatch
+	}
+
+	lines := splitTextLines(content)
+	result := make([]string, 0, len(lines))
+
+	// next line in the from content that is not copied to result yet.
+	next := 0
+	offset := 0
+	for i, h := range p.Hunks {
+		var oldlines, newlines []string
+		for _, l := range h.Lines {
+			if l.Op != diff.Add {
+				oldlines = append(oldlines, l.Text)
+			}
+			if l.Op != diff.Delete {
+				newlines = append(newlines, l.Text)
+			}
+		}
+
+		// hunk line numbers are 1 based, and a hunk with zero from lines records the line before it.
+		expected := h.FromLine - 1 + offset
+		if h.FromCount == 0 {
+			expected = h.FromLine + offset
+		}
+		pos := findLines(lines, oldlines, next, expected)
+		if pos < 0 {
+			return "", fmt.Errorf("hunk %d (@@ -%d,%d +%d,%d @@) doesn't apply", i+1, h.FromLine, h.FromCount, h.ToLine, h.ToCount)
+		}
+
+		result = append(result, lines[next:pos]...)
+		result = append(result, newlines...)
+		next = pos + len(oldlines)
+		offset = pos - (expected - offset)
+	}
+
+	result = append(result, lines[next:]...)
+
+	return strings.Join(result, ""), nil
+}
+
+// splitTextLines splits the content into lines, each line keeps its trailing new line.
+func splitTextLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// findLines finds the position of target in lines that is closest to expected, and not before start.
+func findLines(lines []string, target []string, start int, expected int) int {
+	matchAt := func(pos int) bool {
+		if pos < start || pos+len(target) > len(lines) {
+			return false
+		}
+		for i, t := range target {
+			if lines[pos+i] != t {
+				return false
+			}
+		}
+		return true
+	}
+
+	expected = max(expected, start)
+	for d := 0; expected-d >= start || expected+d <= len(lines); d++ {
+		if matchAt(expected - d) {
+			return expected - d
+		}
+		if matchAt(expected + d) {
+			return expected + d
+		}
+	}
+
+	return -1
+}
+
+// ParseTextPatch parses the output of "git diff", which contains one or more files
+// started by "diff --git" line. Lines before the first "diff --git" line are ignored.
+func ParseTextPatch(patch string) ([]*TextFilePatch, error) {
+	var result []*TextFilePatch
+
+	scanner := bufio.NewScanner(strings.NewReader(patch))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024*1024)
+	scanner.Split(scanLinesKeepEnd)
+
+	var current *TextFilePatch
+	var hunk *TextPatchHunk
+	// remaining lines of the hunk
+	var fromleft, toleft int
+	var gitfrom, gitto string
+
+	finishFile := func() error {
+		if current == nil {
+			return nil
+		}
+		if hunk != nil && (fromleft > 0 || toleft > 0) {
+			return fmt.Errorf("hunk is incomplete for %s", gitto)
+		}
+		hunk = nil
+		if err := current.finish(gitfrom, gitto); err != nil {
+			return err
+		}
+		result = append(result, current)
+		current = nil
+		return nil
+	}
+
+	lineno := 0
+	for scanner.Scan() {
+		lineno++
+		rawline := scanner.Text()
+		line := strings.TrimSuffix(rawline, "\n")
+
+		// inside a hunk.
+		if hunk != nil && (fromleft > 0 || toleft > 0) {
+			var op diff.Operation
+			switch {
+			case strings.HasPrefix(line, " ") || line == "":
+				op = diff.Equal
+				fromleft--
+				toleft--
+			case strings.HasPrefix(line, "-"):
+				op = diff.Delete
+				fromleft--
+			case strings.HasPrefix(line, "+"):
+				op = diff.Add
+				toleft--
+			case strings.HasPrefix(line, `\`):
+				if err := hunk.removeLastNewLine(); err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineno, err)
+				}
+				continue
+			default:
+				return nil, fmt.Errorf("line %d: unexpected line in hunk: %s", lineno, line)
+			}
+			if fromleft < 0 || toleft < 0 {
+				return nil, fmt.Errorf("line %d: hunk contains more lines than its header", lineno)
+			}
+			text := ""
+			if len(rawline) > 0 {
+				text = rawline[1:]
+			}
+			if !strings.HasSuffix(text, "\n") {
+				text += "\n"
+			}
+			hunk.Lines = append(hunk.Lines, TextPatchLine{Op: op, Text: text})
+			continue
+		}
+
+		// "\ No newline at end of file" right after the last line of the hunk.
+		if hunk != nil && strings.HasPrefix(line, `\`) {
+			if err := hunk.removeLastNewLine(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineno, err)
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			if err := finishFile(); err != nil {
+				return nil, err
+			}
+			current = &TextFilePatch{}
+			var err error
+			gitfrom, gitto, err = parseDiffGitLine(strings.TrimPrefix(line, "diff --git "))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineno, err)
+			}
+		case current == nil:
+			// lines before the first diff, such as the commit message, or diffstat.
+			continue
+		case strings.HasPrefix(line, "@@ "):
+			h, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineno, err)
+			}
+			hunk = h
+			fromleft, toleft = h.FromCount, h.ToCount
+			current.Hunks = append(current.Hunks, h)
+		case hunk != nil:
+			// end of the file patch, trailing lines are not part of the diff (for example the signature of format-patch).
+			if err := finishFile(); err != nil {
+				return nil, err
+			}
+		default:
+			isheader, err := current.parseHeaderLine(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineno, err)
+			}
+			// end of a file patch without hunks, such as pure renames.
+			if !isheader {
+				if err := finishFile(); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := finishFile(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func scanLinesKeepEnd(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[0 : i+1], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func (h *TextPatchHunk) removeLastNewLine() error {
+	if len(h.Lines) == 0 {
+		return errors.New("no newline marker without preceding line")
+	}
+	last := &h.Lines[len(h.Lines)-1]
+	last.Text = strings.TrimSuffix(last.Text, "\n")
+	return nil
+}
+
+func (f *TextFilePatch) ensureFrom() *TextPatchFile {
+	if f.from == nil {
+		f.from = &TextPatchFile{}
+	}
+	return f.from
+}
+
+func (f *TextFilePatch) ensureTo() *TextPatchFile {
+	if f.to == nil {
+		f.to = &TextPatchFile{}
+	}
+	return f.to
+}
+
+type textPatchFileState uint8
+
+const (
+	textPatchFileModified textPatchFileState = iota
+	textPatchFileNew
+	textPatchFileDeleted
+)
+
+// parseHeaderLine parses the extended header lines of git diff.
+// It returns false if the line is not a header line.
+func (f *TextFilePatch) parseHeaderLine(line string) (bool, error) {
+	field := func(prefix string) (string, bool) {
+		if !strings.HasPrefix(line, prefix) {
+			return "", false
+		}
+		return strings.TrimPrefix(line, prefix), true
+	}
+
+	if v, ok := field("new file mode "); ok {
+		m, err := filemode.New(v)
+		if err != nil {
+			return true, err
+		}
+		f.from = nil
+		f.ensureTo().mode = m
+		f.state = textPatchFileNew
+		return true, nil
+	}
+	if v, ok := field("deleted file mode "); ok {
+		m, err := filemode.New(v)
+		if err != nil {
+			return true, err
+		}
+		f.ensureFrom().mode = m
+		f.to = nil
+		f.state = textPatchFileDeleted
+		return true, nil
+	}
+	if v, ok := field("old mode "); ok {
+		m, err := filemode.New(v)
+		if err != nil {
+			return true, err
+		}
+		f.ensureFrom().mode = m
+		return true, nil
+	}
+	if v, ok := field("new mode "); ok {
+		m, err := filemode.New(v)
+		if err != nil {
+			return true, err
+		}
+		f.ensureTo().mode = m
+		return true, nil
+	}
+	if v, ok := field("rename from "); ok {
+		p, err := unquotePatchPath(v)
+		if err != nil {
+			return true, err
+		}
+		f.ensureFrom().path = p
+		return true, nil
+	}
+	if v, ok := field("rename to "); ok {
+		p, err := unquotePatchPath(v)
+		if err != nil {
+			return true, err
+		}
+		f.ensureTo().path = p
+		return true, nil
+	}
+	if _, ok := field("copy from "); ok {
+		return true, errors.New("copy patch is not supported")
+	}
+	if v, ok := field("index "); ok {
+		hashes, mode, _ := strings.Cut(v, " ")
+		fromhash, tohash, found := strings.Cut(hashes, "..")
+		if !found {
+			return true, fmt.Errorf("invalid index line: %s", line)
+		}
+		if f.state != textPatchFileNew {
+			f.ensureFrom().AbbreviatedHash = fromhash
+		}
+		if f.state != textPatchFileDeleted {
+			f.ensureTo().AbbreviatedHash = tohash
+		}
+		if mode != "" {
+			m, err := filemode.New(mode)
+			if err != nil {
+				return true, err
+			}
+			if f.state != textPatchFileNew {
+				f.ensureFrom().mode = m
+			}
+			if f.state != textPatchFileDeleted {
+				f.ensureTo().mode = m
+			}
+		}
+		return true, nil
+	}
+	if v, ok := field("--- "); ok {
+		if v == "/dev/null" {
+			f.from = nil
+			return true, nil
+		}
+		p, err := unquotePatchPath(v)
+		if err != nil {
+			return true, err
+		}
+		f.ensureFrom().path = strings.TrimPrefix(p, "a/")
+		return true, nil
+	}
+	if v, ok := field("+++ "); ok {
+		if v == "/dev/null" {
+			f.to = nil
+			return true, nil
+		}
+		p, err := unquotePatchPath(v)
+		if err != nil {
+			return true, err
+		}
+		f.ensureTo().path = strings.TrimPrefix(p, "b/")
+		return true, nil
+	}
+	if strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch" {
+		f.isBinary = true
+		return true, nil
+	}
+	// other extended headers like similarity index are not needed.
+	if strings.HasPrefix(line, "similarity index ") || strings.HasPrefix(line, "dissimilarity index ") {
+		return true, nil
+	}
+	if f.isBinary && line != "-- " {
+		// content of GIT binary patch
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// finish fills the paths and modes that are not contained in the header lines.
+func (f *TextFilePatch) finish(gitfrom, gitto string) error {
+	switch f.state {
+	case textPatchFileNew:
+		f.from = nil
+		f.ensureTo()
+	case textPatchFileDeleted:
+		f.to = nil
+		f.ensureFrom()
+	default:
+		f.ensureFrom()
+		f.ensureTo()
+	}
+
+	if f.from != nil {
+		if f.from.path == "" {
+			f.from.path = gitfrom
+		}
+		if f.from.path == "" {
+			return errors.New("missing from path")
+		}
+	}
+	if f.to != nil {
+		if f.to.path == "" {
+			f.to.path = gitto
+		}
+		if f.to.path == "" {
+			return errors.New("missing to path")
+		}
+	}
+	if f.from != nil && f.to != nil {
+		if f.from.mode == filemode.Empty {
+			f.from.mode = f.to.mode
+		}
+		if f.to.mode == filemode.Empty {
+			f.to.mode = f.from.mode
+		}
+	}
+
+	return nil
+}
+
+// parseDiffGitLine parses the "a/from b/to" part of "diff --git a/from b/to" line.
+// If the paths are not quoted and contain spaces, the line is ambiguous and the paths are
+// assumed to be the same.
+func parseDiffGitLine(v string) (string, string, error) {
+	if strings.HasPrefix(v, `"`) {
+		from, rest, err := cutQuoted(v)
+		if err != nil {
+			return "", "", err
+		}
+		to, err := unquotePatchPath(strings.TrimSpace(rest))
+		if err != nil {
+			return "", "", err
+		}
+		return strings.TrimPrefix(from, "a/"), strings.TrimPrefix(to, "b/"), nil
+	}
+	if strings.HasSuffix(v, `"`) {
+		idx := strings.Index(v, ` "`)
+		if idx < 0 {
+			return "", "", fmt.Errorf("invalid diff --git line: %s", v)
+		}
+		to, err := unquotePatchPath(v[idx+1:])
+		if err != nil {
+			return "", "", err
+		}
+		return strings.TrimPrefix(v[:idx], "a/"), strings.TrimPrefix(to, "b/"), nil
+	}
+
+	// both sides have the same length when the paths are the same.
+	if len(v)%2 == 1 {
+		half := len(v) / 2
+		from, to := v[:half], v[half+1:]
+		if strings.TrimPrefix(from, "a/") == strings.TrimPrefix(to, "b/") {
+			return strings.TrimPrefix(from, "a/"), strings.TrimPrefix(to, "b/"), nil
+		}
+	}
+
+	from, to, found := strings.Cut(v, " b/")
+	if !found {
+		return "", "", fmt.Errorf("invalid diff --git line: %s", v)
+	}
+	return strings.TrimPrefix(from, "a/"), to, nil
+}
+
+func cutQuoted(v string) (string, string, error) {
+	for i := 1; i < len(v); i++ {
+		switch v[i] {
+		case '\\':
+			i++
+		case '"':
+			s, err := strconv.Unquote(v[:i+1])
+			if err != nil {
+				return "", "", err
+			}
+			return s, v[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("unterminated quote: %s", v)
+}
+
+// unquotePatchPath removes the c-style quotes git adds to paths with special characters,
+// and also the trailing tab git adds to paths containing spaces.
+func unquotePatchPath(v string) (string, error) {
+	if strings.HasPrefix(v, `"`) {
+		s, _, err := cutQuoted(v)
+		return s, err
+	}
+	return strings.TrimSuffix(v, "\t"), nil
+}
+
+// parseHunkHeader parses "@@ -a,b +c,d @@ section".
+func parseHunkHeader(line string) (*TextPatchHunk, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[0] != "@@" || fields[3] != "@@" || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return nil, fmt.Errorf("invalid hunk header: %s", line)
+	}
+
+	parseRange := func(s string) (int, int, error) {
+		start, count, found := strings.Cut(s, ",")
+		l, err := strconv.Atoi(start)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid hunk header %s: %w", line, err)
+		}
+		if !found {
+			return l, 1, nil
+		}
+		c, err := strconv.Atoi(count)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid hunk header %s: %w", line, err)
+		}
+		return l, c, nil
+	}
+
+	h := &TextPatchHunk{}
+	var err error
+	h.FromLine, h.FromCount, err = parseRange(fields[1][1:])
+	if err != nil {
+		return nil, err
+	}
+	h.ToLine, h.ToCount, err = parseRange(fields[2][1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}